@@ -0,0 +1,233 @@
+// Package alert pages operators on notable events through a generic JSON
+// webhook and/or a Telegram bot.
+package alert
+
+import (
+    "bytes"
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "math/big"
+    "net/http"
+    "net/url"
+    "strings"
+    "sync"
+    "time"
+
+    "github.com/hypercore-suite/arbitrage/config"
+    "github.com/hypercore-suite/arbitrage/pricing"
+    "github.com/sirupsen/logrus"
+)
+
+type Kind string
+
+const (
+    KindLargeProfit         Kind = "large_profit"
+    KindConsecutiveFailures Kind = "consecutive_failures"
+    KindBreakerTripped      Kind = "breaker_tripped"
+    KindLowGasBalance       Kind = "low_gas_balance"
+    KindNoOpportunities     Kind = "no_opportunities"
+)
+
+// Event is one alert. It is also the JSON body posted to the webhook.
+type Event struct {
+    Kind       Kind      `json:"kind"`
+    Asset      uint32    `json:"asset"`
+    Message    string    `json:"message"`
+    Time       time.Time `json:"time"`
+    Suppressed int       `json:"suppressed,omitempty"`
+}
+
+// Alerter turns execution outcomes into events and delivers them from a
+// single goroutine. Each kind is sent at most once per minInterval; events
+// dropped by the limit are counted on the next one that goes out.
+type Alerter struct {
+    logger *logrus.Logger
+    client *http.Client
+    events chan Event
+    
+    webhookURL       string
+    telegramToken    string
+    telegramChatID   string
+    profitThreshold  *big.Int
+    failureThreshold int
+    minInterval      time.Duration
+    
+    mu       sync.Mutex
+    failures int
+    
+    lastSent   map[Kind]time.Time
+    suppressed map[Kind]int
+}
+
+func New(logger *logrus.Logger, cfg config.AlertConfig) *Alerter {
+    return &Alerter{
+        logger:           logger,
+        client:           &http.Client{Timeout: 10 * time.Second},
+        events:           make(chan Event, 32),
+        webhookURL:       cfg.WebhookURL,
+        telegramToken:    cfg.TelegramToken,
+        telegramChatID:   cfg.TelegramChatID,
+        profitThreshold:  big.NewInt(cfg.ProfitThreshold),
+        failureThreshold: cfg.FailureThreshold,
+        minInterval:      cfg.MinInterval,
+        lastSent:         make(map[Kind]time.Time),
+        suppressed:       make(map[Kind]int),
+    }
+}
+
+// Start delivers queued events until ctx is cancelled.
+func (a *Alerter) Start(ctx context.Context) {
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case ev := <-a.events:
+            a.deliver(ctx, ev)
+        }
+    }
+}
+
+// Execution reports a settled execution. Profit is in USD at
+// trade.PriceDecimals.
+func (a *Alerter) Execution(asset uint32, profit *big.Int, success bool) {
+    a.mu.Lock()
+    if success {
+        a.failures = 0
+    } else {
+        a.failures++
+    }
+    failures := a.failures
+    a.mu.Unlock()
+    
+    switch {
+    case success && a.profitThreshold.Sign() > 0 && profit.Cmp(a.profitThreshold) >= 0:
+        a.notify(Event{
+            Kind:    KindLargeProfit,
+            Asset:   asset,
+            Message: fmt.Sprintf("Arbitrage on asset %d made %s USD", asset, usd(profit)),
+        })
+    case !success && a.failureThreshold > 0 && failures >= a.failureThreshold:
+        a.notify(Event{
+            Kind:    KindConsecutiveFailures,
+            Asset:   asset,
+            Message: fmt.Sprintf("%d consecutive failed executions, last on asset %d", failures, asset),
+        })
+    }
+}
+
+// BreakerTripped reports that the circuit breaker halted trading.
+func (a *Alerter) BreakerTripped() {
+    a.notify(Event{
+        Kind:    KindBreakerTripped,
+        Message: "Circuit breaker tripped; trading is paused",
+    })
+}
+
+// LowGasBalance reports that wallet's native balance, in wei, fell below the
+// gas floor and the wallet stopped sending.
+func (a *Alerter) LowGasBalance(wallet string, balance *big.Int) {
+    a.notify(Event{
+        Kind:    KindLowGasBalance,
+        Message: fmt.Sprintf("Wallet %s has %.4f of gas token left; it is paused until topped up", wallet, pricing.ToUSD(balance, pricing.NativeDecimals)),
+    })
+}
+
+// NoOpportunities reports that label, an asset or "all" for every asset, has
+// gone idle without a detected opportunity, which usually means a broken
+// price feed or misconfigured thresholds.
+func (a *Alerter) NoOpportunities(label string, idle time.Duration) {
+    scope := "asset " + label
+    if label == "all" {
+        scope = "any asset"
+    }
+    a.notify(Event{
+        Kind:    KindNoOpportunities,
+        Message: fmt.Sprintf("No arbitrage opportunities on %s for %s; check the price feeds and thresholds", scope, idle.Round(time.Second)),
+    })
+}
+
+// notify queues ev without blocking the caller; a full queue drops it.
+func (a *Alerter) notify(ev Event) {
+    ev.Time = time.Now()
+    
+    select {
+    case a.events <- ev:
+    default:
+        a.logger.WithField("kind", ev.Kind).Warn("Alert queue full, dropping alert")
+    }
+}
+
+func (a *Alerter) deliver(ctx context.Context, ev Event) {
+    if last, ok := a.lastSent[ev.Kind]; ok && ev.Time.Sub(last) < a.minInterval {
+        a.suppressed[ev.Kind]++
+        return
+    }
+    a.lastSent[ev.Kind] = ev.Time
+    ev.Suppressed = a.suppressed[ev.Kind]
+    a.suppressed[ev.Kind] = 0
+    
+    if a.webhookURL != "" {
+        if err := a.post(ctx, a.webhookURL, ev); err != nil {
+            a.logger.WithError(err).WithField("kind", ev.Kind).Warn("Webhook alert failed")
+        }
+    }
+    
+    if a.telegramToken != "" {
+        text := ev.Message
+        if ev.Suppressed > 0 {
+            text = fmt.Sprintf("%s (%d similar alerts suppressed)", text, ev.Suppressed)
+        }
+        
+        endpoint := "https://api.telegram.org/bot" + a.telegramToken + "/sendMessage"
+        body := map[string]string{"chat_id": a.telegramChatID, "text": text}
+        if err := a.post(ctx, endpoint, body); err != nil {
+            a.logger.WithError(redactToken(err, a.telegramToken)).WithField("kind", ev.Kind).Warn("Telegram alert failed")
+        }
+    }
+}
+
+func (a *Alerter) post(ctx context.Context, endpoint string, body interface{}) error {
+    payload, err := json.Marshal(body)
+    if err != nil {
+        return err
+    }
+    
+    req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+    if err != nil {
+        return err
+    }
+    req.Header.Set("Content-Type", "application/json")
+    
+    resp, err := a.client.Do(req)
+    if err != nil {
+        return err
+    }
+    resp.Body.Close()
+    
+    if resp.StatusCode >= 300 {
+        return fmt.Errorf("unexpected status %s", resp.Status)
+    }
+    return nil
+}
+
+// redactToken masks token in the request URL a failed post reports, so the
+// Telegram bot token embedded in it is not logged.
+func redactToken(err error, token string) error {
+    var urlErr *url.Error
+    if !errors.As(err, &urlErr) {
+        return err
+    }
+    redacted := *urlErr
+    redacted.URL = strings.ReplaceAll(urlErr.URL, token, "<redacted>")
+    return &redacted
+}
+
+// usd formats a 1e8 fixed-point USD amount with two decimals, rounded to the
+// nearest cent.
+func usd(amount *big.Int) string {
+    cents := pricing.Div(amount, pricing.Pow10(pricing.USDDecimals-2), pricing.RoundHalfUp)
+    whole, frac := new(big.Int).QuoRem(cents, big.NewInt(100), new(big.Int))
+    return fmt.Sprintf("%s.%02d", whole, frac.Abs(frac).Int64())
+}
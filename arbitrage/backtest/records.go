@@ -0,0 +1,142 @@
+package backtest
+
+import (
+    "bufio"
+    "encoding/csv"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "math/big"
+    "os"
+    "path/filepath"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/hypercore-suite/arbitrage/detector"
+)
+
+// LoadQuotes reads a recorded price series. Files ending in .csv need a
+// header naming timestamp, asset, perp_price and spot_price columns; any
+// other file is read as JSON lines with the same keys. Timestamps are
+// RFC 3339 or unix milliseconds, prices are integers at
+// trade.PriceDecimals. Quotes are returned in file order.
+func LoadQuotes(path string) ([]detector.Quote, error) {
+    f, err := os.Open(path)
+    if err != nil {
+        return nil, fmt.Errorf("open price series: %w", err)
+    }
+    defer f.Close()
+    
+    if strings.EqualFold(filepath.Ext(path), ".csv") {
+        return readCSV(f)
+    }
+    return readJSONL(f)
+}
+
+func readCSV(r io.Reader) ([]detector.Quote, error) {
+    reader := csv.NewReader(r)
+    reader.TrimLeadingSpace = true
+    
+    header, err := reader.Read()
+    if err != nil {
+        return nil, fmt.Errorf("read csv header: %w", err)
+    }
+    
+    columns := make(map[string]int)
+    for i, name := range header {
+        columns[strings.ToLower(strings.TrimSpace(name))] = i
+    }
+    for _, name := range []string{"timestamp", "asset", "perp_price", "spot_price"} {
+        if _, ok := columns[name]; !ok {
+            return nil, fmt.Errorf("csv header is missing %q", name)
+        }
+    }
+    
+    var quotes []detector.Quote
+    for line := 2; ; line++ {
+        row, err := reader.Read()
+        if errors.Is(err, io.EOF) {
+            return quotes, nil
+        }
+        if err != nil {
+            return nil, fmt.Errorf("read csv line %d: %w", line, err)
+        }
+        
+        q, err := parseQuote(row[columns["timestamp"]], row[columns["asset"]], row[columns["perp_price"]], row[columns["spot_price"]])
+        if err != nil {
+            return nil, fmt.Errorf("csv line %d: %w", line, err)
+        }
+        quotes = append(quotes, q)
+    }
+}
+
+type jsonQuote struct {
+    Timestamp json.RawMessage `json:"timestamp"`
+    Asset     uint32          `json:"asset"`
+    PerpPrice json.Number     `json:"perp_price"`
+    SpotPrice json.Number     `json:"spot_price"`
+}
+
+func readJSONL(r io.Reader) ([]detector.Quote, error) {
+    var quotes []detector.Quote
+    
+    scanner := bufio.NewScanner(r)
+    for line := 1; scanner.Scan(); line++ {
+        text := strings.TrimSpace(scanner.Text())
+        if text == "" {
+            continue
+        }
+        
+        var raw jsonQuote
+        dec := json.NewDecoder(strings.NewReader(text))
+        dec.UseNumber()
+        if err := dec.Decode(&raw); err != nil {
+            return nil, fmt.Errorf("jsonl line %d: %w", line, err)
+        }
+        
+        q, err := parseQuote(strings.Trim(string(raw.Timestamp), `"`), strconv.FormatUint(uint64(raw.Asset), 10), raw.PerpPrice.String(), raw.SpotPrice.String())
+        if err != nil {
+            return nil, fmt.Errorf("jsonl line %d: %w", line, err)
+        }
+        quotes = append(quotes, q)
+    }
+    
+    return quotes, scanner.Err()
+}
+
+func parseQuote(timestamp, asset, perpPrice, spotPrice string) (detector.Quote, error) {
+    at, err := parseTimestamp(strings.TrimSpace(timestamp))
+    if err != nil {
+        return detector.Quote{}, err
+    }
+    
+    id, err := strconv.ParseUint(strings.TrimSpace(asset), 10, 32)
+    if err != nil {
+        return detector.Quote{}, fmt.Errorf("asset %q is not a valid id", asset)
+    }
+    
+    perp, ok := new(big.Int).SetString(strings.TrimSpace(perpPrice), 10)
+    if !ok {
+        return detector.Quote{}, fmt.Errorf("invalid perp price %q", perpPrice)
+    }
+    spot, ok := new(big.Int).SetString(strings.TrimSpace(spotPrice), 10)
+    if !ok {
+        return detector.Quote{}, fmt.Errorf("invalid spot price %q", spotPrice)
+    }
+    
+    return detector.Quote{Time: at, Asset: uint32(id), PerpPrice: perp, SpotPrice: spot}, nil
+}
+
+func parseTimestamp(raw string) (time.Time, error) {
+    if millis, err := strconv.ParseInt(raw, 10, 64); err == nil {
+        return time.UnixMilli(millis), nil
+    }
+    
+    at, err := time.Parse(time.RFC3339Nano, raw)
+    if err != nil {
+        return time.Time{}, fmt.Errorf("invalid timestamp %q", raw)
+    }
+    return at, nil
+}
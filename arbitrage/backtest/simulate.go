@@ -0,0 +1,70 @@
+package backtest
+
+import (
+    "math/big"
+    "time"
+
+    "github.com/hypercore-suite/arbitrage/config"
+    "github.com/hypercore-suite/arbitrage/detector"
+    "github.com/hypercore-suite/arbitrage/executor"
+    "github.com/sirupsen/logrus"
+)
+
+// Verdict is the live decision for one hypothetical quote. SpreadBps is the
+// raw perp/spot spread; the rest is set only when the detector reports an
+// opportunity. Amounts and profits are in USD at trade.PriceDecimals.
+type Verdict struct {
+    Opportunity  bool     `json:"opportunity"`
+    SpreadBps    int64    `json:"spread_bps"`
+    NetSpreadBps int64    `json:"net_spread_bps,omitempty"`
+    IsBuy        bool     `json:"is_buy,omitempty"`
+    Amount       *big.Int `json:"amount,omitempty"`
+    Executed     bool     `json:"executed"`
+    Rejected     string   `json:"rejected,omitempty"`
+    GrossProfit  *big.Int `json:"gross_profit,omitempty"`
+    NetProfit    *big.Int `json:"net_profit,omitempty"`
+}
+
+// Simulator evaluates single quotes through the same detection and execution
+// decisions as Run. It is safe for concurrent use; every quote is judged on
+// its own, without deduplication against earlier ones.
+type Simulator struct {
+    logger *logrus.Logger
+    cfg    *config.Config
+}
+
+// NewSimulator returns a simulator configured from cfg.
+func NewSimulator(logger *logrus.Logger, cfg *config.Config) *Simulator {
+    return &Simulator{logger: logger, cfg: cfg}
+}
+
+// Simulate judges q as if it were the current oracle state. A zero q.Time
+// means now.
+func (s *Simulator) Simulate(q detector.Quote) Verdict {
+    if q.Time.IsZero() {
+        q.Time = time.Now()
+    }
+    
+    verdict := Verdict{}
+    if q.PerpPrice != nil && q.SpotPrice != nil {
+        spread := new(big.Int).Sub(q.PerpPrice, q.SpotPrice)
+        verdict.SpreadBps = detector.SpreadBps(spread.Abs(spread), q.PerpPrice, q.SpotPrice)
+    }
+    
+    det := detector.NewReplayDetector(s.logger, &recorder{}, s.cfg)
+    opp := det.Evaluate(q)
+    if opp == nil {
+        return verdict
+    }
+    verdict.Opportunity = true
+    verdict.NetSpreadBps = detector.SpreadBps(opp.NetSpread, opp.CorePrice, opp.EVMPrice)
+    verdict.IsBuy = opp.IsBuy
+    verdict.Amount = opp.Amount
+    
+    result := executor.NewBacktestExecutor(s.logger, s.cfg).Backtest(opp, q.Time)
+    verdict.Executed = result.Executed
+    verdict.Rejected = string(result.Rejected)
+    verdict.GrossProfit = result.GrossProfit
+    verdict.NetProfit = result.NetProfit
+    return verdict
+}
\ No newline at end of file
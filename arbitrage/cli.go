@@ -0,0 +1,226 @@
+package main
+
+import (
+    "bytes"
+    "context"
+    "encoding/json"
+    "flag"
+    "fmt"
+    "io"
+    "net/http"
+    "os"
+    "strings"
+    "time"
+
+    "github.com/hypercore-suite/arbitrage/config"
+    "github.com/hypercore-suite/arbitrage/detector"
+    "github.com/hypercore-suite/arbitrage/executor"
+    "github.com/hypercore-suite/arbitrage/monitoring"
+    "github.com/hypercore-suite/arbitrage/preflight"
+    "github.com/ethereum/go-ethereum/ethclient"
+    "github.com/joho/godotenv"
+    "github.com/sirupsen/logrus"
+)
+
+const usage = `Usage: arbitrage [command] [-config file] [flags]
+
+Commands:
+  run       trade live until interrupted (default)
+  backtest  replay a CSV or JSONL price series and report the theoretical PnL
+  simulate  scan live prices once and report the opportunities found
+  preflight check the configuration, RPCs, wallets, contracts and oracles
+  stats     print the /stats of a running instance
+  pnl       report the net PnL recorded in the trade ledger
+`
+
+// preflightTimeout bounds the whole preflight, so an unreachable endpoint
+// fails it instead of hanging startup.
+const preflightTimeout = 30 * time.Second
+
+// snapshotTimeout bounds the simulate command's scan of live prices.
+const snapshotTimeout = 30 * time.Second
+
+// commands maps each subcommand to its entry point. Every command loads the
+// configuration the same way through loadConfig.
+var commands = map[string]func(args []string){
+    "run":       runLive,
+    "backtest":  runBacktest,
+    "simulate":  runSimulate,
+    "preflight": runPreflightCommand,
+    "stats":     runStats,
+    "pnl":       reportPnL,
+}
+
+func main() {
+    name, args := "run", os.Args[1:]
+    if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+        name, args = args[0], args[1:]
+    }
+    
+    command, ok := commands[name]
+    if !ok {
+        fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", name, usage)
+        os.Exit(2)
+    }
+    command(args)
+}
+
+// loadConfig adds the shared -config flag to fs, parses args and returns the
+// resolved configuration and a logger at its level.
+func loadConfig(fs *flag.FlagSet, args []string) (*config.Config, *logrus.Logger) {
+    configPath := fs.String("config", "", "path to a YAML config file; environment variables override its values")
+    fs.Usage = func() {
+        fmt.Fprint(fs.Output(), usage)
+        fmt.Fprintf(fs.Output(), "\nFlags for %s:\n", fs.Name())
+        fs.PrintDefaults()
+    }
+    fs.Parse(args)
+    
+    if err := godotenv.Load(); err != nil {
+        logrus.Warn("No .env file found")
+    }
+    
+    cfg, err := config.Load(*configPath)
+    if err != nil {
+        logrus.Fatal("Invalid configuration: ", err)
+    }
+    return cfg, setupLogger(cfg)
+}
+
+// runSimulate is the simulate command: it reads live prices once for every
+// configured asset and logs each opportunity with the result the executor's
+// validation and simulation would give it. Nothing is signed or sent.
+func runSimulate(args []string) {
+    cfg, logger := loadConfig(flag.NewFlagSet("simulate", flag.ExitOnError), args)
+    
+    det, err := detector.NewDetector(logger, monitoring.NewMonitor(cfg.PnLWindows, cfg.Registry()), cfg)
+    if err != nil {
+        logger.Fatal("Failed to create detector:", err)
+    }
+    
+    exec := executor.NewBacktestExecutor(logger, cfg)
+    now := time.Now()
+    
+    ctx, cancel := context.WithTimeout(context.Background(), snapshotTimeout)
+    defer cancel()
+    opportunities := det.Snapshot(ctx)
+    for _, opp := range opportunities {
+        result := exec.Backtest(opp, now)
+        logger.WithFields(opp.LogFields()).WithFields(logrus.Fields{
+            "spread_bps":   opp.SpreadBps,
+            "net_spread":   opp.NetSpread,
+            "amount":       opp.Amount,
+            "is_buy":       opp.IsBuy,
+            "would_trade":  result.Executed,
+            "rejected":     result.Rejected,
+            "gross_profit": result.GrossProfit,
+            "net_profit":   result.NetProfit,
+        }).Info("Simulated opportunity")
+    }
+    
+    logger.WithFields(logrus.Fields{
+        "assets":        len(cfg.Detector.Assets),
+        "opportunities": len(opportunities),
+    }).Info("Simulation complete")
+}
+
+// runPreflightCommand is the preflight command: it runs the startup checks
+// without trading and exits non-zero when any fail.
+func runPreflightCommand(args []string) {
+    cfg, logger := loadConfig(flag.NewFlagSet("preflight", flag.ExitOnError), args)
+    
+    det, err := detector.NewDetector(logger, monitoring.NewMonitor(cfg.PnLWindows, cfg.Registry()), cfg)
+    if err != nil {
+        logger.Fatal("Failed to create detector:", err)
+    }
+    
+    if err := runPreflight(logger, cfg, det.Prices); err != nil {
+        logger.Fatal(err)
+    }
+}
+
+// runPreflight dials both RPC endpoints, runs every preflight check against
+// them and logs the outcome of each, returning the combined failure.
+func runPreflight(logger *logrus.Logger, cfg *config.Config, prices detector.PriceSource) error {
+    ctx, cancel := context.WithTimeout(context.Background(), preflightTimeout)
+    defer cancel()
+    
+    core, err := ethclient.DialContext(ctx, cfg.RPC.CoreURL)
+    if err != nil {
+        return fmt.Errorf("dial HyperCore RPC %s: %w", cfg.RPC.CoreURL, err)
+    }
+    defer core.Close()
+    
+    evm, err := ethclient.DialContext(ctx, cfg.RPC.EVMURL)
+    if err != nil {
+        return fmt.Errorf("dial HyperEVM RPC %s: %w", cfg.RPC.EVMURL, err)
+    }
+    defer evm.Close()
+    
+    report := preflight.Run(ctx, cfg, core, evm, prices)
+    for _, check := range report {
+        if check.Err != nil {
+            logger.WithError(check.Err).WithField("check", check.Name).Error("Preflight check failed")
+        } else {
+            logger.WithField("check", check.Name).Info("Preflight check passed")
+        }
+    }
+    return report.Err()
+}
+
+// runStats is the stats command: it fetches /stats from a running instance
+// and prints it as indented JSON.
+func runStats(args []string) {
+    fs := flag.NewFlagSet("stats", flag.ExitOnError)
+    addr := fs.String("addr", "", "monitoring address of the running instance (default: metrics_addr)")
+    token := fs.String("token", "", "bearer token for /stats (default: stats_token)")
+    cfg, logger := loadConfig(fs, args)
+    
+    if *addr == "" {
+        *addr = cfg.MetricsAddr
+    }
+    if *token == "" {
+        *token = cfg.StatsToken
+    }
+    if strings.HasPrefix(*addr, ":") {
+        *addr = "localhost" + *addr
+    }
+    if !strings.Contains(*addr, "://") {
+        scheme := "http://"
+        if cfg.TLSEnabled() {
+            scheme = "https://"
+        }
+        *addr = scheme + *addr
+    }
+    
+    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    defer cancel()
+    
+    req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(*addr, "/")+"/stats", nil)
+    if err != nil {
+        logger.Fatal("Invalid stats address: ", err)
+    }
+    if *token != "" {
+        req.Header.Set("Authorization", "Bearer "+*token)
+    }
+    
+    resp, err := http.DefaultClient.Do(req)
+    if err != nil {
+        logger.Fatal("Failed to fetch stats: ", err)
+    }
+    defer resp.Body.Close()
+    
+    body, err := io.ReadAll(resp.Body)
+    if err != nil {
+        logger.Fatal("Failed to read stats: ", err)
+    }
+    if resp.StatusCode != http.StatusOK {
+        logger.Fatalf("Stats request failed: %s", resp.Status)
+    }
+    
+    var out bytes.Buffer
+    if err := json.Indent(&out, body, "", "  "); err != nil {
+        logger.Fatal("Malformed stats response: ", err)
+    }
+    fmt.Println(out.String())
+}
\ No newline at end of file
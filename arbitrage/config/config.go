@@ -0,0 +1,1142 @@
+package config
+
+import (
+    "errors"
+    "fmt"
+    "io"
+    "math/big"
+    "os"
+    "strings"
+    "time"
+
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/hypercore-suite/arbitrage/pricing"
+    "github.com/hypercore-suite/arbitrage/registry"
+    "github.com/sirupsen/logrus"
+    "gopkg.in/yaml.v3"
+)
+
+// testPrivateKey is the well-known key with scalar 1. It is refused when
+// Production is set.
+const testPrivateKey = "0000000000000000000000000000000000000000000000000000000000000001"
+
+// maxCycleHops bounds detector.cycle_max_hops; the cycles searched grow
+// exponentially with it.
+const maxCycleHops = 6
+
+const (
+    DefaultCoreRPCURL = "https://rpc.hyperliquid.xyz/evm"
+    DefaultEVMRPCURL  = "https://rpc.hyperliquid.xyz/evm"
+    DefaultWSURL      = "wss://ws.hyperliquid.xyz/evm"
+)
+
+// Config is the fully-resolved bot configuration. Values come from the
+// defaults below, then the YAML file, then environment variables.
+//
+// Logs go to stdout as LogFormat, "json" or "text", and also to LogFile when
+// its path is set. PnLWindows are the trailing windows per-asset realized
+// PnL is reported over. On shutdown the executor spends up to DrainTimeout
+// executing opportunities the detector had already queued, then up to
+// ShutdownTimeout waiting for executions to settle; zero DrainTimeout
+// abandons queued opportunities.
+//
+// ControlToken is the bearer token for the pause, resume, asset, breaker
+// reset and POST /config endpoints, which are disabled while it is empty.
+// Assets disabled at runtime are saved to AssetStatePath; an empty path keeps
+// them in memory only. The monitoring server uses TLS when both TLSCertFile
+// and TLSKeyFile are set. A non-empty StatsToken is required as a bearer
+// token on /stats and the other read endpoints, and on /metrics too when
+// ProtectMetrics is set. OpportunityHistory is how many recent opportunities
+// GET /opportunities can return.
+//
+// AssetRegistry names the assets other settings may refer to by symbol.
+type Config struct {
+    LogLevel        string          `yaml:"log_level"`
+    LogFormat       string          `yaml:"log_format"`
+    LogFile         LogFileConfig   `yaml:"log_file"`
+    MetricsAddr     string          `yaml:"metrics_addr"`
+    ShutdownTimeout time.Duration   `yaml:"shutdown_timeout"`
+    DrainTimeout    time.Duration   `yaml:"drain_timeout"`
+    Production      bool            `yaml:"production"`
+    PnLWindows      []time.Duration `yaml:"pnl_windows"`
+    ControlToken    string          `yaml:"control_token"`
+    AssetStatePath  string          `yaml:"asset_state_path"`
+    TLSCertFile     string          `yaml:"tls_cert_file"`
+    TLSKeyFile      string          `yaml:"tls_key_file"`
+    StatsToken      string          `yaml:"stats_token"`
+    ProtectMetrics  bool            `yaml:"protect_metrics"`
+    
+    OpportunityHistory int `yaml:"opportunity_history"`
+    
+    AssetRegistry []registry.Asset `yaml:"asset_registry"`
+    assets        *registry.Registry
+    
+    RPC      RPCConfig      `yaml:"rpc"`
+    Detector DetectorConfig `yaml:"detector"`
+    Executor ExecutorConfig `yaml:"executor"`
+    Breaker  BreakerConfig  `yaml:"breaker"`
+    Alerts   AlertConfig    `yaml:"alerts"`
+}
+
+// RPCConfig holds the node endpoints. CallTimeout bounds each individual
+// RPC call, so a hung node fails the call instead of stalling a scan or an
+// execution.
+type RPCConfig struct {
+    CoreURL     string        `yaml:"core_url"`
+    EVMURL      string        `yaml:"evm_url"`
+    WSURL       string        `yaml:"ws_url"`
+    MaxRetries  int           `yaml:"max_retries"`
+    RedialAfter int           `yaml:"redial_after"`
+    CallTimeout time.Duration `yaml:"call_timeout"`
+}
+
+// DetectorConfig holds the scanning settings. Assets is resolved from
+// AssetRefs, which may name assets by id or registry symbol. Trade sizes are in
+// PriceDecimals (1e8) fixed point; thresholds are in basis points. An
+// opportunity repeating one emitted within DedupWindow is dropped unless its
+// spread moved by DedupMinChangeBps. PollJitter and SizeJitterBps randomize
+// timing and size to be less predictable; both default to off.
+// AdaptivePoll stretches the poll interval, up to MaxPollInterval, while the
+// executor is not keeping up with the opportunities channel.
+// WatchMempool scans pending transactions for arbitrage calls to the
+// configured route contracts or CompetitorAddresses; an asset stays contested
+// for CompetitionWindow after the last one seen, and its spread threshold is
+// raised by CompetitionSpreadBps meanwhile.
+// Opportunities are sized at an asset's AssetTradeSize, else its
+// AssetTargetNotional or the global TargetNotional (1e8 USD) divided by the
+// price, else MaxTradeSize; AssetMaxNotional then caps each asset's notional.
+// CyclePairs are the spot pairs searched for multi-hop cycles of up to
+// CycleMaxHops trades from CycleStartToken back to it; a cycle gaining at
+// least CycleMinProfitBps after every hop's fee is emitted, sized at
+// MaxTradeSize units of the start token.
+// OracleLayout selects how the price precompiles' results are decoded: "v1"
+// reads fixed 6 (perp) and 8 (spot) decimal prices with an optional update
+// time, "v2" scales them by each asset's registry size decimals. A decoded
+// price outside its asset's OracleAssetBounds, else [OracleMinPrice,
+// OracleMaxPrice], in 1e8 USD, or moving more than OracleMaxJumpBps from the
+// previous read is refused; zero disables each check.
+type DetectorConfig struct {
+    Mode              string           `yaml:"mode"`
+    PollInterval      time.Duration    `yaml:"poll_interval"`
+    AssetRefs         []string         `yaml:"assets"`
+    Assets            []uint32         `yaml:"-"`
+    MinSpreadBps      int64            `yaml:"min_spread_bps"`
+    AssetMinSpreadBps map[uint32]int64 `yaml:"asset_min_spread_bps"`
+    HoldingPeriod     time.Duration    `yaml:"holding_period"`
+    MaxSlippageBps    int64            `yaml:"max_slippage_bps"`
+    MinTradeSize      int64            `yaml:"min_trade_size"`
+    MaxTradeSize      int64            `yaml:"max_trade_size"`
+    MaxPriceAge       time.Duration    `yaml:"max_price_age"`
+    FundingPrecompile string           `yaml:"funding_precompile"`
+    DepthPrecompile   string           `yaml:"depth_precompile"`
+    DedupWindow       time.Duration    `yaml:"dedup_window"`
+    DedupMinChangeBps int64            `yaml:"dedup_min_change_bps"`
+    ScanWorkers       int              `yaml:"scan_workers"`
+    PollJitter        time.Duration    `yaml:"poll_jitter"`
+    SizeJitterBps     int64            `yaml:"size_jitter_bps"`
+    AdaptivePoll      bool             `yaml:"adaptive_poll"`
+    MaxPollInterval   time.Duration    `yaml:"max_poll_interval"`
+    
+    WatchMempool         bool          `yaml:"watch_mempool"`
+    CompetitorAddresses  []string      `yaml:"competitor_addresses"`
+    CompetitionWindow    time.Duration `yaml:"competition_window"`
+    CompetitionSpreadBps int64         `yaml:"competition_spread_bps"`
+    
+    TargetNotional      int64            `yaml:"target_notional"`
+    AssetTradeSize      map[uint32]int64 `yaml:"asset_trade_size"`
+    AssetTargetNotional map[uint32]int64 `yaml:"asset_target_notional"`
+    AssetMaxNotional    map[uint32]int64 `yaml:"asset_max_notional"`
+    
+    CyclePairs        []CyclePairConfig `yaml:"cycle_pairs"`
+    CycleStartToken   string            `yaml:"cycle_start_token"`
+    CycleMaxHops      int               `yaml:"cycle_max_hops"`
+    CycleMinProfitBps int64             `yaml:"cycle_min_profit_bps"`
+    
+    OracleLayout      string                 `yaml:"oracle_layout"`
+    OracleMinPrice    int64                  `yaml:"oracle_min_price"`
+    OracleMaxPrice    int64                  `yaml:"oracle_max_price"`
+    OracleAssetBounds map[uint32]PriceBounds `yaml:"oracle_asset_bounds"`
+    OracleMaxJumpBps  int64                  `yaml:"oracle_max_jump_bps"`
+}
+
+// PriceBounds is the range, in 1e8 USD, an asset's decoded oracle prices must
+// fall within. It replaces the global range for that asset; zero disables
+// each side.
+type PriceBounds struct {
+    Min int64 `yaml:"min"`
+    Max int64 `yaml:"max"`
+}
+
+// CyclePairConfig is a spot pair cycles may trade through. Pair is the index
+// the spot oracle prices it under, as Quote per Base, and FeeBps the taker
+// fee charged on each trade through it.
+type CyclePairConfig struct {
+    Pair   uint32 `yaml:"pair"`
+    Base   string `yaml:"base"`
+    Quote  string `yaml:"quote"`
+    FeeBps int64  `yaml:"fee_bps"`
+}
+
+// ExecutorConfig holds the transaction settings. PrivateKeys adds wallets to
+// the one in PrivateKey; executions rotate across all of them. For WarmUp
+// after start the executor paper-trades as in DryRun, then goes live on its
+// own. MaxGasPrice is in wei, NativeTokenPrice is the USD price of the gas
+// token in 1e8 fixed point and the fee settings are each leg's taker fee in
+// basis points of notional, negative for a maker-style rebate; AssetFees
+// replaces them on every route for the assets it lists, for fee tiers that
+// differ by market. When NativePriceAsset names an asset, by id or registry
+// symbol, gas is priced at its spot oracle price instead, with
+// NativeTokenPrice as the fallback while the oracle has none. Trading live
+// needs one of the two.
+// MinNetProfit is the smallest post-cost profit worth sending, in 1e8 USD, or
+// in dollars through MinNetProfitUSD; MaxGasProfitRatioBps skips trades whose
+// gas at the current price exceeds that share of gross profit, with zero
+// disabling the check. Sent trades revert once the perp/spot price gap falls
+// below the net spread less SlippageToleranceBps of the spot price.
+// Simulation charges each leg half its bid/ask spread, SpotHalfSpreadBps and
+// PerpHalfSpreadBps of notional, on top of its taker fee; the spot
+// half-spread is skipped when the fill price was walked from the order book.
+// Settled trades are appended to LedgerPath when it is set, and arbitrage
+// transactions go through the private relay at RelayURL when it is set.
+// Each wallet's next nonce and unmined transactions are saved to
+// NonceStatePath, when it is set, and reconciled with the chain on startup.
+// A transaction unmined after ReplaceAfter is resent with the same nonce and
+// ReplaceBumpPercent higher fees, as a "speedup" or a "cancel"; zero
+// ReplaceAfter disables replacement. A broadcast that fails transiently is
+// retried up to SendRetries times, waiting SendRetryBackoff, doubled after
+// each attempt, in between.
+// Arbitrage transactions tip PriorityFeeBase plus PriorityFeePerDollar for
+// each dollar of expected profit, in wei per gas, with the profit share
+// fading out as the opportunity ages towards MaxOpportunityAge and the whole
+// tip capped at PriorityFeeMax; with neither base nor rate set they pay the
+// node's suggested tip.
+// Every GasBalanceInterval each wallet's native balance is checked against
+// MinGasBalance, in wei; a wallet below it sends nothing until topped up,
+// and zero disables the check. Trading is halted while a file exists at
+// KillSwitchPath, when it is set.
+// LotSizes holds each asset's venue order size limits; assets without an
+// entry are rounded to their registry decimals. MaxExposure caps each
+// wallet's spot balance and perp position per asset, in 1e8 fixed point;
+// assets without an entry are not checked.
+// An opportunity id already sent, or paper-traded, within ReplayWindow is
+// never traded again; zero disables the guard. An asset whose execution
+// failed is skipped for FailureCooldown, or until it next succeeds; zero
+// disables the cooldown.
+// Executions start at least MinExecutionInterval apart across every asset and
+// wallet; opportunities arriving sooner are skipped, and zero disables the
+// limit.
+// At most MaxConcurrentExecutions trades are in flight at once, each on its
+// own wallet; zero allows one per wallet.
+// Every ReconcileInterval the net profit recorded over the interval is
+// compared with the wallets' actual balance change, warning when they differ
+// by more than ReconcileTolerance in 1e8 USD; zero interval disables it.
+// Routes lists the arbitrage contracts to choose between; when empty the
+// executor trades through ArbContract alone at SpotFeeBps and PerpFeeBps.
+type ExecutorConfig struct {
+    PrivateKey       string        `yaml:"private_key"`
+    PrivateKeys      []string      `yaml:"private_keys"`
+    ArbContract      string        `yaml:"arb_contract"`
+    MaxGasPrice      uint64        `yaml:"max_gas_price"`
+    DefaultGasLimit  uint64        `yaml:"default_gas_limit"`
+    GasBufferPercent uint64        `yaml:"gas_buffer_percent"`
+    ReceiptTimeout   time.Duration `yaml:"receipt_timeout"`
+    DryRun           bool          `yaml:"dry_run"`
+    WarmUp           time.Duration `yaml:"warm_up"`
+    NativeTokenPrice int64         `yaml:"native_token_price"`
+    NativePriceAsset string        `yaml:"native_price_asset"`
+    SpotFeeBps       int64         `yaml:"spot_fee_bps"`
+    PerpFeeBps       int64         `yaml:"perp_fee_bps"`
+    
+    NativePriceAssetID uint32 `yaml:"-"`
+    
+    MinNetProfit         int64         `yaml:"min_net_profit"`
+    MinNetProfitUSD      string        `yaml:"min_net_profit_usd"`
+    MinSpreadBps         int64         `yaml:"min_spread_bps"`
+    MaxOpportunityAge    time.Duration `yaml:"max_opportunity_age"`
+    ReplayWindow         time.Duration `yaml:"replay_window"`
+    FailureCooldown      time.Duration `yaml:"failure_cooldown"`
+    MinExecutionInterval time.Duration `yaml:"min_execution_interval"`
+    MaxGasProfitRatioBps int64         `yaml:"max_gas_profit_ratio_bps"`
+    SlippageToleranceBps uint64        `yaml:"slippage_tolerance_bps"`
+    SpotHalfSpreadBps    uint64        `yaml:"spot_half_spread_bps"`
+    PerpHalfSpreadBps    uint64        `yaml:"perp_half_spread_bps"`
+    LedgerPath           string        `yaml:"ledger_path"`
+    NonceStatePath       string        `yaml:"nonce_state_path"`
+    RelayURL             string        `yaml:"relay_url"`
+    
+    ReplaceAfter       time.Duration `yaml:"replace_after"`
+    ReplaceBumpPercent uint64        `yaml:"replace_bump_percent"`
+    ReplaceMode        string        `yaml:"replace_mode"`
+    SendRetries        int           `yaml:"send_retries"`
+    SendRetryBackoff   time.Duration `yaml:"send_retry_backoff"`
+    
+    PriorityFeeBase      uint64 `yaml:"priority_fee_base"`
+    PriorityFeePerDollar uint64 `yaml:"priority_fee_per_dollar"`
+    PriorityFeeMax       uint64 `yaml:"priority_fee_max"`
+    
+    MaxConcurrentExecutions int           `yaml:"max_concurrent_executions"`
+    ReconcileInterval       time.Duration `yaml:"reconcile_interval"`
+    ReconcileTolerance      int64         `yaml:"reconcile_tolerance"`
+    
+    MinGasBalance      uint64        `yaml:"min_gas_balance"`
+    GasBalanceInterval time.Duration `yaml:"gas_balance_interval"`
+    KillSwitchPath     string        `yaml:"kill_switch_path"`
+    
+    Routes      []RouteConfig             `yaml:"routes"`
+    LotSizes    map[uint32]LotSizeConfig  `yaml:"lot_sizes"`
+    AssetFees   map[uint32]AssetFeeConfig `yaml:"asset_fees"`
+    MaxExposure map[uint32]int64          `yaml:"max_exposure"`
+    Capital     CapitalConfig             `yaml:"capital"`
+    Approvals   ApprovalConfig            `yaml:"approvals"`
+    Conversion  ConversionConfig          `yaml:"conversion"`
+}
+
+// RouteConfig is one arbitrage contract the executor may trade through, with
+// its own trading fees. ABI selects the contract's executeArbitrage
+// parameters: "path", the default, passes Path as the swap route; "legacy"
+// is the original parameters without one.
+type RouteConfig struct {
+    Name       string   `yaml:"name"`
+    Contract   string   `yaml:"contract"`
+    ABI        string   `yaml:"abi"`
+    Path       []string `yaml:"path"`
+    SpotFeeBps int64    `yaml:"spot_fee_bps"`
+    PerpFeeBps int64    `yaml:"perp_fee_bps"`
+}
+
+// LotSizeConfig is one asset's order size limits in 1e8 fixed point. Sizes
+// are rounded down to a multiple of Step and capped at Max. Zero Max disables
+// the cap; zero Step falls back to the asset's registry decimals.
+type LotSizeConfig struct {
+    Min  uint64 `yaml:"min"`
+    Max  uint64 `yaml:"max"`
+    Step uint64 `yaml:"step"`
+}
+
+// AssetFeeConfig is one asset's taker fees in basis points of notional,
+// negative for a rebate.
+type AssetFeeConfig struct {
+    SpotFeeBps int64 `yaml:"spot_fee_bps"`
+    PerpFeeBps int64 `yaml:"perp_fee_bps"`
+}
+
+// CapitalConfig selects the balance trades are sized against. An empty
+// Token means the native balance; Decimals of zero picks 18 for native and
+// 6 for a token.
+type CapitalConfig struct {
+    Token        string `yaml:"token"`
+    Decimals     int    `yaml:"decimals"`
+    FractionBps  uint64 `yaml:"fraction_bps"`
+    MinTradeSize uint64 `yaml:"min_trade_size"`
+}
+
+// ApprovalConfig lists the ERC20 tokens the arbitrage contract needs an
+// allowance for. Amount is "max" or an exact integer.
+type ApprovalConfig struct {
+    Tokens []string      `yaml:"tokens"`
+    Amount string        `yaml:"amount"`
+    TTL    time.Duration `yaml:"ttl"`
+}
+
+// ConversionConfig sweeps realized profit into SettlementToken through the
+// Uniswap V2 style Router once a wallet's unconverted profit reaches
+// Threshold, in 1e8 USD. Profit accrues in Token, which has Decimals and is
+// worth TokenPrice, in 1e8 USD per whole token. A sweep is skipped while its
+// gas would cost as much as the profit it converts and reverts when the
+// router returns less than its quote minus SlippageBps.
+type ConversionConfig struct {
+    Enabled         bool   `yaml:"enabled"`
+    Router          string `yaml:"router"`
+    Token           string `yaml:"token"`
+    Decimals        int    `yaml:"decimals"`
+    TokenPrice      int64  `yaml:"token_price"`
+    SettlementToken string `yaml:"settlement_token"`
+    Threshold       int64  `yaml:"threshold"`
+    SlippageBps     uint64 `yaml:"slippage_bps"`
+    GasLimit        uint64 `yaml:"gas_limit"`
+}
+
+// BreakerConfig configures the executor's circuit breaker. MaxLoss is in
+// 1e8 USD; zero disables the loss check. DailyMaxLoss and DailyMaxVolume cap
+// realized loss and traded spot notional per UTC day, also in 1e8 USD; zero
+// disables either cap. Each of the three may instead be given in dollars
+// through its USD field.
+type BreakerConfig struct {
+    MaxFailures    int           `yaml:"max_failures"`
+    MaxLoss        int64         `yaml:"max_loss"`
+    Window         time.Duration `yaml:"window"`
+    Cooldown       time.Duration `yaml:"cooldown"`
+    DailyMaxLoss   int64         `yaml:"daily_max_loss"`
+    DailyMaxVolume int64         `yaml:"daily_max_volume"`
+    
+    MaxLossUSD        string `yaml:"max_loss_usd"`
+    DailyMaxLossUSD   string `yaml:"daily_max_loss_usd"`
+    DailyMaxVolumeUSD string `yaml:"daily_max_volume_usd"`
+}
+
+// AlertConfig configures operator alerts. Alerts are disabled unless a
+// webhook or Telegram bot is set. ProfitThreshold is in 1e8 USD, or in
+// dollars through ProfitThresholdUSD; either
+// threshold can be zero to disable that alert. NoOpportunityAfter alerts when
+// a detector asset, or every asset, goes that long without an opportunity;
+// zero disables it. Each alert kind is sent at most once per MinInterval.
+type AlertConfig struct {
+    WebhookURL       string        `yaml:"webhook_url"`
+    TelegramToken    string        `yaml:"telegram_token"`
+    TelegramChatID   string        `yaml:"telegram_chat_id"`
+    ProfitThreshold  int64         `yaml:"profit_threshold"`
+    FailureThreshold int           `yaml:"failure_threshold"`
+    MinInterval      time.Duration `yaml:"min_interval"`
+    
+    NoOpportunityAfter time.Duration `yaml:"no_opportunity_after"`
+    
+    ProfitThresholdUSD string `yaml:"profit_threshold_usd"`
+}
+
+// resolveAssets builds the asset registry and resolves the detector's asset
+// references against it.
+func (c *Config) resolveAssets() error {
+    assets, err := registry.New(c.AssetRegistry)
+    if err != nil {
+        return fmt.Errorf("asset_registry: %w", err)
+    }
+    c.assets = assets
+    
+    c.Detector.Assets = nil
+    for _, ref := range c.Detector.AssetRefs {
+        asset, err := assets.Resolve(ref)
+        if err != nil {
+            return fmt.Errorf("detector.assets: %w", err)
+        }
+        c.Detector.Assets = append(c.Detector.Assets, asset.ID)
+    }
+    
+    if ref := c.Executor.NativePriceAsset; ref != "" {
+        asset, err := assets.Resolve(ref)
+        if err != nil {
+            return fmt.Errorf("executor.native_price_asset: %w", err)
+        }
+        c.Executor.NativePriceAssetID = asset.ID
+    }
+    return nil
+}
+
+// resolveAmounts converts the thresholds given in dollars, such as "5" or
+// "0.25", to the 1e8 fixed point the bot works in. A dollar setting takes
+// precedence over its fixed-point counterpart.
+func (c *Config) resolveAmounts() error {
+    amounts := []struct {
+        name string
+        raw  string
+        dst  *int64
+    }{
+        {"executor.min_net_profit_usd", c.Executor.MinNetProfitUSD, &c.Executor.MinNetProfit},
+        {"breaker.max_loss_usd", c.Breaker.MaxLossUSD, &c.Breaker.MaxLoss},
+        {"breaker.daily_max_loss_usd", c.Breaker.DailyMaxLossUSD, &c.Breaker.DailyMaxLoss},
+        {"breaker.daily_max_volume_usd", c.Breaker.DailyMaxVolumeUSD, &c.Breaker.DailyMaxVolume},
+        {"alerts.profit_threshold_usd", c.Alerts.ProfitThresholdUSD, &c.Alerts.ProfitThreshold},
+    }
+    for _, amount := range amounts {
+        if amount.raw == "" {
+            continue
+        }
+        value, err := pricing.ParseDecimal(amount.raw, pricing.USDDecimals)
+        if err != nil {
+            return fmt.Errorf("%s: %w", amount.name, err)
+        }
+        if !value.IsInt64() {
+            return fmt.Errorf("%s: amount %q out of range", amount.name, amount.raw)
+        }
+        *amount.dst = value.Int64()
+    }
+    return nil
+}
+
+// Registry returns the asset registry built when the configuration was
+// loaded.
+func (c *Config) Registry() *registry.Registry {
+    return c.assets
+}
+
+// TLSEnabled reports whether the monitoring server should serve TLS.
+func (c *Config) TLSEnabled() bool {
+    return c.TLSCertFile != "" && c.TLSKeyFile != ""
+}
+
+// Enabled reports whether any alert destination is configured.
+func (a AlertConfig) Enabled() bool {
+    return a.WebhookURL != "" || a.TelegramToken != ""
+}
+
+// LogFileConfig is a log file rotated once it reaches MaxSizeMB. Rotated
+// files are removed after MaxAgeDays or beyond the newest MaxBackups, zero
+// keeping them all, and gzipped when Compress is set.
+type LogFileConfig struct {
+    Path       string `yaml:"path"`
+    MaxSizeMB  int    `yaml:"max_size_mb"`
+    MaxAgeDays int    `yaml:"max_age_days"`
+    MaxBackups int    `yaml:"max_backups"`
+    Compress   bool   `yaml:"compress"`
+}
+
+// Default returns the configuration used when neither a file nor the
+// environment sets a value.
+func Default() *Config {
+    return &Config{
+        LogLevel:  "info",
+        LogFormat: "json",
+        LogFile: LogFileConfig{
+            MaxSizeMB:  100,
+            MaxAgeDays: 7,
+            MaxBackups: 5,
+        },
+        MetricsAddr:     ":8080",
+        ShutdownTimeout: 30 * time.Second,
+        DrainTimeout:    5 * time.Second,
+        PnLWindows:      []time.Duration{time.Hour, 24 * time.Hour},
+        AssetStatePath:  "asset_state.json",
+        
+        OpportunityHistory: 1000,
+        
+        AssetRegistry: []registry.Asset{
+            {ID: 0, Symbol: "BTC", Decimals: 5},
+            {ID: 1, Symbol: "ETH", Decimals: 4},
+            {ID: 2, Symbol: "ATOM", Decimals: 2},
+            {ID: 3, Symbol: "MATIC", Decimals: 1},
+            {ID: 4, Symbol: "DYDX", Decimals: 1},
+            {ID: 5, Symbol: "SOL", Decimals: 2},
+        },
+        
+        RPC: RPCConfig{
+            CoreURL:     DefaultCoreRPCURL,
+            EVMURL:      DefaultEVMRPCURL,
+            WSURL:       DefaultWSURL,
+            MaxRetries:  2,
+            RedialAfter: 5,
+            CallTimeout: 2 * time.Second,
+        },
+        Detector: DetectorConfig{
+            Mode:              "poll",
+            PollInterval:      100 * time.Millisecond,
+            AssetRefs:         []string{"BTC", "ETH", "ATOM", "MATIC", "DYDX"},
+            MinSpreadBps:      10,
+            AssetMinSpreadBps: map[uint32]int64{},
+            HoldingPeriod:     time.Hour,
+            MaxSlippageBps:    10,
+            MinTradeSize:      10000000,
+            MaxTradeSize:      100000000,
+            MaxPriceAge:       30 * time.Second,
+            DedupWindow:       time.Second,
+            DedupMinChangeBps: 5,
+            ScanWorkers:       4,
+            MaxPollInterval:   time.Second,
+            CompetitionWindow: time.Minute,
+            
+            AssetTradeSize:      map[uint32]int64{},
+            AssetTargetNotional: map[uint32]int64{},
+            AssetMaxNotional:    map[uint32]int64{},
+            
+            CycleStartToken:   "USDC",
+            CycleMaxHops:      3,
+            CycleMinProfitBps: 20,
+            
+            OracleLayout:      "v1",
+            OracleMinPrice:    100,
+            OracleMaxPrice:    1000000000000000,
+            OracleAssetBounds: map[uint32]PriceBounds{},
+            OracleMaxJumpBps:  5000,
+        },
+        Executor: ExecutorConfig{
+            MaxGasPrice:      100000000000,
+            DefaultGasLimit:  500000,
+            GasBufferPercent: 20,
+            ReceiptTimeout:   30 * time.Second,
+            WarmUp:           30 * time.Second,
+            NonceStatePath:   "nonce_state.json",
+            
+            MinNetProfit:         1000000,
+            MinSpreadBps:         20,
+            MaxOpportunityAge:    500 * time.Millisecond,
+            ReplayWindow:         10 * time.Minute,
+            FailureCooldown:      30 * time.Second,
+            ReconcileInterval:    time.Hour,
+            ReconcileTolerance:   1000000000,
+            MaxGasProfitRatioBps: 5000,
+            SlippageToleranceBps: 10,
+            
+            ReplaceBumpPercent: 15,
+            ReplaceMode:        "speedup",
+            SendRetries:        2,
+            SendRetryBackoff:   200 * time.Millisecond,
+            
+            MinGasBalance:      250000000000000000,
+            GasBalanceInterval: 30 * time.Second,
+            
+            LotSizes:    map[uint32]LotSizeConfig{},
+            AssetFees:   map[uint32]AssetFeeConfig{},
+            MaxExposure: map[uint32]int64{},
+            Capital: CapitalConfig{
+                FractionBps:  5000,
+                MinTradeSize: 10000000,
+            },
+            Approvals: ApprovalConfig{
+                Amount: "max",
+                TTL:    time.Hour,
+            },
+            Conversion: ConversionConfig{
+                Decimals:    6,
+                TokenPrice:  100000000,
+                SlippageBps: 50,
+                GasLimit:    250000,
+            },
+        },
+        Breaker: BreakerConfig{
+            MaxFailures: 5,
+            Window:      10 * time.Minute,
+            Cooldown:    5 * time.Minute,
+        },
+        Alerts: AlertConfig{
+            ProfitThreshold:  100000000000,
+            FailureThreshold: 3,
+            MinInterval:      time.Minute,
+            
+            NoOpportunityAfter: time.Hour,
+        },
+    }
+}
+
+// Load builds the configuration from the defaults, the YAML file at path
+// (skipped when path is empty) and environment overrides, then validates
+// the result.
+func Load(path string) (*Config, error) {
+    cfg := Default()
+    
+    if path != "" {
+        if err := cfg.loadFile(path); err != nil {
+            return nil, err
+        }
+    }
+    
+    if err := cfg.applyEnv(); err != nil {
+        return nil, err
+    }
+    
+    if err := cfg.resolveAssets(); err != nil {
+        return nil, err
+    }
+    if err := cfg.resolveAmounts(); err != nil {
+        return nil, err
+    }
+    if err := cfg.Validate(); err != nil {
+        return nil, err
+    }
+    return cfg, nil
+}
+
+func (c *Config) loadFile(path string) error {
+    f, err := os.Open(path)
+    if err != nil {
+        return fmt.Errorf("open config: %w", err)
+    }
+    defer f.Close()
+    
+    dec := yaml.NewDecoder(f)
+    dec.KnownFields(true)
+    if err := dec.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+        return fmt.Errorf("parse config %s: %w", path, err)
+    }
+    return nil
+}
+
+// Validate checks the resolved configuration for values the detector and
+// executor cannot run with.
+func (c *Config) Validate() error {
+    if _, err := logrus.ParseLevel(c.LogLevel); err != nil {
+        return fmt.Errorf("invalid log_level %q", c.LogLevel)
+    }
+    if c.LogFormat != "json" && c.LogFormat != "text" {
+        return fmt.Errorf("invalid log_format %q: want \"json\" or \"text\"", c.LogFormat)
+    }
+    if c.LogFile.Path != "" && c.LogFile.MaxSizeMB <= 0 {
+        return errors.New("log_file.max_size_mb must be positive")
+    }
+    if c.LogFile.MaxAgeDays < 0 || c.LogFile.MaxBackups < 0 {
+        return errors.New("log_file.max_age_days and log_file.max_backups must not be negative")
+    }
+    if c.MetricsAddr == "" {
+        return errors.New("metrics_addr is empty")
+    }
+    if c.ShutdownTimeout <= 0 {
+        return errors.New("shutdown_timeout must be positive")
+    }
+    if c.DrainTimeout < 0 {
+        return errors.New("drain_timeout must not be negative")
+    }
+    if c.ProtectMetrics && c.StatsToken == "" {
+        return errors.New("protect_metrics requires stats_token")
+    }
+    if c.OpportunityHistory <= 0 {
+        return errors.New("opportunity_history must be positive")
+    }
+    for _, window := range c.PnLWindows {
+        if window <= 0 {
+            return fmt.Errorf("invalid pnl_windows entry %s: must be positive", window)
+        }
+    }
+    
+    if err := c.RPC.validate(); err != nil {
+        return err
+    }
+    if c.Detector.Mode == "subscribe" && c.RPC.WSURL == "" {
+        return errors.New("rpc.ws_url is required in subscribe mode")
+    }
+    if err := c.Detector.validate(); err != nil {
+        return err
+    }
+    if err := c.Executor.validate(); err != nil {
+        return err
+    }
+    if err := c.Breaker.validate(); err != nil {
+        return err
+    }
+    return c.Alerts.validate()
+}
+
+// ValidateCredentials checks the settings only needed to trade live: the
+// executor keys and the arbitrage contract. Backtests skip it.
+func (c *Config) ValidateCredentials() error {
+    keys := c.Executor.Keys()
+    if len(keys) == 0 {
+        return errors.New("executor private key is not set")
+    }
+    
+    seen := make(map[string]bool)
+    for _, key := range keys {
+        hexKey := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(key), "0x"))
+        if c.Production && hexKey == testPrivateKey {
+            return errors.New("executor private key is the well-known test key; refusing to start in production")
+        }
+        if seen[hexKey] {
+            return errors.New("executor private key listed more than once")
+        }
+        seen[hexKey] = true
+    }
+    
+    if len(c.Executor.Routes) == 0 && !common.IsHexAddress(c.Executor.ArbContract) {
+        return fmt.Errorf("invalid executor.arb_contract %q", c.Executor.ArbContract)
+    }
+    return nil
+}
+
+// RouteList returns the configured routes, or a single "default" route
+// through ArbContract when none are set.
+func (e *ExecutorConfig) RouteList() []RouteConfig {
+    if len(e.Routes) > 0 {
+        return e.Routes
+    }
+    return []RouteConfig{{
+        Name:       "default",
+        Contract:   e.ArbContract,
+        ABI:        "path",
+        SpotFeeBps: e.SpotFeeBps,
+        PerpFeeBps: e.PerpFeeBps,
+    }}
+}
+
+// Keys returns every configured executor private key, PrivateKey first.
+func (e *ExecutorConfig) Keys() []string {
+    var keys []string
+    if strings.TrimSpace(e.PrivateKey) != "" {
+        keys = append(keys, e.PrivateKey)
+    }
+    for _, key := range e.PrivateKeys {
+        if strings.TrimSpace(key) != "" {
+            keys = append(keys, key)
+        }
+    }
+    return keys
+}
+
+func (r *RPCConfig) validate() error {
+    if r.CoreURL == "" {
+        return errors.New("rpc.core_url is empty")
+    }
+    if r.EVMURL == "" {
+        return errors.New("rpc.evm_url is empty")
+    }
+    if r.MaxRetries < 0 || r.RedialAfter < 0 {
+        return errors.New("rpc.max_retries and rpc.redial_after must not be negative")
+    }
+    if r.CallTimeout <= 0 {
+        return errors.New("rpc.call_timeout must be positive")
+    }
+    return nil
+}
+
+func (d *DetectorConfig) validate() error {
+    if d.Mode != "poll" && d.Mode != "subscribe" {
+        return fmt.Errorf("invalid detector.mode %q: want \"poll\" or \"subscribe\"", d.Mode)
+    }
+    if d.PollInterval <= 0 {
+        return errors.New("detector.poll_interval must be positive")
+    }
+    if d.AdaptivePoll && d.MaxPollInterval < d.PollInterval {
+        return errors.New("detector.max_poll_interval must not be shorter than detector.poll_interval")
+    }
+    
+    if len(d.Assets) == 0 {
+        return errors.New("detector.assets: no assets configured")
+    }
+    seen := make(map[uint32]bool)
+    for _, asset := range d.Assets {
+        if seen[asset] {
+            return fmt.Errorf("detector.assets: asset %d listed more than once", asset)
+        }
+        seen[asset] = true
+    }
+    
+    if d.MinSpreadBps <= 0 {
+        return errors.New("detector.min_spread_bps must be positive")
+    }
+    for asset, bps := range d.AssetMinSpreadBps {
+        if bps <= 0 {
+            return fmt.Errorf("detector.asset_min_spread_bps: asset %d threshold must be positive", asset)
+        }
+    }
+    
+    if d.HoldingPeriod < 0 {
+        return errors.New("detector.holding_period must not be negative")
+    }
+    if d.MaxSlippageBps <= 0 || d.MaxSlippageBps >= 10000 {
+        return fmt.Errorf("detector.max_slippage_bps must be in (0, 10000), got %d", d.MaxSlippageBps)
+    }
+    if d.MinTradeSize <= 0 || d.MaxTradeSize <= 0 {
+        return errors.New("detector trade sizes must be positive")
+    }
+    if d.MinTradeSize > d.MaxTradeSize {
+        return errors.New("detector.min_trade_size exceeds detector.max_trade_size")
+    }
+    if d.MaxPriceAge <= 0 {
+        return errors.New("detector.max_price_age must be positive")
+    }
+    if d.PollJitter < 0 {
+        return errors.New("detector.poll_jitter must not be negative")
+    }
+    if d.SizeJitterBps < 0 || d.SizeJitterBps >= 10000 {
+        return fmt.Errorf("detector.size_jitter_bps must be in [0, 10000), got %d", d.SizeJitterBps)
+    }
+    if d.ScanWorkers <= 0 {
+        return errors.New("detector.scan_workers must be positive")
+    }
+    if d.DedupWindow < 0 || d.DedupMinChangeBps < 0 {
+        return errors.New("detector.dedup_window and detector.dedup_min_change_bps must not be negative")
+    }
+    if d.WatchMempool && d.CompetitionWindow <= 0 {
+        return errors.New("detector.competition_window must be positive when detector.watch_mempool is set")
+    }
+    if d.CompetitionSpreadBps < 0 {
+        return errors.New("detector.competition_spread_bps must not be negative")
+    }
+    if d.TargetNotional < 0 {
+        return errors.New("detector.target_notional must not be negative")
+    }
+    for name, values := range map[string]map[uint32]int64{
+        "asset_trade_size":      d.AssetTradeSize,
+        "asset_target_notional": d.AssetTargetNotional,
+        "asset_max_notional":    d.AssetMaxNotional,
+    } {
+        for asset, value := range values {
+            if value <= 0 {
+                return fmt.Errorf("detector.%s: asset %d value must be positive", name, asset)
+            }
+        }
+    }
+    for asset := range d.AssetTradeSize {
+        if _, ok := d.AssetTargetNotional[asset]; ok {
+            return fmt.Errorf("detector: asset %d has both asset_trade_size and asset_target_notional", asset)
+        }
+    }
+    for _, address := range d.CompetitorAddresses {
+        if !common.IsHexAddress(address) {
+            return fmt.Errorf("detector.competitor_addresses: invalid address %q", address)
+        }
+    }
+    if err := d.validateCycles(); err != nil {
+        return err
+    }
+    if d.OracleLayout != "v1" && d.OracleLayout != "v2" {
+        return fmt.Errorf("invalid detector.oracle_layout %q: want \"v1\" or \"v2\"", d.OracleLayout)
+    }
+    if d.OracleMinPrice < 0 || d.OracleMaxPrice < 0 || d.OracleMaxJumpBps < 0 {
+        return errors.New("detector oracle sanity bounds must not be negative")
+    }
+    if d.OracleMaxPrice > 0 && d.OracleMinPrice > d.OracleMaxPrice {
+        return errors.New("detector.oracle_min_price must not exceed detector.oracle_max_price")
+    }
+    for asset, bounds := range d.OracleAssetBounds {
+        if bounds.Min < 0 || bounds.Max < 0 {
+            return fmt.Errorf("detector.oracle_asset_bounds: asset %d bounds must not be negative", asset)
+        }
+        if bounds.Max > 0 && bounds.Min > bounds.Max {
+            return fmt.Errorf("detector.oracle_asset_bounds: asset %d min exceeds max", asset)
+        }
+    }
+    
+    if err := optionalAddress("detector.funding_precompile", d.FundingPrecompile); err != nil {
+        return err
+    }
+    return optionalAddress("detector.depth_precompile", d.DepthPrecompile)
+}
+
+// validateCycles checks the cycle settings, which only matter when pairs
+// are configured.
+func (d *DetectorConfig) validateCycles() error {
+    if len(d.CyclePairs) == 0 {
+        return nil
+    }
+    if strings.TrimSpace(d.CycleStartToken) == "" {
+        return errors.New("detector.cycle_start_token must be set when detector.cycle_pairs is")
+    }
+    if d.CycleMaxHops < 3 || d.CycleMaxHops > maxCycleHops {
+        return fmt.Errorf("detector.cycle_max_hops must be in [3, %d], got %d", maxCycleHops, d.CycleMaxHops)
+    }
+    if d.CycleMinProfitBps <= 0 {
+        return errors.New("detector.cycle_min_profit_bps must be positive")
+    }
+    for _, pair := range d.CyclePairs {
+        base, quote := strings.TrimSpace(pair.Base), strings.TrimSpace(pair.Quote)
+        if base == "" || quote == "" || strings.EqualFold(base, quote) {
+            return fmt.Errorf("detector.cycle_pairs: pair %d needs distinct base and quote tokens", pair.Pair)
+        }
+        if pair.FeeBps < 0 || pair.FeeBps >= 10000 {
+            return fmt.Errorf("detector.cycle_pairs: pair %d fee_bps must be in [0, 10000)", pair.Pair)
+        }
+    }
+    return nil
+}
+
+func (e *ExecutorConfig) validate() error {
+    if e.MaxGasPrice == 0 {
+        return errors.New("executor.max_gas_price must be positive")
+    }
+    if e.DefaultGasLimit == 0 {
+        return errors.New("executor.default_gas_limit must be positive")
+    }
+    if e.ReceiptTimeout <= 0 {
+        return errors.New("executor.receipt_timeout must be positive")
+    }
+    if e.NativeTokenPrice < 0 {
+        return errors.New("executor.native_token_price must not be negative")
+    }
+    if !e.DryRun && e.NativeTokenPrice == 0 && e.NativePriceAsset == "" {
+        // Gas would be priced at zero, leaving every gas guard open.
+        return errors.New("executor.native_token_price or executor.native_price_asset must be set unless executor.dry_run is")
+    }
+    if !validFeeBps(e.SpotFeeBps) || !validFeeBps(e.PerpFeeBps) {
+        return errors.New("executor.spot_fee_bps and executor.perp_fee_bps must be between -10000 and 10000")
+    }
+    if e.SlippageToleranceBps >= 10000 {
+        return errors.New("executor.slippage_tolerance_bps must be below 10000")
+    }
+    if e.SpotHalfSpreadBps >= 10000 || e.PerpHalfSpreadBps >= 10000 {
+        return errors.New("executor.spot_half_spread_bps and executor.perp_half_spread_bps must be below 10000")
+    }
+    if e.MinNetProfit < 0 {
+        return errors.New("executor.min_net_profit must not be negative")
+    }
+    if e.MinSpreadBps <= 0 {
+        return errors.New("executor.min_spread_bps must be positive")
+    }
+    if e.MaxOpportunityAge <= 0 {
+        return errors.New("executor.max_opportunity_age must be positive")
+    }
+    if e.WarmUp < 0 {
+        return errors.New("executor.warm_up must not be negative")
+    }
+    if e.ReplayWindow < 0 {
+        return errors.New("executor.replay_window must not be negative")
+    }
+    if e.FailureCooldown < 0 {
+        return errors.New("executor.failure_cooldown must not be negative")
+    }
+    if e.MinExecutionInterval < 0 {
+        return errors.New("executor.min_execution_interval must not be negative")
+    }
+    if e.PriorityFeeMax > e.MaxGasPrice {
+        return errors.New("executor.priority_fee_max must not exceed executor.max_gas_price")
+    }
+    if e.MaxConcurrentExecutions < 0 {
+        return errors.New("executor.max_concurrent_executions must not be negative")
+    }
+    if e.ReconcileInterval < 0 || e.ReconcileTolerance < 0 {
+        return errors.New("executor.reconcile_interval and executor.reconcile_tolerance must not be negative")
+    }
+    if e.MaxGasProfitRatioBps < 0 {
+        return errors.New("executor.max_gas_profit_ratio_bps must not be negative")
+    }
+    if e.ReplaceAfter < 0 {
+        return errors.New("executor.replace_after must not be negative")
+    }
+    if e.ReplaceAfter > 0 && e.ReplaceAfter >= e.ReceiptTimeout {
+        return errors.New("executor.replace_after must be shorter than executor.receipt_timeout")
+    }
+    if e.ReplaceBumpPercent < 10 {
+        return errors.New("executor.replace_bump_percent must be at least 10")
+    }
+    if e.ReplaceMode != "speedup" && e.ReplaceMode != "cancel" {
+        return fmt.Errorf("invalid executor.replace_mode %q: want \"speedup\" or \"cancel\"", e.ReplaceMode)
+    }
+    if e.SendRetries < 0 {
+        return errors.New("executor.send_retries must not be negative")
+    }
+    if e.SendRetries > 0 && e.SendRetryBackoff <= 0 {
+        return errors.New("executor.send_retry_backoff must be positive when executor.send_retries is set")
+    }
+    if e.MinGasBalance > 0 && e.GasBalanceInterval <= 0 {
+        return errors.New("executor.gas_balance_interval must be positive when executor.min_gas_balance is set")
+    }
+    names := make(map[string]bool)
+    for i, route := range e.Routes {
+        if route.Name == "" {
+            return fmt.Errorf("executor.routes[%d]: name must be set", i)
+        }
+        if names[route.Name] {
+            return fmt.Errorf("executor.routes: route %q listed more than once", route.Name)
+        }
+        names[route.Name] = true
+        if !common.IsHexAddress(route.Contract) {
+            return fmt.Errorf("executor.routes: invalid contract %q for route %q", route.Contract, route.Name)
+        }
+        if route.ABI != "" && route.ABI != "path" && route.ABI != "legacy" {
+            return fmt.Errorf("executor.routes: invalid abi %q for route %q: want \"path\" or \"legacy\"", route.ABI, route.Name)
+        }
+        if route.ABI == "legacy" && len(route.Path) > 0 {
+            return fmt.Errorf("executor.routes: route %q uses the legacy abi, which takes no path", route.Name)
+        }
+        if !validFeeBps(route.SpotFeeBps) || !validFeeBps(route.PerpFeeBps) {
+            return fmt.Errorf("executor.routes: route %q fees must be between -10000 and 10000 bps", route.Name)
+        }
+        for _, hop := range route.Path {
+            if !common.IsHexAddress(hop) {
+                return fmt.Errorf("executor.routes: invalid path address %q for route %q", hop, route.Name)
+            }
+        }
+    }
+    for asset, lot := range e.LotSizes {
+        if lot.Max > 0 && lot.Min > lot.Max {
+            return fmt.Errorf("executor.lot_sizes: asset %d min exceeds max", asset)
+        }
+        if lot.Step > 0 && lot.Max > 0 && lot.Max < lot.Step {
+            return fmt.Errorf("executor.lot_sizes: asset %d max is below one step", asset)
+        }
+    }
+    for asset, fees := range e.AssetFees {
+        if !validFeeBps(fees.SpotFeeBps) || !validFeeBps(fees.PerpFeeBps) {
+            return fmt.Errorf("executor.asset_fees: asset %d fees must be between -10000 and 10000 bps", asset)
+        }
+    }
+    for asset, limit := range e.MaxExposure {
+        if limit <= 0 {
+            return fmt.Errorf("executor.max_exposure: asset %d limit must be positive", asset)
+        }
+    }
+    
+    if err := optionalAddress("executor.capital.token", e.Capital.Token); err != nil {
+        return err
+    }
+    if e.Capital.Decimals < 0 {
+        return errors.New("executor.capital.decimals must not be negative")
+    }
+    if e.Capital.FractionBps == 0 || e.Capital.FractionBps > 10000 {
+        return fmt.Errorf("executor.capital.fraction_bps must be in (0, 10000], got %d", e.Capital.FractionBps)
+    }
+    
+    for _, token := range e.Approvals.Tokens {
+        if !common.IsHexAddress(token) {
+            return fmt.Errorf("invalid token %q in executor.approvals.tokens", token)
+        }
+    }
+    if e.Approvals.Amount != "max" {
+        if amount, ok := new(big.Int).SetString(e.Approvals.Amount, 10); !ok || amount.Sign() <= 0 {
+            return fmt.Errorf("invalid executor.approvals.amount %q", e.Approvals.Amount)
+        }
+    }
+    if e.Approvals.TTL <= 0 {
+        return errors.New("executor.approvals.ttl must be positive")
+    }
+    return e.Conversion.validate()
+}
+
+// validFeeBps reports whether bps is a usable fee or rebate, less than the
+// whole notional either way.
+func validFeeBps(bps int64) bool {
+    return bps > -10000 && bps < 10000
+}
+
+func (c *ConversionConfig) validate() error {
+    if !c.Enabled {
+        return nil
+    }
+    
+    if !common.IsHexAddress(c.Router) {
+        return fmt.Errorf("invalid executor.conversion.router %q", c.Router)
+    }
+    if !common.IsHexAddress(c.Token) || !common.IsHexAddress(c.SettlementToken) {
+        return fmt.Errorf("invalid executor.conversion tokens %q and %q", c.Token, c.SettlementToken)
+    }
+    if common.HexToAddress(c.Token) == common.HexToAddress(c.SettlementToken) {
+        return errors.New("executor.conversion.token and executor.conversion.settlement_token must differ")
+    }
+    if c.Decimals < 0 {
+        return errors.New("executor.conversion.decimals must not be negative")
+    }
+    if c.TokenPrice <= 0 || c.Threshold <= 0 {
+        return errors.New("executor.conversion.token_price and executor.conversion.threshold must be positive")
+    }
+    if c.SlippageBps >= 10000 {
+        return errors.New("executor.conversion.slippage_bps must be below 10000")
+    }
+    if c.GasLimit == 0 {
+        return errors.New("executor.conversion.gas_limit must be positive")
+    }
+    return nil
+}
+
+func (b *BreakerConfig) validate() error {
+    if b.MaxFailures < 0 {
+        return errors.New("breaker.max_failures must not be negative")
+    }
+    if b.MaxLoss < 0 {
+        return errors.New("breaker.max_loss must not be negative")
+    }
+    if b.DailyMaxLoss < 0 || b.DailyMaxVolume < 0 {
+        return errors.New("breaker.daily_max_loss and breaker.daily_max_volume must not be negative")
+    }
+    if b.Window <= 0 || b.Cooldown <= 0 {
+        return errors.New("breaker.window and breaker.cooldown must be positive")
+    }
+    return nil
+}
+
+func (a *AlertConfig) validate() error {
+    if (a.TelegramToken == "") != (a.TelegramChatID == "") {
+        return errors.New("alerts.telegram_token and alerts.telegram_chat_id must be set together")
+    }
+    if a.ProfitThreshold < 0 || a.FailureThreshold < 0 {
+        return errors.New("alert thresholds must not be negative")
+    }
+    if a.MinInterval <= 0 {
+        return errors.New("alerts.min_interval must be positive")
+    }
+    if a.NoOpportunityAfter < 0 {
+        return errors.New("alerts.no_opportunity_after must not be negative")
+    }
+    return nil
+}
+
+func optionalAddress(name, raw string) error {
+    if raw != "" && !common.IsHexAddress(raw) {
+        return fmt.Errorf("invalid %s %q", name, raw)
+    }
+    return nil
+}
\ No newline at end of file
@@ -0,0 +1,133 @@
+package config
+
+import (
+    "strings"
+    "testing"
+)
+
+// validConfig returns the default configuration with its references
+// resolved, as Load leaves it before validating, and the native token
+// priced so it may trade live.
+func validConfig(t *testing.T) *Config {
+    t.Helper()
+    
+    cfg := Default()
+    cfg.Executor.NativeTokenPrice = 2000000000
+    if err := cfg.resolveAssets(); err != nil {
+        t.Fatalf("resolve assets: %v", err)
+    }
+    if err := cfg.resolveAmounts(); err != nil {
+        t.Fatalf("resolve amounts: %v", err)
+    }
+    return cfg
+}
+
+// checkValid fails t unless err matches wantErr, a substring of the expected
+// error or "" for none.
+func checkValid(t *testing.T, err error, wantErr string) {
+    t.Helper()
+    
+    switch {
+    case wantErr == "" && err != nil:
+        t.Fatalf("Validate: %v", err)
+    case wantErr != "" && err == nil:
+        t.Fatalf("Validate succeeded, want an error containing %q", wantErr)
+    case wantErr != "" && !strings.Contains(err.Error(), wantErr):
+        t.Fatalf("Validate: %v, want an error containing %q", err, wantErr)
+    }
+}
+
+func TestValidateAssetMinSpreadBps(t *testing.T) {
+    tests := []struct {
+        name       string
+        thresholds map[uint32]int64
+        wantErr    string
+    }{
+        {"none", map[uint32]int64{}, ""},
+        {"positive", map[uint32]int64{0: 5, 3: 40}, ""},
+        {"zero", map[uint32]int64{3: 0}, "asset_min_spread_bps: asset 3"},
+        {"negative", map[uint32]int64{1: -10}, "asset_min_spread_bps: asset 1"},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            cfg := validConfig(t)
+            cfg.Detector.AssetMinSpreadBps = tt.thresholds
+            checkValid(t, cfg.Validate(), tt.wantErr)
+        })
+    }
+}
+
+func TestValidateMinNetProfit(t *testing.T) {
+    tests := []struct {
+        name         string
+        minNetProfit int64
+        wantErr      string
+    }{
+        {"zero", 0, ""},
+        {"positive", 50000000, ""},
+        {"negative", -1, "executor.min_net_profit must not be negative"},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            cfg := validConfig(t)
+            cfg.Executor.MinNetProfit = tt.minNetProfit
+            checkValid(t, cfg.Validate(), tt.wantErr)
+        })
+    }
+}
+
+func TestValidateNativePrice(t *testing.T) {
+    tests := []struct {
+        name    string
+        dryRun  bool
+        price   int64
+        asset   string
+        wantErr string
+    }{
+        {"live with a price", false, 2000000000, "", ""},
+        {"live with an oracle asset", false, 0, "0", ""},
+        {"dry run without a price", true, 0, "", ""},
+        {"live without a price", false, 0, "", "executor.native_token_price or executor.native_price_asset must be set"},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            cfg := validConfig(t)
+            cfg.Executor.DryRun = tt.dryRun
+            cfg.Executor.NativeTokenPrice = tt.price
+            cfg.Executor.NativePriceAsset = tt.asset
+            checkValid(t, cfg.Validate(), tt.wantErr)
+        })
+    }
+}
+func TestResolveAmounts(t *testing.T) {
+    cfg := Default()
+    cfg.Executor.MinNetProfit = 1
+    cfg.Executor.MinNetProfitUSD = "0.25"
+    cfg.Breaker.MaxLossUSD = "$50"
+    cfg.Breaker.DailyMaxLoss = 7
+    if err := cfg.resolveAmounts(); err != nil {
+        t.Fatalf("resolveAmounts: %v", err)
+    }
+    if cfg.Executor.MinNetProfit != 25000000 {
+        t.Fatalf("min net profit = %d, want 25000000", cfg.Executor.MinNetProfit)
+    }
+    if cfg.Breaker.MaxLoss != 5000000000 {
+        t.Fatalf("max loss = %d, want 5000000000", cfg.Breaker.MaxLoss)
+    }
+    // Fixed-point settings without a dollar counterpart are kept.
+    if cfg.Breaker.DailyMaxLoss != 7 {
+        t.Fatalf("daily max loss = %d, want 7", cfg.Breaker.DailyMaxLoss)
+    }
+    
+    for _, raw := range []string{"0.000000001", "-1", "five", "100000000000000"} {
+        cfg := Default()
+        cfg.Alerts.ProfitThresholdUSD = raw
+        err := cfg.resolveAmounts()
+        if err == nil || !strings.Contains(err.Error(), "alerts.profit_threshold_usd") {
+            t.Errorf("resolveAmounts(%q) = %v, want an alerts.profit_threshold_usd error", raw, err)
+        }
+    }
+}
@@ -0,0 +1,655 @@
+package config
+
+import (
+    "fmt"
+    "os"
+    "strconv"
+    "strings"
+    "time"
+)
+
+// applyEnv overrides file values with any environment variables that are
+// set. An empty variable leaves the file value in place, except for RPC
+// URLs where set-but-empty is treated as a mistake.
+func (c *Config) applyEnv() error {
+    envString("LOG_LEVEL", &c.LogLevel)
+    envString("LOG_FORMAT", &c.LogFormat)
+    envString("LOG_FILE", &c.LogFile.Path)
+    for key, dst := range map[string]*int{
+        "LOG_FILE_MAX_SIZE_MB":  &c.LogFile.MaxSizeMB,
+        "LOG_FILE_MAX_AGE_DAYS": &c.LogFile.MaxAgeDays,
+        "LOG_FILE_MAX_BACKUPS":  &c.LogFile.MaxBackups,
+    } {
+        if err := envInt(key, dst); err != nil {
+            return err
+        }
+    }
+    if err := envBool("LOG_FILE_COMPRESS", &c.LogFile.Compress); err != nil {
+        return err
+    }
+    envString("METRICS_ADDR", &c.MetricsAddr)
+    envString("CONTROL_TOKEN", &c.ControlToken)
+    envString("ASSET_STATE_PATH", &c.AssetStatePath)
+    envString("TLS_CERT_FILE", &c.TLSCertFile)
+    envString("TLS_KEY_FILE", &c.TLSKeyFile)
+    envString("STATS_TOKEN", &c.StatsToken)
+    if err := envBool("PROTECT_METRICS", &c.ProtectMetrics); err != nil {
+        return err
+    }
+    if err := envDuration("SHUTDOWN_TIMEOUT", &c.ShutdownTimeout); err != nil {
+        return err
+    }
+    if err := envDuration("DRAIN_TIMEOUT", &c.DrainTimeout); err != nil {
+        return err
+    }
+    if err := envInt("OPPORTUNITY_HISTORY", &c.OpportunityHistory); err != nil {
+        return err
+    }
+    if err := envBool("PRODUCTION", &c.Production); err != nil {
+        return err
+    }
+    if raw := os.Getenv("PNL_WINDOWS"); raw != "" {
+        var windows []time.Duration
+        for _, field := range splitList(raw) {
+            window, err := time.ParseDuration(field)
+            if err != nil {
+                return fmt.Errorf("invalid PNL_WINDOWS: %w", err)
+            }
+            windows = append(windows, window)
+        }
+        c.PnLWindows = windows
+    }
+    
+    for key, dst := range map[string]*string{
+        "HYPERCORE_RPC_URL": &c.RPC.CoreURL,
+        "HYPEREVM_RPC_URL":  &c.RPC.EVMURL,
+        "HYPERCORE_WS_URL":  &c.RPC.WSURL,
+    } {
+        if err := envURL(key, dst); err != nil {
+            return err
+        }
+    }
+    if err := envInt("RPC_MAX_RETRIES", &c.RPC.MaxRetries); err != nil {
+        return err
+    }
+    if err := envInt("RPC_REDIAL_AFTER", &c.RPC.RedialAfter); err != nil {
+        return err
+    }
+    if err := envDuration("RPC_CALL_TIMEOUT", &c.RPC.CallTimeout); err != nil {
+        return err
+    }
+    
+    if err := c.Detector.applyEnv(); err != nil {
+        return err
+    }
+    if err := c.Executor.applyEnv(); err != nil {
+        return err
+    }
+    if err := c.Breaker.applyEnv(); err != nil {
+        return err
+    }
+    return c.Alerts.applyEnv()
+}
+
+func (d *DetectorConfig) applyEnv() error {
+    envString("DETECTOR_MODE", &d.Mode)
+    if err := envDuration("DETECTOR_POLL_INTERVAL", &d.PollInterval); err != nil {
+        return err
+    }
+    
+    if raw, ok := os.LookupEnv("SCAN_ASSETS"); ok {
+        d.AssetRefs = splitList(raw)
+    }
+    
+    if err := envInt64("DETECTOR_MIN_SPREAD_BPS", &d.MinSpreadBps); err != nil {
+        return err
+    }
+    if raw := os.Getenv("DETECTOR_ASSET_MIN_SPREADS_BPS"); raw != "" {
+        thresholds, err := ParseAssetThresholds(raw)
+        if err != nil {
+            return fmt.Errorf("invalid DETECTOR_ASSET_MIN_SPREADS_BPS: %w", err)
+        }
+        d.AssetMinSpreadBps = thresholds
+    }
+    
+    if err := envDuration("DETECTOR_HOLDING_PERIOD", &d.HoldingPeriod); err != nil {
+        return err
+    }
+    if err := envInt64("DETECTOR_MAX_SLIPPAGE_BPS", &d.MaxSlippageBps); err != nil {
+        return err
+    }
+    if err := envInt64("DETECTOR_MIN_TRADE_SIZE", &d.MinTradeSize); err != nil {
+        return err
+    }
+    if err := envInt64("DETECTOR_MAX_TRADE_SIZE", &d.MaxTradeSize); err != nil {
+        return err
+    }
+    if err := envDuration("DETECTOR_MAX_PRICE_AGE", &d.MaxPriceAge); err != nil {
+        return err
+    }
+    if err := envDuration("DETECTOR_DEDUP_WINDOW", &d.DedupWindow); err != nil {
+        return err
+    }
+    if err := envInt64("DETECTOR_DEDUP_MIN_CHANGE_BPS", &d.DedupMinChangeBps); err != nil {
+        return err
+    }
+    if err := envInt("DETECTOR_SCAN_WORKERS", &d.ScanWorkers); err != nil {
+        return err
+    }
+    if err := envDuration("DETECTOR_POLL_JITTER", &d.PollJitter); err != nil {
+        return err
+    }
+    if err := envInt64("DETECTOR_SIZE_JITTER_BPS", &d.SizeJitterBps); err != nil {
+        return err
+    }
+    if err := envBool("DETECTOR_ADAPTIVE_POLL", &d.AdaptivePoll); err != nil {
+        return err
+    }
+    if err := envDuration("DETECTOR_MAX_POLL_INTERVAL", &d.MaxPollInterval); err != nil {
+        return err
+    }
+    if err := envBool("DETECTOR_WATCH_MEMPOOL", &d.WatchMempool); err != nil {
+        return err
+    }
+    if raw := os.Getenv("DETECTOR_COMPETITOR_ADDRESSES"); raw != "" {
+        d.CompetitorAddresses = splitList(raw)
+    }
+    if err := envDuration("DETECTOR_COMPETITION_WINDOW", &d.CompetitionWindow); err != nil {
+        return err
+    }
+    if err := envInt64("DETECTOR_COMPETITION_SPREAD_BPS", &d.CompetitionSpreadBps); err != nil {
+        return err
+    }
+    if err := envInt64("DETECTOR_TARGET_NOTIONAL", &d.TargetNotional); err != nil {
+        return err
+    }
+    for key, dst := range map[string]*map[uint32]int64{
+        "DETECTOR_ASSET_TRADE_SIZES":      &d.AssetTradeSize,
+        "DETECTOR_ASSET_TARGET_NOTIONALS": &d.AssetTargetNotional,
+        "DETECTOR_ASSET_MAX_NOTIONALS":    &d.AssetMaxNotional,
+    } {
+        if raw := os.Getenv(key); raw != "" {
+            values, err := ParseAssetThresholds(raw)
+            if err != nil {
+                return fmt.Errorf("invalid %s: %w", key, err)
+            }
+            *dst = values
+        }
+    }
+    
+    if raw := os.Getenv("DETECTOR_CYCLE_PAIRS"); raw != "" {
+        pairs, err := ParseCyclePairs(raw)
+        if err != nil {
+            return fmt.Errorf("invalid DETECTOR_CYCLE_PAIRS: %w", err)
+        }
+        d.CyclePairs = pairs
+    }
+    envString("DETECTOR_CYCLE_START_TOKEN", &d.CycleStartToken)
+    if err := envInt("DETECTOR_CYCLE_MAX_HOPS", &d.CycleMaxHops); err != nil {
+        return err
+    }
+    if err := envInt64("DETECTOR_CYCLE_MIN_PROFIT_BPS", &d.CycleMinProfitBps); err != nil {
+        return err
+    }
+    
+    envString("DETECTOR_ORACLE_LAYOUT", &d.OracleLayout)
+    for key, dst := range map[string]*int64{
+        "DETECTOR_ORACLE_MIN_PRICE":    &d.OracleMinPrice,
+        "DETECTOR_ORACLE_MAX_PRICE":    &d.OracleMaxPrice,
+        "DETECTOR_ORACLE_MAX_JUMP_BPS": &d.OracleMaxJumpBps,
+    } {
+        if err := envInt64(key, dst); err != nil {
+            return err
+        }
+    }
+    if raw := os.Getenv("DETECTOR_ORACLE_ASSET_BOUNDS"); raw != "" {
+        bounds, err := ParsePriceBounds(raw)
+        if err != nil {
+            return fmt.Errorf("invalid DETECTOR_ORACLE_ASSET_BOUNDS: %w", err)
+        }
+        d.OracleAssetBounds = bounds
+    }
+    
+    envString("FUNDING_PRECOMPILE_ADDRESS", &d.FundingPrecompile)
+    envString("DEPTH_PRECOMPILE_ADDRESS", &d.DepthPrecompile)
+    return nil
+}
+
+func (e *ExecutorConfig) applyEnv() error {
+    envString("EXECUTOR_PRIVATE_KEY", &e.PrivateKey)
+    if raw := os.Getenv("EXECUTOR_PRIVATE_KEYS"); raw != "" {
+        e.PrivateKeys = splitList(raw)
+    }
+    envString("CORE_EVM_ARBITRAGE_ADDRESS", &e.ArbContract)
+    
+    for key, dst := range map[string]*uint64{
+        "EXECUTOR_MAX_GAS_PRICE":           &e.MaxGasPrice,
+        "EXECUTOR_DEFAULT_GAS_LIMIT":       &e.DefaultGasLimit,
+        "EXECUTOR_GAS_BUFFER_PERCENT":      &e.GasBufferPercent,
+        "EXECUTOR_CAPITAL_FRACTION_BPS":    &e.Capital.FractionBps,
+        "EXECUTOR_MIN_TRADE_SIZE":          &e.Capital.MinTradeSize,
+        "EXECUTOR_SLIPPAGE_TOLERANCE_BPS":  &e.SlippageToleranceBps,
+        "EXECUTOR_SPOT_HALF_SPREAD_BPS":    &e.SpotHalfSpreadBps,
+        "EXECUTOR_PERP_HALF_SPREAD_BPS":    &e.PerpHalfSpreadBps,
+        "EXECUTOR_MIN_GAS_BALANCE":         &e.MinGasBalance,
+        "EXECUTOR_PRIORITY_FEE_BASE":       &e.PriorityFeeBase,
+        "EXECUTOR_PRIORITY_FEE_PER_DOLLAR": &e.PriorityFeePerDollar,
+        "EXECUTOR_PRIORITY_FEE_MAX":        &e.PriorityFeeMax,
+    } {
+        if err := envUint64(key, dst); err != nil {
+            return err
+        }
+    }
+    
+    if err := envDuration("EXECUTOR_RECEIPT_TIMEOUT", &e.ReceiptTimeout); err != nil {
+        return err
+    }
+    if err := envBool("DRY_RUN", &e.DryRun); err != nil {
+        return err
+    }
+    if err := envInt64("NATIVE_TOKEN_PRICE", &e.NativeTokenPrice); err != nil {
+        return err
+    }
+    envString("NATIVE_PRICE_ASSET", &e.NativePriceAsset)
+    if err := envInt64("EXECUTOR_MIN_NET_PROFIT", &e.MinNetProfit); err != nil {
+        return err
+    }
+    envString("EXECUTOR_MIN_NET_PROFIT_USD", &e.MinNetProfitUSD)
+    if err := envInt64("EXECUTOR_MIN_SPREAD_BPS", &e.MinSpreadBps); err != nil {
+        return err
+    }
+    if err := envInt64("EXECUTOR_SPOT_FEE_BPS", &e.SpotFeeBps); err != nil {
+        return err
+    }
+    if err := envInt64("EXECUTOR_PERP_FEE_BPS", &e.PerpFeeBps); err != nil {
+        return err
+    }
+    if err := envDuration("EXECUTOR_MAX_OPPORTUNITY_AGE", &e.MaxOpportunityAge); err != nil {
+        return err
+    }
+    if err := envDuration("EXECUTOR_REPLAY_WINDOW", &e.ReplayWindow); err != nil {
+        return err
+    }
+    if err := envDuration("EXECUTOR_FAILURE_COOLDOWN", &e.FailureCooldown); err != nil {
+        return err
+    }
+    if err := envDuration("EXECUTOR_MIN_EXECUTION_INTERVAL", &e.MinExecutionInterval); err != nil {
+        return err
+    }
+    if err := envDuration("EXECUTOR_WARM_UP", &e.WarmUp); err != nil {
+        return err
+    }
+    if err := envDuration("EXECUTOR_GAS_BALANCE_INTERVAL", &e.GasBalanceInterval); err != nil {
+        return err
+    }
+    if err := envInt64("EXECUTOR_MAX_GAS_PROFIT_RATIO_BPS", &e.MaxGasProfitRatioBps); err != nil {
+        return err
+    }
+    if err := envDuration("EXECUTOR_REPLACE_AFTER", &e.ReplaceAfter); err != nil {
+        return err
+    }
+    if err := envUint64("EXECUTOR_REPLACE_BUMP_PERCENT", &e.ReplaceBumpPercent); err != nil {
+        return err
+    }
+    envString("EXECUTOR_REPLACE_MODE", &e.ReplaceMode)
+    if err := envInt("EXECUTOR_MAX_CONCURRENT_EXECUTIONS", &e.MaxConcurrentExecutions); err != nil {
+        return err
+    }
+    if err := envDuration("EXECUTOR_RECONCILE_INTERVAL", &e.ReconcileInterval); err != nil {
+        return err
+    }
+    if err := envInt64("EXECUTOR_RECONCILE_TOLERANCE", &e.ReconcileTolerance); err != nil {
+        return err
+    }
+    if err := envInt("EXECUTOR_SEND_RETRIES", &e.SendRetries); err != nil {
+        return err
+    }
+    if err := envDuration("EXECUTOR_SEND_RETRY_BACKOFF", &e.SendRetryBackoff); err != nil {
+        return err
+    }
+    
+    envString("EXECUTOR_LEDGER_PATH", &e.LedgerPath)
+    envString("EXECUTOR_NONCE_STATE_PATH", &e.NonceStatePath)
+    envString("EXECUTOR_KILL_SWITCH_PATH", &e.KillSwitchPath)
+    envString("EXECUTOR_RELAY_URL", &e.RelayURL)
+    envString("EXECUTOR_CAPITAL_TOKEN", &e.Capital.Token)
+    if err := envInt("EXECUTOR_CAPITAL_DECIMALS", &e.Capital.Decimals); err != nil {
+        return err
+    }
+    
+    if raw := os.Getenv("EXECUTOR_APPROVAL_TOKENS"); raw != "" {
+        e.Approvals.Tokens = splitList(raw)
+    }
+    envString("EXECUTOR_APPROVAL_AMOUNT", &e.Approvals.Amount)
+    
+    if raw := os.Getenv("EXECUTOR_LOT_SIZES"); raw != "" {
+        lots, err := ParseLotSizes(raw)
+        if err != nil {
+            return fmt.Errorf("invalid EXECUTOR_LOT_SIZES: %w", err)
+        }
+        e.LotSizes = lots
+    }
+    if raw := os.Getenv("EXECUTOR_ASSET_FEES"); raw != "" {
+        fees, err := ParseAssetFees(raw)
+        if err != nil {
+            return fmt.Errorf("invalid EXECUTOR_ASSET_FEES: %w", err)
+        }
+        e.AssetFees = fees
+    }
+    if raw := os.Getenv("EXECUTOR_MAX_EXPOSURE"); raw != "" {
+        limits, err := ParseAssetThresholds(raw)
+        if err != nil {
+            return fmt.Errorf("invalid EXECUTOR_MAX_EXPOSURE: %w", err)
+        }
+        e.MaxExposure = limits
+    }
+    if err := envDuration("EXECUTOR_APPROVAL_TTL", &e.Approvals.TTL); err != nil {
+        return err
+    }
+    return e.Conversion.applyEnv()
+}
+
+func (c *ConversionConfig) applyEnv() error {
+    if err := envBool("EXECUTOR_CONVERSION_ENABLED", &c.Enabled); err != nil {
+        return err
+    }
+    envString("EXECUTOR_CONVERSION_ROUTER", &c.Router)
+    envString("EXECUTOR_CONVERSION_TOKEN", &c.Token)
+    envString("EXECUTOR_CONVERSION_SETTLEMENT_TOKEN", &c.SettlementToken)
+    if err := envInt("EXECUTOR_CONVERSION_DECIMALS", &c.Decimals); err != nil {
+        return err
+    }
+    if err := envInt64("EXECUTOR_CONVERSION_TOKEN_PRICE", &c.TokenPrice); err != nil {
+        return err
+    }
+    if err := envInt64("EXECUTOR_CONVERSION_THRESHOLD", &c.Threshold); err != nil {
+        return err
+    }
+    if err := envUint64("EXECUTOR_CONVERSION_SLIPPAGE_BPS", &c.SlippageBps); err != nil {
+        return err
+    }
+    return envUint64("EXECUTOR_CONVERSION_GAS_LIMIT", &c.GasLimit)
+}
+
+func (b *BreakerConfig) applyEnv() error {
+    if err := envInt("BREAKER_MAX_FAILURES", &b.MaxFailures); err != nil {
+        return err
+    }
+    if err := envInt64("BREAKER_MAX_LOSS", &b.MaxLoss); err != nil {
+        return err
+    }
+    if err := envDuration("BREAKER_WINDOW", &b.Window); err != nil {
+        return err
+    }
+    if err := envInt64("BREAKER_DAILY_MAX_LOSS", &b.DailyMaxLoss); err != nil {
+        return err
+    }
+    if err := envInt64("BREAKER_DAILY_MAX_VOLUME", &b.DailyMaxVolume); err != nil {
+        return err
+    }
+    envString("BREAKER_MAX_LOSS_USD", &b.MaxLossUSD)
+    envString("BREAKER_DAILY_MAX_LOSS_USD", &b.DailyMaxLossUSD)
+    envString("BREAKER_DAILY_MAX_VOLUME_USD", &b.DailyMaxVolumeUSD)
+    return envDuration("BREAKER_COOLDOWN", &b.Cooldown)
+}
+
+func (a *AlertConfig) applyEnv() error {
+    envString("ALERT_WEBHOOK_URL", &a.WebhookURL)
+    envString("ALERT_TELEGRAM_TOKEN", &a.TelegramToken)
+    envString("ALERT_TELEGRAM_CHAT_ID", &a.TelegramChatID)
+    if err := envInt64("ALERT_PROFIT_THRESHOLD", &a.ProfitThreshold); err != nil {
+        return err
+    }
+    envString("ALERT_PROFIT_THRESHOLD_USD", &a.ProfitThresholdUSD)
+    if err := envInt("ALERT_FAILURE_THRESHOLD", &a.FailureThreshold); err != nil {
+        return err
+    }
+    if err := envDuration("ALERT_NO_OPPORTUNITY_AFTER", &a.NoOpportunityAfter); err != nil {
+        return err
+    }
+    return envDuration("ALERT_MIN_INTERVAL", &a.MinInterval)
+}
+
+// ParseAssetThresholds parses per-asset thresholds in the form "0:10,5:25".
+func ParseAssetThresholds(raw string) (map[uint32]int64, error) {
+    thresholds := make(map[uint32]int64)
+    
+    for _, field := range splitList(raw) {
+        assetPart, valuePart, ok := strings.Cut(field, ":")
+        if !ok {
+            return nil, fmt.Errorf("entry %q is not asset:threshold", field)
+        }
+        
+        id, err := strconv.ParseUint(strings.TrimSpace(assetPart), 10, 32)
+        if err != nil {
+            return nil, fmt.Errorf("asset %q is not a valid id", assetPart)
+        }
+        
+        value, err := strconv.ParseInt(strings.TrimSpace(valuePart), 10, 64)
+        if err != nil {
+            return nil, fmt.Errorf("asset %d: %q is not an integer", id, valuePart)
+        }
+        thresholds[uint32(id)] = value
+    }
+    
+    return thresholds, nil
+}
+
+// ParseLotSizes parses per-asset size limits in the form
+// "asset:min:max:step", e.g. "0:1000000:0:100000,5:50000000:0:1000000".
+func ParseLotSizes(raw string) (map[uint32]LotSizeConfig, error) {
+    lots := make(map[uint32]LotSizeConfig)
+    
+    for _, field := range splitList(raw) {
+        parts := strings.Split(field, ":")
+        if len(parts) != 4 {
+            return nil, fmt.Errorf("entry %q is not asset:min:max:step", field)
+        }
+        
+        id, err := strconv.ParseUint(strings.TrimSpace(parts[0]), 10, 32)
+        if err != nil {
+            return nil, fmt.Errorf("asset %q is not a valid id", parts[0])
+        }
+        
+        var values [3]uint64
+        for i, part := range parts[1:] {
+            values[i], err = strconv.ParseUint(strings.TrimSpace(part), 10, 64)
+            if err != nil {
+                return nil, fmt.Errorf("asset %d: %q is not an unsigned integer", id, part)
+            }
+        }
+        lots[uint32(id)] = LotSizeConfig{Min: values[0], Max: values[1], Step: values[2]}
+    }
+    
+    return lots, nil
+}
+
+// ParseAssetFees parses per-asset taker fees in the form
+// "asset:spot_bps:perp_bps", e.g. "0:4:2,5:7:-1", a negative fee being a
+// rebate.
+func ParseAssetFees(raw string) (map[uint32]AssetFeeConfig, error) {
+    fees := make(map[uint32]AssetFeeConfig)
+    
+    for _, field := range splitList(raw) {
+        parts := strings.Split(field, ":")
+        if len(parts) != 3 {
+            return nil, fmt.Errorf("entry %q is not asset:spot_bps:perp_bps", field)
+        }
+        
+        id, err := strconv.ParseUint(strings.TrimSpace(parts[0]), 10, 32)
+        if err != nil {
+            return nil, fmt.Errorf("asset %q is not a valid id", parts[0])
+        }
+        
+        var values [2]int64
+        for i, part := range parts[1:] {
+            values[i], err = strconv.ParseInt(strings.TrimSpace(part), 10, 64)
+            if err != nil {
+                return nil, fmt.Errorf("asset %d: %q is not an integer", id, part)
+            }
+        }
+        fees[uint32(id)] = AssetFeeConfig{SpotFeeBps: values[0], PerpFeeBps: values[1]}
+    }
+    
+    return fees, nil
+}
+
+// ParsePriceBounds parses per-asset oracle price bounds in the form
+// "asset:min:max", e.g. "0:1000000000:20000000000000,5:0:100000000000".
+func ParsePriceBounds(raw string) (map[uint32]PriceBounds, error) {
+    bounds := make(map[uint32]PriceBounds)
+    
+    for _, field := range splitList(raw) {
+        parts := strings.Split(field, ":")
+        if len(parts) != 3 {
+            return nil, fmt.Errorf("entry %q is not asset:min:max", field)
+        }
+        
+        id, err := strconv.ParseUint(strings.TrimSpace(parts[0]), 10, 32)
+        if err != nil {
+            return nil, fmt.Errorf("asset %q is not a valid id", parts[0])
+        }
+        
+        var values [2]int64
+        for i, part := range parts[1:] {
+            values[i], err = strconv.ParseInt(strings.TrimSpace(part), 10, 64)
+            if err != nil {
+                return nil, fmt.Errorf("asset %d: %q is not an integer", id, part)
+            }
+        }
+        bounds[uint32(id)] = PriceBounds{Min: values[0], Max: values[1]}
+    }
+    
+    return bounds, nil
+}
+
+// ParseCyclePairs parses cycle pairs in the form "pair:base:quote:fee_bps",
+// e.g. "107:HYPE:USDC:7,140:PURR:HYPE:7".
+func ParseCyclePairs(raw string) ([]CyclePairConfig, error) {
+    var pairs []CyclePairConfig
+    
+    for _, field := range splitList(raw) {
+        parts := strings.Split(field, ":")
+        if len(parts) != 4 {
+            return nil, fmt.Errorf("entry %q is not pair:base:quote:fee_bps", field)
+        }
+        
+        id, err := strconv.ParseUint(strings.TrimSpace(parts[0]), 10, 32)
+        if err != nil {
+            return nil, fmt.Errorf("pair %q is not a valid index", parts[0])
+        }
+        fee, err := strconv.ParseInt(strings.TrimSpace(parts[3]), 10, 64)
+        if err != nil {
+            return nil, fmt.Errorf("pair %d: fee %q is not an integer", id, parts[3])
+        }
+        pairs = append(pairs, CyclePairConfig{
+            Pair:   uint32(id),
+            Base:   strings.TrimSpace(parts[1]),
+            Quote:  strings.TrimSpace(parts[2]),
+            FeeBps: fee,
+        })
+    }
+    
+    return pairs, nil
+}
+
+// splitList splits a comma-separated value, dropping blank entries.
+func splitList(raw string) []string {
+    var fields []string
+    for _, field := range strings.Split(raw, ",") {
+        if field = strings.TrimSpace(field); field != "" {
+            fields = append(fields, field)
+        }
+    }
+    return fields
+}
+
+func envString(key string, dst *string) {
+    if raw := os.Getenv(key); raw != "" {
+        *dst = raw
+    }
+}
+
+// envURL is envString for endpoints: a variable that is set but empty is a
+// configuration error rather than a request for the file or default value.
+func envURL(key string, dst *string) error {
+    raw, ok := os.LookupEnv(key)
+    if !ok {
+        return nil
+    }
+    if raw == "" {
+        return fmt.Errorf("%s is set but empty", key)
+    }
+    *dst = raw
+    return nil
+}
+
+func envDuration(key string, dst *time.Duration) error {
+    raw := os.Getenv(key)
+    if raw == "" {
+        return nil
+    }
+    
+    value, err := time.ParseDuration(raw)
+    if err != nil {
+        return fmt.Errorf("invalid %s: %w", key, err)
+    }
+    *dst = value
+    return nil
+}
+
+func envBool(key string, dst *bool) error {
+    raw := os.Getenv(key)
+    if raw == "" {
+        return nil
+    }
+    
+    value, err := strconv.ParseBool(raw)
+    if err != nil {
+        return fmt.Errorf("invalid %s %q", key, raw)
+    }
+    *dst = value
+    return nil
+}
+
+func envInt(key string, dst *int) error {
+    raw := os.Getenv(key)
+    if raw == "" {
+        return nil
+    }
+    
+    value, err := strconv.Atoi(strings.TrimSpace(raw))
+    if err != nil {
+        return fmt.Errorf("invalid %s %q", key, raw)
+    }
+    *dst = value
+    return nil
+}
+
+func envInt64(key string, dst *int64) error {
+    raw := os.Getenv(key)
+    if raw == "" {
+        return nil
+    }
+    
+    value, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+    if err != nil {
+        return fmt.Errorf("invalid %s %q", key, raw)
+    }
+    *dst = value
+    return nil
+}
+
+func envUint64(key string, dst *uint64) error {
+    raw := os.Getenv(key)
+    if raw == "" {
+        return nil
+    }
+    
+    value, err := strconv.ParseUint(strings.TrimSpace(raw), 10, 64)
+    if err != nil {
+        return fmt.Errorf("invalid %s: %w", key, err)
+    }
+    *dst = value
+    return nil
+}
\ No newline at end of file
@@ -0,0 +1,56 @@
+package detector
+
+import (
+    "time"
+
+    "github.com/sirupsen/logrus"
+)
+
+// The executor is considered behind while the opportunities channel is at
+// least backlogHighPercent full after a scan, and caught up once it drains
+// below backlogLowPercent.
+const (
+    backlogHighPercent = 75
+    backlogLowPercent  = 25
+)
+
+// adaptInterval returns the poll interval for the next scan. With
+// AdaptivePoll set it doubles interval, up to MaxPollInterval, while the
+// channel backlog is high and halves it back towards PollInterval once the
+// backlog clears, so polling slows under load but never stops.
+func (d *Detector) adaptInterval(interval time.Duration, opportunities chan<- *Opportunity) time.Duration {
+    if !d.AdaptivePoll || cap(opportunities) == 0 {
+        return d.PollInterval
+    }
+    
+    fill := len(opportunities) * 100 / cap(opportunities)
+    next := interval
+    switch {
+    case fill >= backlogHighPercent:
+        next = interval * 2
+        if next > d.MaxPollInterval {
+            next = d.MaxPollInterval
+        }
+    case fill < backlogLowPercent:
+        next = interval / 2
+        if next < d.PollInterval {
+            next = d.PollInterval
+        }
+    }
+    
+    if next != interval {
+        d.logger.WithFields(logrus.Fields{
+            "backlog_percent": fill,
+            "poll_interval":   next,
+        }).Info("Adjusting poll interval for executor backlog")
+    }
+    return next
+}
+
+// longestPoll is the longest interval polling can settle at.
+func (d *Detector) longestPoll() time.Duration {
+    if d.AdaptivePoll && d.MaxPollInterval > d.PollInterval {
+        return d.MaxPollInterval
+    }
+    return d.PollInterval
+}
\ No newline at end of file
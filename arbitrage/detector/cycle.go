@@ -0,0 +1,197 @@
+package detector
+
+import (
+    "context"
+    "math/big"
+    "strings"
+
+    "github.com/google/uuid"
+    "github.com/hypercore-suite/arbitrage/config"
+    "github.com/hypercore-suite/arbitrage/pricing"
+    "github.com/hypercore-suite/arbitrage/trade"
+    "github.com/sirupsen/logrus"
+)
+
+// cycleEdge is one direction of trading through a spot pair: selling its
+// base for its quote, or buying its base with its quote.
+type cycleEdge struct {
+    pair   uint32
+    from   string
+    to     string
+    sell   bool
+    feeBps int64
+}
+
+// cycleDetector finds multi-hop cycles across spot pairs that end with more
+// of the start token than they began with. The graph is fixed by
+// configuration, so every candidate cycle is enumerated once up front and
+// only re-priced on each scan.
+type cycleDetector struct {
+    start        string
+    minProfitBps int64
+    cycles       [][]cycleEdge
+}
+
+// newCycleDetector returns nil when no cycle pairs are configured.
+func newCycleDetector(detCfg config.DetectorConfig) *cycleDetector {
+    if len(detCfg.CyclePairs) == 0 {
+        return nil
+    }
+    
+    edges := make(map[string][]cycleEdge)
+    for _, p := range detCfg.CyclePairs {
+        base, quote := strings.ToUpper(strings.TrimSpace(p.Base)), strings.ToUpper(strings.TrimSpace(p.Quote))
+        edges[base] = append(edges[base], cycleEdge{pair: p.Pair, from: base, to: quote, sell: true, feeBps: p.FeeBps})
+        edges[quote] = append(edges[quote], cycleEdge{pair: p.Pair, from: quote, to: base, feeBps: p.FeeBps})
+    }
+    
+    c := &cycleDetector{
+        start:        strings.ToUpper(strings.TrimSpace(detCfg.CycleStartToken)),
+        minProfitBps: detCfg.CycleMinProfitBps,
+    }
+    c.cycles = findCycles(edges, c.start, detCfg.CycleMaxHops)
+    return c
+}
+
+// findCycles returns every path from start back to it of three to maxHops
+// trades that visits no token and uses no pair twice. Each cycle is found in
+// both directions, since either may be the profitable one.
+func findCycles(edges map[string][]cycleEdge, start string, maxHops int) [][]cycleEdge {
+    var cycles [][]cycleEdge
+    visited := map[string]bool{start: true}
+    usedPairs := make(map[uint32]bool)
+    
+    var walk func(token string, path []cycleEdge)
+    walk = func(token string, path []cycleEdge) {
+        for _, edge := range edges[token] {
+            if usedPairs[edge.pair] {
+                continue
+            }
+            
+            next := append(path[:len(path):len(path)], edge)
+            if edge.to == start {
+                if len(next) >= 3 {
+                    cycles = append(cycles, next)
+                }
+                continue
+            }
+            if visited[edge.to] || len(next) >= maxHops {
+                continue
+            }
+            
+            visited[edge.to], usedPairs[edge.pair] = true, true
+            walk(edge.to, next)
+            visited[edge.to], usedPairs[edge.pair] = false, false
+        }
+    }
+    walk(start, nil)
+    return cycles
+}
+
+// detectCycles prices every cycle at the current spot oracle prices and
+// returns those clearing the minimum profit, each flagged as KindCycle.
+// Cycles through a pair without a fresh price, or one Filter disables, are
+// skipped.
+func (d *Detector) detectCycles(ctx context.Context) []*Opportunity {
+    if d.cycles == nil {
+        return nil
+    }
+    
+    var cycles [][]cycleEdge
+    for _, cycle := range d.cycles.cycles {
+        if d.cycleEnabled(cycle) {
+            cycles = append(cycles, cycle)
+        }
+    }
+    
+    now := d.now()
+    prices := make(map[uint32]*big.Int)
+    for _, cycle := range cycles {
+        for _, edge := range cycle {
+            if _, ok := prices[edge.pair]; ok {
+                continue
+            }
+            price := d.getSpotPrice(ctx, edge.pair)
+            if price == nil || price.Sign() <= 0 || d.spotFreshness.age(edge.pair, now) > d.MaxPriceAge {
+                price = nil
+            }
+            prices[edge.pair] = price
+        }
+    }
+    
+    var found []*Opportunity
+    for _, cycle := range cycles {
+        if opp := d.priceCycle(cycle, prices); opp != nil {
+            found = append(found, opp)
+        }
+    }
+    return found
+}
+
+// cycleEnabled reports whether Filter enables every pair cycle trades.
+func (d *Detector) cycleEnabled(cycle []cycleEdge) bool {
+    if d.Filter == nil {
+        return true
+    }
+    for _, edge := range cycle {
+        if !d.Filter.Enabled(edge.pair) {
+            return false
+        }
+    }
+    return true
+}
+
+// priceCycle walks one unit of the start token around cycle, returning an
+// opportunity when what comes back clears the minimum profit after fees.
+func (d *Detector) priceCycle(cycle []cycleEdge, prices map[uint32]*big.Int) *Opportunity {
+    unit := pricing.Pow10(PriceDecimals)
+    gross, net := new(big.Int).Set(unit), new(big.Int).Set(unit)
+    path := make([]trade.Hop, 0, len(cycle))
+    labels := []string{d.cycles.start}
+    
+    for _, edge := range cycle {
+        price := prices[edge.pair]
+        if price == nil {
+            return nil
+        }
+        
+        // Pairs are priced as quote per base; buying the base takes the
+        // inverse rate.
+        rate := new(big.Int).Set(price)
+        if !edge.sell {
+            rate.Mul(unit, unit)
+            rate.Quo(rate, price)
+        }
+        gross.Mul(gross, rate).Quo(gross, unit)
+        net.Mul(net, rate).Quo(net, unit)
+        net.Mul(net, big.NewInt(10000-edge.feeBps)).Quo(net, big.NewInt(10000))
+        
+        path = append(path, trade.Hop{Pair: edge.pair, From: edge.from, To: edge.to, Rate: rate})
+        labels = append(labels, edge.to)
+    }
+    
+    netGain := new(big.Int).Sub(net, unit)
+    netBps := pricing.MulDiv(netGain, big.NewInt(10000), unit, pricing.RoundDown)
+    if netGain.Sign() <= 0 || netBps.Int64() < d.cycles.minProfitBps {
+        return nil
+    }
+    symbol := strings.Join(labels, ">")
+    
+    opp := &Opportunity{
+        ID:        uuid.NewString(),
+        Kind:      trade.KindCycle,
+        Asset:     path[0].Pair,
+        Symbol:    symbol,
+        Spread:    new(big.Int).Sub(gross, unit),
+        SpreadBps: netBps.Int64(),
+        NetSpread: netGain,
+        Amount:    new(big.Int).Set(d.MaxTradeSize),
+        Path:      path,
+        Timestamp: d.now(),
+    }
+    d.logger.WithFields(opp.LogFields()).WithFields(logrus.Fields{
+        "hops":       len(path),
+        "spread_bps": opp.SpreadBps,
+    }).Debug("Cycle candidate")
+    return opp
+}
\ No newline at end of file
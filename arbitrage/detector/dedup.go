@@ -0,0 +1,76 @@
+package detector
+
+import (
+    "sync"
+    "time"
+
+    "github.com/hypercore-suite/arbitrage/trade"
+)
+
+// emitDedup suppresses repeat opportunities for an asset, or for a cycle. A
+// new opportunity is only emitted within window of the previous one when its
+// spread has moved by at least minChangeBps; a zero window disables
+// deduplication.
+type emitDedup struct {
+    window       time.Duration
+    minChangeBps int64
+    
+    mu   sync.Mutex
+    last map[dedupKey]emitted
+}
+
+// dedupKey identifies what an opportunity repeats: its asset and direction,
+// or for a cycle its path, so a cycle never suppresses its first pair's
+// perp/spot opportunity and a spread that flips sign is never taken for the
+// one before it.
+type dedupKey struct {
+    asset uint32
+    isBuy bool
+    cycle string
+}
+
+func keyOf(opp *Opportunity) dedupKey {
+    if opp.Kind == trade.KindCycle {
+        return dedupKey{asset: opp.Asset, cycle: opp.Symbol}
+    }
+    return dedupKey{asset: opp.Asset, isBuy: opp.IsBuy}
+}
+
+type emitted struct {
+    spreadBps int64
+    at        time.Time
+}
+
+func newEmitDedup(window time.Duration, minChangeBps int64) *emitDedup {
+    return &emitDedup{
+        window:       window,
+        minChangeBps: minChangeBps,
+        last:         make(map[dedupKey]emitted),
+    }
+}
+
+// duplicate reports whether opp repeats the last opportunity emitted for its
+// asset in the same direction.
+func (d *emitDedup) duplicate(opp *Opportunity, now time.Time) bool {
+    d.mu.Lock()
+    defer d.mu.Unlock()
+    
+    prev, ok := d.last[keyOf(opp)]
+    if !ok || d.window == 0 || now.Sub(prev.at) >= d.window {
+        return false
+    }
+    
+    change := opp.SpreadBps - prev.spreadBps
+    if change < 0 {
+        change = -change
+    }
+    return change < d.minChangeBps
+}
+
+// record marks opp as emitted at now.
+func (d *emitDedup) record(opp *Opportunity, now time.Time) {
+    d.mu.Lock()
+    defer d.mu.Unlock()
+    
+    d.last[keyOf(opp)] = emitted{spreadBps: opp.SpreadBps, at: now}
+}
\ No newline at end of file
@@ -0,0 +1,85 @@
+package detector
+
+import (
+    "context"
+    "testing"
+    "time"
+
+    "github.com/hypercore-suite/arbitrage/config"
+)
+
+// countingMonitor counts the opportunities the detector reports.
+type countingMonitor struct {
+    nopMonitor
+    opportunities int
+}
+
+func (m *countingMonitor) RecordOpportunity(asset uint32, spreadBps int64) {
+    m.opportunities++
+}
+
+func TestScanDeduplicatesRepeatedOpportunities(t *testing.T) {
+    tests := []struct {
+        name       string
+        after      time.Duration
+        secondPerp int64
+        want       int
+    }{
+        {"same price on the next tick", 100 * time.Millisecond, 10050000000, 1},
+        {"small spread change", 100 * time.Millisecond, 10052000000, 1},
+        {"spread moved past the minimum change", 100 * time.Millisecond, 10080000000, 2},
+        {"same price after the window", 1500 * time.Millisecond, 10050000000, 2},
+        {"spread flipped direction", 100 * time.Millisecond, 9950000000, 2},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            d := newTestDetector(t, func(cfg *config.Config) {
+                cfg.Detector.Assets = []uint32{0}
+                cfg.Detector.DedupWindow = time.Second
+                cfg.Detector.DedupMinChangeBps = 5
+            })
+            monitor := &countingMonitor{}
+            d.monitor = monitor
+            opportunities := make(chan *Opportunity, 2)
+            
+            d.replay.Set(quote(0, 10050000000, 10000000000))
+            d.scan(context.Background(), opportunities)
+            
+            d.clock = testNow.Add(tt.after)
+            second := quote(0, tt.secondPerp, 10000000000)
+            second.Time = d.clock
+            d.replay.Set(second)
+            d.scan(context.Background(), opportunities)
+            
+            if got := len(opportunities); got != tt.want {
+                t.Fatalf("emitted %d opportunities, want %d", got, tt.want)
+            }
+            if monitor.opportunities != tt.want {
+                t.Errorf("counted %d opportunities, want the %d emitted", monitor.opportunities, tt.want)
+            }
+        })
+    }
+}
+func TestScanCountsOnlyEmittedOpportunities(t *testing.T) {
+    d := newTestDetector(t, func(cfg *config.Config) {
+        cfg.Detector.Assets = []uint32{0, 1}
+    })
+    monitor := &countingMonitor{}
+    d.monitor = monitor
+    d.replay.Set(quote(0, 10050000000, 10000000000))
+    d.replay.Set(quote(1, 10050000000, 10000000000))
+    
+    // The channel holds one; the other opportunity is dropped.
+    opportunities := make(chan *Opportunity, 1)
+    d.scan(context.Background(), opportunities)
+    
+    if monitor.opportunities != 1 {
+        t.Errorf("counted %d opportunities, want only the one emitted", monitor.opportunities)
+    }
+    
+    d.Snapshot(context.Background())
+    if monitor.opportunities != 1 {
+        t.Errorf("Snapshot counted opportunities: %d", monitor.opportunities)
+    }
+}
@@ -0,0 +1,133 @@
+package detector
+
+import (
+    "context"
+    "math/big"
+    "strings"
+    "time"
+
+    "github.com/ethereum/go-ethereum"
+    "github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// BookLevel is one price level of the spot order book, in PriceDecimals
+// fixed point for both price and size.
+type BookLevel struct {
+    Price *big.Int
+    Size  *big.Int
+}
+
+// bookABI decodes the depth precompile's (uint256[] prices, uint256[] sizes)
+// response, ordered from the top of the book outwards.
+var bookABI = func() abi.Arguments {
+    parsed, err := abi.JSON(strings.NewReader(`[{"type":"function","name":"book","outputs":[{"name":"prices","type":"uint256[]"},{"name":"sizes","type":"uint256[]"}]}]`))
+    if err != nil {
+        panic(err)
+    }
+    return parsed.Methods["book"].Outputs
+}()
+
+// getOrderBook reads the side of the spot book a trade would cross: asks when
+// buying spot, bids when selling. It returns nil when the book is unavailable.
+func (d *Detector) getOrderBook(ctx context.Context, asset uint32, buySpot bool) []BookLevel {
+    side := byte(0)
+    if buySpot {
+        side = 1
+    }
+    data := append(encodeAsset(asset), make([]byte, 32)...)
+    data[63] = side
+    
+    start := time.Now()
+    result, err := d.coreClient.CallContract(ctx, ethereum.CallMsg{
+        To:   d.depthAddr,
+        Data: data,
+    }, nil)
+    d.monitor.RecordRPCLatency("getOrderBook", time.Since(start))
+    if err != nil {
+        d.logger.WithError(err).WithField("asset", asset).Debug("Depth precompile call failed")
+        return nil
+    }
+    
+    out, err := bookABI.Unpack(result)
+    if err != nil || len(out) != 2 {
+        d.logger.WithField("asset", asset).Debug("Order book unavailable")
+        return nil
+    }
+    
+    prices, _ := out[0].([]*big.Int)
+    sizes, _ := out[1].([]*big.Int)
+    if len(prices) != len(sizes) {
+        return nil
+    }
+    
+    levels := make([]BookLevel, len(prices))
+    for i := range prices {
+        levels[i] = BookLevel{Price: normalizePrice(prices[i], spotOracleDecimals), Size: sizes[i]}
+    }
+    return levels
+}
+
+// sizeForSlippage walks levels and returns the largest size up to maxSize
+// whose volume-weighted fill price stays within maxSlippageBps of the top of
+// the book, together with that fill price. buying selects the ask side, where
+// slippage pushes the fill price up. A zero size means even the first level
+// is outside the limit or the book is empty.
+func sizeForSlippage(levels []BookLevel, buying bool, maxSlippageBps int64, maxSize *big.Int) (*big.Int, *big.Int) {
+    if len(levels) == 0 {
+        return new(big.Int), nil
+    }
+    
+    top := levels[0].Price
+    bound := new(big.Int).Mul(top, big.NewInt(10000+maxSlippageBps))
+    if !buying {
+        bound.Mul(top, big.NewInt(10000-maxSlippageBps))
+    }
+    bound.Quo(bound, big.NewInt(10000))
+    
+    filled := new(big.Int)
+    cost := new(big.Int)
+    
+    for _, level := range levels {
+        remaining := new(big.Int).Sub(maxSize, filled)
+        if remaining.Sign() <= 0 {
+            break
+        }
+        
+        take := new(big.Int).Set(level.Size)
+        if take.Cmp(remaining) > 0 {
+            take = remaining
+        }
+        
+        // Largest q at this level keeping the fill price within bound:
+        // buying:  q*(p-bound) <= bound*filled - cost
+        // selling: q*(bound-p) <= cost - bound*filled
+        gap := new(big.Int).Sub(level.Price, bound)
+        room := new(big.Int).Mul(bound, filled)
+        room.Sub(room, cost)
+        if !buying {
+            gap.Neg(gap)
+            room.Neg(room)
+        }
+        if gap.Sign() > 0 {
+            limit := room.Quo(room, gap)
+            if limit.Cmp(take) < 0 {
+                take = limit
+            }
+        }
+        if take.Sign() <= 0 {
+            break
+        }
+        
+        filled.Add(filled, take)
+        cost.Add(cost, new(big.Int).Mul(take, level.Price))
+        
+        if take.Cmp(level.Size) < 0 {
+            break
+        }
+    }
+    
+    if filled.Sign() == 0 {
+        return filled, nil
+    }
+    return filled, cost.Quo(cost, filled)
+}
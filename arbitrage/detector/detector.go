@@ -2,123 +2,506 @@ package detector
 
 import (
     "context"
+    "fmt"
     "math/big"
     "time"
 
     "github.com/ethereum/go-ethereum/common"
-    "github.com/ethereum/go-ethereum/ethclient"
+    "github.com/google/uuid"
+    "github.com/hypercore-suite/arbitrage/config"
+    "github.com/hypercore-suite/arbitrage/pricing"
+    "github.com/hypercore-suite/arbitrage/registry"
+    "github.com/hypercore-suite/arbitrage/thresholds"
+    "github.com/hypercore-suite/arbitrage/trade"
     "github.com/sirupsen/logrus"
+    "golang.org/x/sync/errgroup"
 )
 
-type Opportunity struct {
-    Asset       uint32
-    CorePrice   *big.Int
-    EVMPrice    *big.Int
-    Spread      *big.Int
-    IsBuy       bool
-    Amount      *big.Int
-    Timestamp   time.Time
-}
+// PriceDecimals is the fixed-point scale every oracle price is normalized to
+// before it reaches detectOpportunity. Spreads, amounts and the executor's
+// profit math all assume 1e8 units per dollar.
+const PriceDecimals = trade.PriceDecimals
+
+const (
+    perpOracleDecimals = 6
+    spotOracleDecimals = 8
+)
+
+// Opportunity is an alias of trade.Opportunity, kept while callers move to
+// the trade package.
+type Opportunity = trade.Opportunity
 
 type Detector struct {
     logger     *logrus.Logger
-    coreClient *ethclient.Client
-    evmClient  *ethclient.Client
+    coreClient *rpcClient
+    evmClient  *rpcClient
     monitor    Monitor
+    assets     *registry.Registry
     
-    perpOracleAddr common.Address
-    spotOracleAddr common.Address
+    Mode            string
+    WSURL           string
+    PollInterval    time.Duration
+    PollJitter      time.Duration
+    Assets          []uint32
+    Thresholds      *thresholds.Holder
+    HoldingPeriod   time.Duration
+    MaxSlippageBps  int64
+    MinTradeSize    *big.Int
+    MaxTradeSize    *big.Int
+    MaxPriceAge     time.Duration
+    ScanWorkers     int
+    SizeJitterBps   int64
+    AdaptivePoll    bool
+    MaxPollInterval time.Duration
+    Filter          AssetFilter
+    Feed            OpportunityFeed
+    Prices          PriceSource
+    Fees            trade.FeeModel
+    OwnAddresses    []common.Address
+    
+    fundingAddr *common.Address
+    depthAddr   *common.Address
+    
+    perpFreshness *priceFreshness
+    spotFreshness *priceFreshness
+    dedup         *emitDedup
+    competition   *competitionTracker
+    sizing        *notionalSizing
+    cycles        *cycleDetector
+    
+    now    func() time.Time
+    replay StaticPrices
+    clock  time.Time
 }
 
 type Monitor interface {
-    RecordOpportunity(asset uint32, spread *big.Int)
+    RecordOpportunity(asset uint32, spreadBps int64)
+    RecordStalePrice(asset uint32)
+    RecordDropped(asset uint32)
+    RecordRPCHealth(endpoint string, healthy bool)
+    RecordRPCLatency(method string, elapsed time.Duration)
+    RecordTick(maxGap time.Duration)
+    RecordCompetition(asset uint32, contested bool)
+    RecordOracleSanityFail(asset uint32, market, reason string)
+    RecordCycleOpportunity(cycle string, netBps int64)
 }
 
-func NewDetector(logger *logrus.Logger, monitor Monitor) (*Detector, error) {
-    coreClient, err := ethclient.Dial("https://rpc.hyperliquid.xyz/evm")
+// AssetFilter reports whether an asset is enabled for trading. Disabled
+// assets are not scanned; a nil Filter enables every asset.
+type AssetFilter interface {
+    Enabled(asset uint32) bool
+}
+
+// OpportunityFeed receives every opportunity the detector emits, for live
+// display. Publish must not block; a nil Feed publishes nothing.
+type OpportunityFeed interface {
+    Publish(opp *Opportunity)
+}
+
+const (
+    minPollInterval     = 50 * time.Millisecond
+    healthProbeInterval = 10 * time.Second
+    subscribeMaxTickGap = 30 * time.Second
+)
+
+// NewDetector builds a detector from an already validated configuration.
+func NewDetector(logger *logrus.Logger, monitor Monitor, cfg *config.Config) (*Detector, error) {
+    rpcCfg, detCfg := cfg.RPC, cfg.Detector
+    
+    if rpcCfg.CoreURL == rpcCfg.EVMURL && rpcCfg.CoreURL != config.DefaultCoreRPCURL {
+        logger.WithField("url", rpcCfg.CoreURL).Warn("HyperCore and HyperEVM RPC URLs point to the same endpoint")
+    }
+    
+    if detCfg.PollInterval < minPollInterval {
+        logger.WithField("poll_interval", detCfg.PollInterval).Warn("Detector poll interval below 50ms may hammer the RPC")
+    }
+    
+    fundingAddr := optionalAddress(detCfg.FundingPrecompile)
+    if fundingAddr == nil {
+        logger.Warn("Funding precompile address not set; funding is ignored in spread calculations")
+    }
+    
+    depthAddr := optionalAddress(detCfg.DepthPrecompile)
+    if depthAddr == nil {
+        logger.Warn("Depth precompile address not set; opportunities are sized at the max trade size at the oracle price")
+    }
+    
+    coreClient, err := dialRPC("hypercore", rpcCfg.CoreURL, logger, monitor, rpcCfg.MaxRetries, rpcCfg.RedialAfter, rpcCfg.CallTimeout)
     if err != nil {
-        return nil, err
+        return nil, fmt.Errorf("dial HyperCore RPC %s: %w", rpcCfg.CoreURL, err)
     }
     
-    evmClient, err := ethclient.Dial("https://rpc.hyperliquid.xyz/evm")
+    evmClient, err := dialRPC("hyperevm", rpcCfg.EVMURL, logger, monitor, rpcCfg.MaxRetries, rpcCfg.RedialAfter, rpcCfg.CallTimeout)
     if err != nil {
-        return nil, err
+        return nil, fmt.Errorf("dial HyperEVM RPC %s: %w", rpcCfg.EVMURL, err)
+    }
+    
+    var competition *competitionTracker
+    if detCfg.WatchMempool {
+        competition = newCompetitionTracker(cfg)
     }
     
     return &Detector{
-        logger:         logger,
-        coreClient:     coreClient,
-        evmClient:      evmClient,
-        monitor:        monitor,
-        perpOracleAddr: common.HexToAddress("0x0000000000000000000000000000000000000807"),
-        spotOracleAddr: common.HexToAddress("0x0000000000000000000000000000000000000808"),
+        logger:          logger,
+        coreClient:      coreClient,
+        evmClient:       evmClient,
+        monitor:         monitor,
+        assets:          cfg.Registry(),
+        Mode:            detCfg.Mode,
+        WSURL:           rpcCfg.WSURL,
+        PollInterval:    detCfg.PollInterval,
+        PollJitter:      detCfg.PollJitter,
+        Assets:          append([]uint32(nil), detCfg.Assets...),
+        Thresholds:      thresholds.New(thresholds.FromConfig(cfg)),
+        HoldingPeriod:   detCfg.HoldingPeriod,
+        MaxSlippageBps:  detCfg.MaxSlippageBps,
+        MinTradeSize:    big.NewInt(detCfg.MinTradeSize),
+        MaxTradeSize:    big.NewInt(detCfg.MaxTradeSize),
+        MaxPriceAge:     detCfg.MaxPriceAge,
+        ScanWorkers:     detCfg.ScanWorkers,
+        SizeJitterBps:   detCfg.SizeJitterBps,
+        AdaptivePoll:    detCfg.AdaptivePoll,
+        MaxPollInterval: detCfg.MaxPollInterval,
+        Prices:          newPrecompilePrices(logger, coreClient, monitor, cfg),
+        Fees:            trade.BpsFees{},
+        fundingAddr:     fundingAddr,
+        depthAddr:       depthAddr,
+        perpFreshness:   newPriceFreshness(),
+        spotFreshness:   newPriceFreshness(),
+        dedup:           newEmitDedup(detCfg.DedupWindow, detCfg.DedupMinChangeBps),
+        competition:     competition,
+        sizing:          newNotionalSizing(detCfg),
+        cycles:          newCycleDetector(detCfg),
+        now:             time.Now,
     }, nil
 }
 
+// Start scans for opportunities until ctx is cancelled. A scan already under
+// way finishes and forwards what it found; opportunities is then closed so
+// the consumer knows nothing more will arrive.
 func (d *Detector) Start(ctx context.Context, opportunities chan<- *Opportunity) {
-    ticker := time.NewTicker(100 * time.Millisecond)
-    defer ticker.Stop()
+    defer close(opportunities)
+    go d.probe(ctx)
+    if d.competition != nil {
+        go d.watchMempool(ctx)
+    }
     
-    assets := []uint32{0, 1, 2, 3, 4}
+    if d.Mode == ModeSubscribe {
+        err := d.subscribe(ctx, opportunities)
+        if err == nil {
+            return
+        }
+        d.logger.WithError(err).Warn("Head subscription unavailable, falling back to polling")
+    }
+    
+    d.poll(ctx, opportunities)
+}
+
+func (d *Detector) poll(ctx context.Context, opportunities chan<- *Opportunity) {
+    interval := d.PollInterval
+    timer := time.NewTimer(d.nextPoll(interval))
+    defer timer.Stop()
+    
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case <-timer.C:
+            d.scan(ctx, opportunities)
+            interval = d.adaptInterval(interval, opportunities)
+            timer.Reset(d.nextPoll(interval))
+        }
+    }
+}
+
+// probe periodically checks both RPC endpoints so their health is reported
+// even when no oracle reads are failing or succeeding.
+func (d *Detector) probe(ctx context.Context) {
+    ticker := time.NewTicker(healthProbeInterval)
+    defer ticker.Stop()
     
     for {
         select {
         case <-ctx.Done():
             return
         case <-ticker.C:
-            for _, asset := range assets {
-                opp := d.detectOpportunity(asset)
-                if opp != nil {
-                    select {
-                    case opportunities <- opp:
-                        d.logger.WithFields(logrus.Fields{
-                            "asset":  opp.Asset,
-                            "spread": opp.Spread,
-                        }).Info("Opportunity detected")
-                    default:
-                        d.logger.Warn("Opportunities channel full")
-                    }
+            d.coreClient.BlockNumber(ctx)
+            d.evmClient.BlockNumber(ctx)
+        }
+    }
+}
+
+// scan checks every configured asset once and forwards any opportunities.
+// Assets are checked concurrently on up to ScanWorkers goroutines so one slow
+// oracle read does not hold up the rest; results are forwarded in asset
+// order once the whole tick has been evaluated. Cancelling ctx abandons the
+// reads still in flight.
+func (d *Detector) scan(ctx context.Context, opportunities chan<- *Opportunity) {
+    maxGap := 3 * (d.longestPoll() + d.PollJitter)
+    if d.Mode == ModeSubscribe {
+        maxGap = subscribeMaxTickGap
+    }
+    d.monitor.RecordTick(maxGap)
+    d.expireCompetition()
+    
+    for _, opp := range d.detectAll(ctx) {
+        if opp != nil {
+            if d.dedup.duplicate(opp, opp.Timestamp) {
+                d.logger.WithFields(opp.LogFields()).WithField("spread_bps", opp.SpreadBps).Debug("Duplicate opportunity suppressed")
+                continue
+            }
+            
+            select {
+            case opportunities <- opp:
+                d.dedup.record(opp, opp.Timestamp)
+                d.recordEmitted(opp)
+                d.logger.WithFields(opp.LogFields()).WithField("spread", opp.Spread).Info("Opportunity detected")
+                if d.Feed != nil {
+                    d.Feed.Publish(opp)
                 }
+            default:
+                d.logger.WithFields(opp.LogFields()).Warn("Opportunities channel full, dropping opportunity")
+                d.monitor.RecordDropped(opp.Asset)
             }
         }
     }
 }
 
-func (d *Detector) detectOpportunity(asset uint32) *Opportunity {
-    perpPrice := d.getPerpPrice(asset)
-    spotPrice := d.getSpotPrice(asset)
+// recordEmitted counts an opportunity scan has emitted. Duplicates and
+// dropped opportunities are not counted, nor are those found by Snapshot or
+// Evaluate.
+func (d *Detector) recordEmitted(opp *Opportunity) {
+    if opp.Kind == trade.KindCycle {
+        d.monitor.RecordCycleOpportunity(opp.Symbol, opp.SpreadBps)
+        return
+    }
+    d.monitor.RecordOpportunity(opp.Asset, opp.SpreadBps)
+}
+
+// detectAll checks every asset on the bounded worker pool and returns the
+// result for each, in asset order, followed by any profitable cycles; assets
+// without an opportunity are nil.
+func (d *Detector) detectAll(ctx context.Context) []*Opportunity {
+    found := make([]*Opportunity, len(d.Assets))
+    var workers errgroup.Group
+    workers.SetLimit(d.ScanWorkers)
+    for i, asset := range d.Assets {
+        i, asset := i, asset
+        workers.Go(func() error {
+            found[i] = d.detectOpportunity(ctx, asset)
+            return nil
+        })
+    }
+    workers.Wait()
+    return append(found, d.detectCycles(ctx)...)
+}
+
+// Snapshot scans every asset once and returns the opportunities found,
+// without deduplicating or emitting them.
+func (d *Detector) Snapshot(ctx context.Context) []*Opportunity {
+    var opportunities []*Opportunity
+    for _, opp := range d.detectAll(ctx) {
+        if opp != nil {
+            opportunities = append(opportunities, opp)
+        }
+    }
+    return opportunities
+}
+
+func (d *Detector) detectOpportunity(ctx context.Context, asset uint32) *Opportunity {
+    if d.Filter != nil && !d.Filter.Enabled(asset) {
+        return nil
+    }
+    
+    perpPrice := d.getPerpPrice(ctx, asset)
+    spotPrice := d.getSpotPrice(ctx, asset)
     
     if perpPrice == nil || spotPrice == nil {
         return nil
     }
     
+    now := d.now()
+    perpAge := d.perpFreshness.age(asset, now)
+    spotAge := d.spotFreshness.age(asset, now)
+    if perpAge > d.MaxPriceAge || spotAge > d.MaxPriceAge {
+        d.logger.WithFields(logrus.Fields{
+            "asset":    asset,
+            "perp_age": perpAge,
+            "spot_age": spotAge,
+        }).Debug("Stale oracle price")
+        d.monitor.RecordStalePrice(asset)
+        return nil
+    }
+    
     spread := new(big.Int).Sub(perpPrice, spotPrice)
     if spread.Sign() < 0 {
         spread.Neg(spread)
     }
     
-    minSpread := big.NewInt(10000000)
-    if spread.Cmp(minSpread) < 0 {
+    fundingRate := d.getFundingRate(ctx, asset)
+    if fundingRate == nil {
         return nil
     }
     
-    d.monitor.RecordOpportunity(asset, spread)
+    isBuy := perpPrice.Cmp(spotPrice) > 0
+    amount, fillPrice := d.size(ctx, asset, isBuy, spotPrice)
+    if amount == nil {
+        return nil
+    }
+    amount = d.sizing.capped(asset, d.jitterSize(amount), fillPrice)
+    if amount.Sign() <= 0 {
+        return nil
+    }
     
-    return &Opportunity{
-        Asset:     asset,
-        CorePrice: perpPrice,
-        EVMPrice:  spotPrice,
-        Spread:    spread,
-        IsBuy:     perpPrice.Cmp(spotPrice) > 0,
-        Amount:    big.NewInt(100000000),
-        Timestamp: time.Now(),
+    // The spread actually captured is against the spot fill price, not the
+    // top-of-book oracle price.
+    fillSpread := new(big.Int).Sub(perpPrice, fillPrice)
+    if !isBuy {
+        fillSpread.Neg(fillSpread)
+    }
+    
+    net := d.netSpread(asset, fillSpread, perpPrice, fundingRate, isBuy)
+    if net.Sign() <= 0 {
+        return nil
     }
+    
+    if SpreadBps(net, perpPrice, spotPrice) < d.minSpreadBpsFor(asset) {
+        return nil
+    }
+    
+    bps := SpreadBps(spread, perpPrice, spotPrice)
+    opp := &Opportunity{
+        ID:          uuid.NewString(),
+        Asset:       asset,
+        Symbol:      d.assets.Label(asset),
+        CorePrice:   perpPrice,
+        EVMPrice:    spotPrice,
+        Spread:      spread,
+        SpreadBps:   bps,
+        FundingRate: fundingRate,
+        NetSpread:   net,
+        FillPrice:   fillPrice,
+        IsBuy:       isBuy,
+        Amount:      amount,
+        Timestamp:   now,
+    }
+    
+    d.logger.WithFields(opp.LogFields()).WithFields(logrus.Fields{
+        "spread_bps": bps,
+        "perp_age":   perpAge,
+        "spot_age":   spotAge,
+    }).Debug("Opportunity candidate")
+    
+    return opp
 }
 
-func (d *Detector) getPerpPrice(asset uint32) *big.Int {
-    return big.NewInt(5000_00000000)
+// size returns the trade size for asset and the expected spot fill price.
+// The target is the asset's configured size or notional at the oracle price,
+// or MaxTradeSize. With a depth precompile configured the size is the largest
+// up to the target that keeps slippage within MaxSlippageBps; otherwise it
+// is the target at the oracle price. A nil size means the book is
+// unavailable or too thin for MinTradeSize.
+func (d *Detector) size(ctx context.Context, asset uint32, buySpot bool, spotPrice *big.Int) (*big.Int, *big.Int) {
+    target := d.sizing.target(asset, spotPrice, d.MaxTradeSize)
+    if d.depthAddr == nil {
+        return target, spotPrice
+    }
+    
+    levels := d.getOrderBook(ctx, asset, buySpot)
+    if levels == nil {
+        return nil, nil
+    }
+    
+    amount, fillPrice := sizeForSlippage(levels, buySpot, d.MaxSlippageBps, target)
+    if amount.Cmp(d.MinTradeSize) < 0 {
+        d.logger.WithFields(logrus.Fields{
+            "asset":     asset,
+            "available": amount,
+        }).Debug("Order book too thin for minimum trade size")
+        return nil, nil
+    }
+    return amount, fillPrice
 }
 
-func (d *Detector) getSpotPrice(asset uint32) *big.Int {
-    return big.NewInt(4999_00000000)
+// minSpreadBpsFor returns the current minimum spread for asset in basis
+// points, falling back to the global minimum when the asset has no override.
+// Assets other bots are contesting need the extra competition spread on top.
+func (d *Detector) minSpreadBpsFor(asset uint32) int64 {
+    minSpread := d.Thresholds.Load().MinSpreadBpsFor(asset)
+    if d.competition != nil && d.competition.contested(asset, d.now()) {
+        minSpread += d.competition.spreadBps
+    }
+    return minSpread
+}
+
+// SpreadBps expresses spread in basis points of the lower of the two prices,
+// rounded down since it gates trading. It returns 0 when either price is
+// zero.
+func SpreadBps(spread, perpPrice, spotPrice *big.Int) int64 {
+    base := perpPrice
+    if spotPrice.Cmp(base) < 0 {
+        base = spotPrice
+    }
+    if base.Sign() == 0 {
+        return 0
+    }
+    
+    return pricing.MulDiv(spread, big.NewInt(10000), base, pricing.RoundDown).Int64()
+}
+
+// getPerpPrice reads asset's perp price from Prices and records it for the
+// staleness check.
+func (d *Detector) getPerpPrice(ctx context.Context, asset uint32) *big.Int {
+    price, updated := d.Prices.PerpPrice(ctx, asset)
+    if price == nil {
+        return nil
+    }
+    d.perpFreshness.observe(asset, price, updated, d.now())
+    return price
+}
+
+// getSpotPrice reads asset's spot price from Prices and records it for the
+// staleness check.
+func (d *Detector) getSpotPrice(ctx context.Context, asset uint32) *big.Int {
+    price, updated := d.Prices.SpotPrice(ctx, asset)
+    if price == nil {
+        return nil
+    }
+    d.spotFreshness.observe(asset, price, updated, d.now())
+    return price
+}
+
+// encodeAsset ABI-encodes an asset index as a single uint32 argument.
+func encodeAsset(asset uint32) []byte {
+    return common.LeftPadBytes(new(big.Int).SetUint64(uint64(asset)).Bytes(), 32)
+}
+
+// decodePrice reads the first ABI word of a precompile result as an unsigned
+// price. Empty results and zero prices are reported as unavailable (nil).
+func decodePrice(result []byte) *big.Int {
+    if len(result) < 32 {
+        return nil
+    }
+    
+    price := new(big.Int).SetBytes(result[:32])
+    if price.Sign() == 0 {
+        return nil
+    }
+    
+    return price
+}
+
+// normalizePrice rescales a raw oracle price with the given number of decimals
+// to PriceDecimals.
+func normalizePrice(price *big.Int, decimals int) *big.Int {
+    return pricing.Rescale(price, decimals, PriceDecimals)
+}
+
+// optionalAddress converts a validated, possibly empty, hex address.
+func optionalAddress(raw string) *common.Address {
+    if raw == "" {
+        return nil
+    }
+    addr := common.HexToAddress(raw)
+    return &addr
 }
\ No newline at end of file
@@ -0,0 +1,106 @@
+package detector
+
+import (
+    "context"
+    "fmt"
+    "math/big"
+    "testing"
+    "time"
+
+    "github.com/hypercore-suite/arbitrage/config"
+)
+
+func BenchmarkDetectOpportunity(b *testing.B) {
+    d := newTestDetector(b, nil)
+    d.replay.Set(quote(0, 6512000000000, 6500000000000))
+    ctx := context.Background()
+    
+    b.ReportAllocs()
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        if d.detectOpportunity(ctx, 0) == nil {
+            b.Fatal("no opportunity detected")
+        }
+    }
+}
+
+func BenchmarkScan(b *testing.B) {
+    const assets = 32
+    d := newTestDetector(b, func(cfg *config.Config) {
+        // Emit every tick's opportunities rather than only the first's.
+        cfg.Detector.DedupWindow = 0
+        for asset := uint32(0); asset < assets; asset++ {
+            cfg.Detector.Assets = append(cfg.Detector.Assets, asset)
+        }
+    })
+    d.ScanWorkers = 4
+    for asset := uint32(0); asset < assets; asset++ {
+        // Every other asset trades at a spread worth emitting.
+        spot := int64(100000000) * int64(asset+1)
+        perp := spot
+        if asset%2 == 0 {
+            perp += spot / 100
+        }
+        d.replay.Set(quote(asset, perp, spot))
+    }
+    
+    ctx := context.Background()
+    opportunities := make(chan *Opportunity, assets)
+    
+    b.ReportAllocs()
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        d.scan(ctx, opportunities)
+        for len(opportunities) > 0 {
+            <-opportunities
+        }
+    }
+}
+
+// slowPrices is a PriceSource that takes delay to answer each read, like an
+// oracle behind a distant node.
+type slowPrices struct {
+    StaticPrices
+    delay time.Duration
+}
+
+func (s slowPrices) PerpPrice(ctx context.Context, asset uint32) (*big.Int, time.Time) {
+    time.Sleep(s.delay)
+    return s.StaticPrices.PerpPrice(ctx, asset)
+}
+
+func (s slowPrices) SpotPrice(ctx context.Context, asset uint32) (*big.Int, time.Time) {
+    time.Sleep(s.delay)
+    return s.StaticPrices.SpotPrice(ctx, asset)
+}
+
+func BenchmarkScanSlowOracle(b *testing.B) {
+    const assets = 8
+    for _, workers := range []int{1, assets} {
+        b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+            d := newTestDetector(b, func(cfg *config.Config) {
+                cfg.Detector.DedupWindow = 0
+                for asset := uint32(0); asset < assets; asset++ {
+                    cfg.Detector.Assets = append(cfg.Detector.Assets, asset)
+                }
+            })
+            d.ScanWorkers = workers
+            for asset := uint32(0); asset < assets; asset++ {
+                d.replay.Set(quote(asset, 10050000000, 10000000000))
+            }
+            d.Prices = slowPrices{StaticPrices: d.replay, delay: time.Millisecond}
+            
+            ctx := context.Background()
+            opportunities := make(chan *Opportunity, assets)
+            
+            b.ReportAllocs()
+            b.ResetTimer()
+            for i := 0; i < b.N; i++ {
+                d.scan(ctx, opportunities)
+                for len(opportunities) > 0 {
+                    <-opportunities
+                }
+            }
+        })
+    }
+}
\ No newline at end of file
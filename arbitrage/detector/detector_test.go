@@ -0,0 +1,164 @@
+package detector
+
+import (
+    "context"
+    "io"
+    "math/big"
+    "reflect"
+    "testing"
+    "time"
+
+    "github.com/hypercore-suite/arbitrage/config"
+    "github.com/hypercore-suite/arbitrage/toggle"
+    "github.com/sirupsen/logrus"
+)
+
+// testNow is the clock replay detectors built by newTestDetector start at.
+var testNow = time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+
+// nopMonitor discards every metric the detector records.
+type nopMonitor struct{}
+
+func (nopMonitor) RecordOpportunity(asset uint32, spreadBps int64)            {}
+func (nopMonitor) RecordStalePrice(asset uint32)                              {}
+func (nopMonitor) RecordDropped(asset uint32)                                 {}
+func (nopMonitor) RecordRPCHealth(endpoint string, healthy bool)              {}
+func (nopMonitor) RecordRPCLatency(method string, elapsed time.Duration)      {}
+func (nopMonitor) RecordTick(maxGap time.Duration)                            {}
+func (nopMonitor) RecordCompetition(asset uint32, contested bool)             {}
+func (nopMonitor) RecordOracleSanityFail(asset uint32, market, reason string) {}
+func (nopMonitor) RecordCycleOpportunity(cycle string, netBps int64)          {}
+
+// quietLogger returns a logger that writes nowhere.
+func quietLogger() *logrus.Logger {
+    logger := logrus.New()
+    logger.SetOutput(io.Discard)
+    return logger
+}
+
+// newTestDetector returns a replay detector over the default configuration,
+// as adjusted by configure, reading its prices from quotes set on d.replay.
+func newTestDetector(tb testing.TB, configure func(*config.Config)) *Detector {
+    tb.Helper()
+    
+    cfg := config.Default()
+    if configure != nil {
+        configure(cfg)
+    }
+    d := NewReplayDetector(quietLogger(), nopMonitor{}, cfg)
+    d.clock = testNow
+    return d
+}
+
+// quote is a fresh quote for asset at the given perp and spot prices, in
+// PriceDecimals.
+func quote(asset uint32, perp, spot int64) Quote {
+    return Quote{Time: testNow, Asset: asset, PerpPrice: big.NewInt(perp), SpotPrice: big.NewInt(spot)}
+}
+
+func TestAssetMinSpreadBps(t *testing.T) {
+    tests := []struct {
+        name       string
+        asset      uint32
+        perp, spot int64
+        want       bool
+    }{
+        {"global threshold passes", 0, 10050000000, 10000000000, true},
+        {"below asset threshold", 1, 10050000000, 10000000000, false},
+        {"above asset threshold", 1, 10150000000, 10000000000, true},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            d := newTestDetector(t, func(cfg *config.Config) {
+                cfg.Detector.MinSpreadBps = 10
+                cfg.Detector.AssetMinSpreadBps = map[uint32]int64{1: 100}
+            })
+            d.replay.Set(quote(tt.asset, tt.perp, tt.spot))
+            
+            if got := d.detectOpportunity(context.Background(), tt.asset) != nil; got != tt.want {
+                t.Fatalf("detected = %v, want %v", got, tt.want)
+            }
+        })
+    }
+}
+
+func TestDetectDirection(t *testing.T) {
+    tests := []struct {
+        name       string
+        perp, spot int64
+        wantBuy    bool
+    }{
+        {"perp above spot buys spot", 10050000000, 10000000000, true},
+        {"spot above perp sells spot", 10000000000, 10050000000, false},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            d := newTestDetector(t, nil)
+            d.replay.Set(quote(0, tt.perp, tt.spot))
+            
+            opp := d.detectOpportunity(context.Background(), 0)
+            if opp == nil {
+                t.Fatal("no opportunity detected")
+            }
+            if opp.IsBuy != tt.wantBuy {
+                t.Errorf("IsBuy = %v, want %v", opp.IsBuy, tt.wantBuy)
+            }
+            if opp.Spread.Int64() != 50000000 || opp.NetSpread.Int64() != 50000000 {
+                t.Errorf("spread = %v, net spread = %v, want both 50000000", opp.Spread, opp.NetSpread)
+            }
+            if opp.SpreadBps != 50 {
+                t.Errorf("spread = %d bps, want 50", opp.SpreadBps)
+            }
+        })
+    }
+}
+
+func TestDisabledAssetEmitsNothing(t *testing.T) {
+    tests := []struct {
+        name     string
+        disable  []uint32
+        reenable []uint32
+        want     []uint32
+    }{
+        {"every asset enabled", nil, nil, []uint32{0, 1}},
+        {"disabled asset skipped", []uint32{1}, nil, []uint32{0}},
+        {"every asset disabled", []uint32{0, 1}, nil, nil},
+        {"re-enabled asset emitted again", []uint32{1}, []uint32{1}, []uint32{0, 1}},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            assets, err := toggle.Open("", []uint32{0, 1})
+            if err != nil {
+                t.Fatalf("open toggles: %v", err)
+            }
+            for _, asset := range tt.disable {
+                assets.SetEnabled(asset, false)
+            }
+            for _, asset := range tt.reenable {
+                assets.SetEnabled(asset, true)
+            }
+            
+            d := newTestDetector(t, func(cfg *config.Config) {
+                cfg.Detector.Assets = []uint32{0, 1}
+            })
+            d.Filter = assets
+            d.replay.Set(quote(0, 10050000000, 10000000000))
+            d.replay.Set(quote(1, 10050000000, 10000000000))
+            
+            opportunities := make(chan *Opportunity, 2)
+            d.scan(context.Background(), opportunities)
+            close(opportunities)
+            
+            var got []uint32
+            for opp := range opportunities {
+                got = append(got, opp.Asset)
+            }
+            if !reflect.DeepEqual(got, tt.want) {
+                t.Fatalf("emitted assets %v, want %v", got, tt.want)
+            }
+        })
+    }
+}
\ No newline at end of file
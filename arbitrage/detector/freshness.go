@@ -0,0 +1,68 @@
+package detector
+
+import (
+    "math/big"
+    "sync"
+    "time"
+)
+
+// priceFreshness tracks when each asset's oracle price last updated. Oracles
+// that report an update timestamp in their second return word are trusted
+// directly; otherwise the price is considered updated whenever its value
+// changes, so a frozen feed ages even though the call keeps succeeding.
+type priceFreshness struct {
+    mu   sync.Mutex
+    last map[uint32]priceObservation
+}
+
+type priceObservation struct {
+    price   *big.Int
+    updated time.Time
+}
+
+func newPriceFreshness() *priceFreshness {
+    return &priceFreshness{last: make(map[uint32]priceObservation)}
+}
+
+// observe records price for asset. reported is the oracle's own update
+// time, or the zero time when the oracle does not provide one.
+func (f *priceFreshness) observe(asset uint32, price *big.Int, reported time.Time, now time.Time) {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    
+    obs, ok := f.last[asset]
+    switch {
+    case !reported.IsZero():
+        obs.updated = reported
+    case !ok || obs.price.Cmp(price) != 0:
+        obs.updated = now
+    }
+    obs.price = price
+    f.last[asset] = obs
+}
+
+// age returns how long ago asset's price last updated.
+func (f *priceFreshness) age(asset uint32, now time.Time) time.Duration {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    
+    obs, ok := f.last[asset]
+    if !ok {
+        return 0
+    }
+    return now.Sub(obs.updated)
+}
+
+// decodeTimestamp reads an optional unix-seconds update time from the second
+// ABI word of an oracle result.
+func decodeTimestamp(result []byte) time.Time {
+    if len(result) < 64 {
+        return time.Time{}
+    }
+    
+    seconds := new(big.Int).SetBytes(result[32:64])
+    if seconds.Sign() == 0 || !seconds.IsInt64() {
+        return time.Time{}
+    }
+    return time.Unix(seconds.Int64(), 0)
+}
@@ -0,0 +1,58 @@
+package detector
+
+import (
+    "context"
+    "math/big"
+    "time"
+
+    "github.com/ethereum/go-ethereum"
+    "github.com/hypercore-suite/arbitrage/trade"
+)
+
+// FundingRateDecimals is the fixed-point scale of hourly funding rates read
+// from the funding precompile.
+const FundingRateDecimals = trade.FundingRateDecimals
+
+// getFundingRate reads the signed hourly funding rate for asset. When no
+// funding precompile is configured the rate is treated as zero; nil means the
+// configured precompile could not be read by the time ctx is done.
+func (d *Detector) getFundingRate(ctx context.Context, asset uint32) *big.Int {
+    if d.fundingAddr == nil {
+        return new(big.Int)
+    }
+    
+    start := time.Now()
+    result, err := d.coreClient.CallContract(ctx, ethereum.CallMsg{
+        To:   d.fundingAddr,
+        Data: encodeAsset(asset),
+    }, nil)
+    d.monitor.RecordRPCLatency("getFundingRate", time.Since(start))
+    if err != nil {
+        d.logger.WithError(err).WithField("asset", asset).Debug("Funding precompile call failed")
+        return nil
+    }
+    if len(result) < 32 {
+        d.logger.WithField("asset", asset).Debug("Funding rate unavailable")
+        return nil
+    }
+    
+    return decodeSigned(result[:32])
+}
+
+// netSpread adjusts spread on asset by the funding the perp leg pays or
+// earns per unit over the holding period, as the fee model prices it.
+// shortPerp is true when the trade sells the perp (perp above spot), in which
+// case positive funding is earned and widens the spread.
+func (d *Detector) netSpread(asset uint32, spread, perpPrice, fundingRate *big.Int, shortPerp bool) *big.Int {
+    funding := d.Fees.Funding(asset, perpPrice, fundingRate, d.HoldingPeriod, shortPerp)
+    return new(big.Int).Sub(spread, funding)
+}
+
+// decodeSigned interprets a 32-byte ABI word as a two's complement int256.
+func decodeSigned(word []byte) *big.Int {
+    value := new(big.Int).SetBytes(word)
+    if len(word) == 32 && word[0]&0x80 != 0 {
+        value.Sub(value, new(big.Int).Lsh(big.NewInt(1), 256))
+    }
+    return value
+}
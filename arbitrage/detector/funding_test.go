@@ -0,0 +1,39 @@
+package detector
+
+import (
+    "math/big"
+    "testing"
+    "time"
+)
+
+func TestNetSpread(t *testing.T) {
+    perp := big.NewInt(10000000000) // $100
+    spread := big.NewInt(50000000)  // $0.50
+    tests := []struct {
+        name      string
+        rate      int64
+        holding   time.Duration
+        shortPerp bool
+        want      int64
+    }{
+        {"no funding", 0, 8 * time.Hour, false, 50000000},
+        {"long perp pays positive funding", 10000, 8 * time.Hour, false, 42000000},
+        {"short perp earns positive funding", 10000, 8 * time.Hour, true, 58000000},
+        {"long perp earns negative funding", -10000, 8 * time.Hour, false, 58000000},
+        {"short perp pays negative funding", -10000, 8 * time.Hour, true, 42000000},
+        {"no holding period", 10000, 0, false, 50000000},
+        {"funding above the spread", 100000, 8 * time.Hour, false, -30000000},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            d := newTestDetector(t, nil)
+            d.HoldingPeriod = tt.holding
+            
+            got := d.netSpread(0, spread, perp, big.NewInt(tt.rate), tt.shortPerp)
+            if got.Int64() != tt.want {
+                t.Fatalf("netSpread = %v, want %d", got, tt.want)
+            }
+        })
+    }
+}
\ No newline at end of file
@@ -0,0 +1,53 @@
+package detector
+
+import (
+    "crypto/rand"
+    "math/big"
+    "time"
+)
+
+// Jitter makes the bot's timing and sizing harder to predict from the
+// mempool. It is off by default: every tick of poll jitter is added latency
+// before an opportunity is seen, and size jitter only ever trades smaller
+// than the sizing logic allows.
+
+// nextPoll returns the delay before the next scan: interval plus a
+// uniformly random share of PollJitter.
+func (d *Detector) nextPoll(interval time.Duration) time.Duration {
+    if d.PollJitter <= 0 {
+        return interval
+    }
+    return interval + time.Duration(randomBelow(int64(d.PollJitter)))
+}
+
+// jitterSize shrinks amount by a random fraction of up to SizeJitterBps,
+// never below MinTradeSize. Amounts already at or below it, such as small
+// notional targets, are left as they are.
+func (d *Detector) jitterSize(amount *big.Int) *big.Int {
+    if d.SizeJitterBps <= 0 || amount.Cmp(d.MinTradeSize) <= 0 {
+        return amount
+    }
+    
+    cut := new(big.Int).Mul(amount, big.NewInt(randomBelow(d.SizeJitterBps+1)))
+    cut.Quo(cut, big.NewInt(10000))
+    
+    jittered := new(big.Int).Sub(amount, cut)
+    if jittered.Cmp(d.MinTradeSize) < 0 {
+        return new(big.Int).Set(d.MinTradeSize)
+    }
+    return jittered
+}
+
+// randomBelow returns a uniform value in [0, n) from crypto/rand. It falls
+// back to zero, i.e. no jitter, if the system source fails.
+func randomBelow(n int64) int64 {
+    if n <= 0 {
+        return 0
+    }
+    
+    v, err := rand.Int(rand.Reader, big.NewInt(n))
+    if err != nil {
+        return 0
+    }
+    return v.Int64()
+}
@@ -0,0 +1,199 @@
+package detector
+
+import (
+    "bytes"
+    "context"
+    "encoding/binary"
+    "fmt"
+    "sync"
+    "time"
+
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/ethereum/go-ethereum/core/types"
+    "github.com/ethereum/go-ethereum/crypto"
+    "github.com/ethereum/go-ethereum/ethclient/gethclient"
+    "github.com/ethereum/go-ethereum/rpc"
+    "github.com/hypercore-suite/arbitrage/config"
+    "github.com/sirupsen/logrus"
+)
+
+// Selectors of the arbitrage calls competing bots send through the same
+// contracts we route through; see the executor's path and legacy ABIs.
+var (
+    pathArbitrageSelector   = crypto.Keccak256([]byte("executeArbitrage((uint32,uint64,uint64,bool,address[]))"))[:4]
+    legacyArbitrageSelector = crypto.Keccak256([]byte("executeArbitrage((uint32,uint64,uint64,bool))"))[:4]
+)
+
+// competitionTracker remembers which assets other bots have recently sent
+// arbitrage transactions for. An asset is contested for window after the
+// last competing transaction seen, and needs spreadBps more spread meanwhile.
+type competitionTracker struct {
+    window    time.Duration
+    spreadBps int64
+    watched   map[common.Address]bool
+    
+    mu       sync.Mutex
+    lastSeen map[uint32]time.Time
+}
+
+// newCompetitionTracker watches every configured route contract and
+// competitor address.
+func newCompetitionTracker(cfg *config.Config) *competitionTracker {
+    watched := make(map[common.Address]bool)
+    for _, route := range cfg.Executor.RouteList() {
+        if common.IsHexAddress(route.Contract) {
+            watched[common.HexToAddress(route.Contract)] = true
+        }
+    }
+    for _, address := range cfg.Detector.CompetitorAddresses {
+        watched[common.HexToAddress(address)] = true
+    }
+    
+    return &competitionTracker{
+        window:    cfg.Detector.CompetitionWindow,
+        spreadBps: cfg.Detector.CompetitionSpreadBps,
+        watched:   watched,
+        lastSeen:  make(map[uint32]time.Time),
+    }
+}
+
+// observe records a competing transaction for asset and reports whether the
+// asset was not already contested.
+func (c *competitionTracker) observe(asset uint32, now time.Time) bool {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    
+    last, ok := c.lastSeen[asset]
+    c.lastSeen[asset] = now
+    return !ok || now.Sub(last) >= c.window
+}
+
+// contested reports whether a competing transaction for asset was seen
+// within the window.
+func (c *competitionTracker) contested(asset uint32, now time.Time) bool {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    
+    last, ok := c.lastSeen[asset]
+    return ok && now.Sub(last) < c.window
+}
+
+// expire forgets assets whose last competing transaction has aged out of the
+// window and returns them.
+func (c *competitionTracker) expire(now time.Time) []uint32 {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    
+    var expired []uint32
+    for asset, last := range c.lastSeen {
+        if now.Sub(last) >= c.window {
+            delete(c.lastSeen, asset)
+            expired = append(expired, asset)
+        }
+    }
+    return expired
+}
+
+// watchMempool follows pending transactions on the WebSocket endpoint and
+// marks assets other bots are trading as contested. Nodes without full
+// pending transaction subscriptions leave competition detection off.
+func (d *Detector) watchMempool(ctx context.Context) {
+    if err := d.followPending(ctx); err != nil {
+        d.logger.WithError(err).Info("Mempool monitoring unavailable; competition detection disabled")
+    }
+}
+
+func (d *Detector) followPending(ctx context.Context) error {
+    client, err := rpc.DialContext(ctx, d.WSURL)
+    if err != nil {
+        return fmt.Errorf("dial %s: %w", d.WSURL, err)
+    }
+    defer client.Close()
+    
+    pending := make(chan *types.Transaction, 256)
+    sub, err := gethclient.New(client).SubscribeFullPendingTransactions(ctx, pending)
+    if err != nil {
+        return fmt.Errorf("subscribe to pending transactions: %w", err)
+    }
+    defer sub.Unsubscribe()
+    
+    own := make(map[common.Address]bool, len(d.OwnAddresses))
+    for _, address := range d.OwnAddresses {
+        own[address] = true
+    }
+    
+    d.logger.WithField("url", d.WSURL).WithField("watched", len(d.competition.watched)).Info("Detector watching the mempool for competing arbitrage")
+    
+    for {
+        select {
+        case <-ctx.Done():
+            return nil
+        case err := <-sub.Err():
+            return fmt.Errorf("pending transaction subscription dropped: %w", err)
+        case tx := <-pending:
+            d.inspectPending(tx, own)
+        }
+    }
+}
+
+// inspectPending records tx as competition when it is an arbitrage call to a
+// watched contract sent by someone other than our own wallets.
+func (d *Detector) inspectPending(tx *types.Transaction, own map[common.Address]bool) {
+    if tx.To() == nil || !d.competition.watched[*tx.To()] {
+        return
+    }
+    asset, ok := competingAsset(tx.Data())
+    if !ok {
+        return
+    }
+    sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+    if err != nil || own[sender] {
+        return
+    }
+    
+    if d.competition.observe(asset, d.now()) {
+        d.logger.WithFields(logrus.Fields{
+            "asset":    asset,
+            "sender":   sender.Hex(),
+            "contract": tx.To().Hex(),
+            "tx_hash":  tx.Hash().Hex(),
+        }).Info("Competing arbitrage detected")
+        d.monitor.RecordCompetition(asset, true)
+    }
+}
+
+// competingAsset decodes the asset id from executeArbitrage calldata in
+// either ABI. The asset is the first field of the params tuple; with the
+// dynamic path the tuple is encoded behind a one-word offset.
+func competingAsset(data []byte) (uint32, bool) {
+    if len(data) < 4 {
+        return 0, false
+    }
+    
+    var word []byte
+    switch selector := data[:4]; {
+    case bytes.Equal(selector, pathArbitrageSelector) && len(data) >= 68:
+        word = data[36:68]
+    case bytes.Equal(selector, legacyArbitrageSelector) && len(data) >= 36:
+        word = data[4:36]
+    default:
+        return 0, false
+    }
+    for _, b := range word[:28] {
+        if b != 0 {
+            return 0, false
+        }
+    }
+    return binary.BigEndian.Uint32(word[28:]), true
+}
+
+// expireCompetition reports assets whose competition has aged out.
+func (d *Detector) expireCompetition() {
+    if d.competition == nil {
+        return
+    }
+    for _, asset := range d.competition.expire(d.now()) {
+        d.logger.WithField("asset", asset).Info("Competition cleared")
+        d.monitor.RecordCompetition(asset, false)
+    }
+}
\ No newline at end of file
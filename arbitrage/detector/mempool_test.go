@@ -0,0 +1,152 @@
+package detector
+
+import (
+    "context"
+    "math/big"
+    "net/http/httptest"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/ethereum/go-ethereum/core/types"
+    "github.com/ethereum/go-ethereum/crypto"
+    "github.com/ethereum/go-ethereum/rpc"
+    "github.com/hypercore-suite/arbitrage/config"
+)
+
+// pendingFeed is the eth namespace of a node streaming the full pending
+// transactions sent on txs to every subscriber.
+type pendingFeed struct {
+    txs chan *types.Transaction
+}
+
+func (f *pendingFeed) NewPendingTransactions(ctx context.Context, fullTx *bool) (*rpc.Subscription, error) {
+    notifier, ok := rpc.NotifierFromContext(ctx)
+    if !ok {
+        return nil, rpc.ErrNotificationsUnsupported
+    }
+    sub := notifier.CreateSubscription()
+    go func() {
+        for {
+            select {
+            case tx := <-f.txs:
+                notifier.Notify(sub.ID, tx)
+            case <-sub.Err():
+                return
+            }
+        }
+    }()
+    return sub, nil
+}
+
+// competitionEvent is one RecordCompetition call.
+type competitionEvent struct {
+    asset     uint32
+    contested bool
+}
+
+// competitionMonitor passes on the competition the detector reports.
+type competitionMonitor struct {
+    nopMonitor
+    events chan competitionEvent
+}
+
+func (m competitionMonitor) RecordCompetition(asset uint32, contested bool) {
+    m.events <- competitionEvent{asset, contested}
+}
+
+// arbitrageTx is a signed executeArbitrage call on the path ABI for asset,
+// sent to contract by key.
+func arbitrageTx(t *testing.T, key string, contract common.Address, asset uint32) *types.Transaction {
+    t.Helper()
+    
+    signer, err := crypto.HexToECDSA(key)
+    if err != nil {
+        t.Fatalf("key: %v", err)
+    }
+    data := append(append([]byte{}, pathArbitrageSelector...), word(32)...)
+    data = append(data, word(int64(asset))...)
+    tx, err := types.SignNewTx(signer, types.LatestSignerForChainID(big.NewInt(999)), &types.DynamicFeeTx{
+        ChainID:   big.NewInt(999),
+        GasTipCap: big.NewInt(1000000000),
+        GasFeeCap: big.NewInt(3000000000),
+        Gas:       500000,
+        To:        &contract,
+        Data:      data,
+    })
+    if err != nil {
+        t.Fatalf("sign: %v", err)
+    }
+    return tx
+}
+
+func TestMempoolFeedFlipsCompetition(t *testing.T) {
+    const (
+        ownKey        = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+        competitorKey = "8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a"
+    )
+    contract := common.HexToAddress("0x00000000000000000000000000000000000a4b17")
+    
+    feed := &pendingFeed{txs: make(chan *types.Transaction)}
+    server := rpc.NewServer()
+    if err := server.RegisterName("eth", feed); err != nil {
+        t.Fatalf("register: %v", err)
+    }
+    ws := httptest.NewServer(server.WebsocketHandler([]string{"*"}))
+    t.Cleanup(ws.Close)
+    t.Cleanup(server.Stop)
+    
+    cfg := config.Default()
+    cfg.Executor.ArbContract = contract.Hex()
+    cfg.Detector.CompetitionWindow = time.Minute
+    monitor := competitionMonitor{events: make(chan competitionEvent, 4)}
+    d := newTestDetector(t, nil)
+    d.monitor = monitor
+    d.competition = newCompetitionTracker(cfg)
+    d.WSURL = "ws" + strings.TrimPrefix(ws.URL, "http")
+    own, err := crypto.HexToECDSA(ownKey)
+    if err != nil {
+        t.Fatalf("key: %v", err)
+    }
+    d.OwnAddresses = []common.Address{crypto.PubkeyToAddress(own.PublicKey)}
+    
+    ctx, cancel := context.WithCancel(context.Background())
+    done := make(chan struct{})
+    go func() {
+        d.watchMempool(ctx)
+        close(done)
+    }()
+    t.Cleanup(func() {
+        cancel()
+        <-done
+    })
+    
+    // Our own trade is not competition; the next bot's is. The feed only
+    // takes a transaction once the subscription is live.
+    feed.txs <- arbitrageTx(t, ownKey, contract, 1)
+    feed.txs <- arbitrageTx(t, competitorKey, contract, 3)
+    
+    select {
+    case ev := <-monitor.events:
+        if ev != (competitionEvent{3, true}) {
+            t.Fatalf("competition = %+v, want asset 3 contested", ev)
+        }
+    case <-time.After(5 * time.Second):
+        t.Fatal("competing transaction not reported")
+    }
+    if !d.competition.contested(3, d.now()) || d.competition.contested(1, d.now()) {
+        t.Fatal("contested assets do not match the pending transactions")
+    }
+    
+    d.clock = testNow.Add(time.Minute)
+    d.expireCompetition()
+    select {
+    case ev := <-monitor.events:
+        if ev != (competitionEvent{3, false}) {
+            t.Fatalf("competition = %+v, want asset 3 cleared", ev)
+        }
+    default:
+        t.Fatal("competition not cleared after the window")
+    }
+}
@@ -0,0 +1,76 @@
+package detector
+
+import (
+    "math/big"
+
+    "github.com/hypercore-suite/arbitrage/config"
+    "github.com/hypercore-suite/arbitrage/pricing"
+)
+
+// notionalSizing picks each asset's trade size from configuration, so
+// assets of very different prices can trade similar dollar amounts. Sizes
+// are in PriceDecimals units of the asset and notionals in 1e8 USD.
+type notionalSizing struct {
+    notional  *big.Int
+    sizes     map[uint32]*big.Int
+    notionals map[uint32]*big.Int
+    caps      map[uint32]*big.Int
+}
+
+func newNotionalSizing(detCfg config.DetectorConfig) *notionalSizing {
+    s := &notionalSizing{
+        sizes:     bigValues(detCfg.AssetTradeSize),
+        notionals: bigValues(detCfg.AssetTargetNotional),
+        caps:      bigValues(detCfg.AssetMaxNotional),
+    }
+    if detCfg.TargetNotional > 0 {
+        s.notional = big.NewInt(detCfg.TargetNotional)
+    }
+    return s
+}
+
+func bigValues(values map[uint32]int64) map[uint32]*big.Int {
+    out := make(map[uint32]*big.Int, len(values))
+    for asset, value := range values {
+        out[asset] = big.NewInt(value)
+    }
+    return out
+}
+
+// target returns the size to aim for on asset at price: its fixed size when
+// one is configured, else its target notional, else the global target
+// notional, converted at price; without any of those it is fallback.
+func (s *notionalSizing) target(asset uint32, price, fallback *big.Int) *big.Int {
+    if size, ok := s.sizes[asset]; ok {
+        return new(big.Int).Set(size)
+    }
+    
+    notional, ok := s.notionals[asset]
+    if !ok {
+        notional = s.notional
+    }
+    if notional == nil || price == nil || price.Sign() <= 0 {
+        return new(big.Int).Set(fallback)
+    }
+    return sizeForNotional(notional, price)
+}
+
+// capped limits amount so its notional at price stays within asset's max
+// notional, when it has one.
+func (s *notionalSizing) capped(asset uint32, amount, price *big.Int) *big.Int {
+    limit, ok := s.caps[asset]
+    if !ok || price == nil || price.Sign() <= 0 {
+        return amount
+    }
+    if maxSize := sizeForNotional(limit, price); amount.Cmp(maxSize) > 0 {
+        return maxSize
+    }
+    return amount
+}
+
+// sizeForNotional converts a 1e8 USD notional to a size at price, rounding
+// down.
+func sizeForNotional(notional, price *big.Int) *big.Int {
+    size := new(big.Int).Mul(notional, pricing.Pow10(PriceDecimals))
+    return size.Quo(size, price)
+}
\ No newline at end of file
@@ -0,0 +1,66 @@
+package detector
+
+import (
+    "math/big"
+    "testing"
+
+    "github.com/hypercore-suite/arbitrage/config"
+)
+
+func TestNotionalSizing(t *testing.T) {
+    // BTC (0) trades $1000 under the global target capped at $200, ETH (1)
+    // targets $500 and ATOM (2) a fixed 5 units.
+    sizing := newNotionalSizing(config.DetectorConfig{
+        TargetNotional:      100000000000,
+        AssetTradeSize:      map[uint32]int64{2: 500000000},
+        AssetTargetNotional: map[uint32]int64{1: 50000000000},
+        AssetMaxNotional:    map[uint32]int64{0: 20000000000},
+    })
+    unsized := newNotionalSizing(config.DetectorConfig{})
+    fallback := big.NewInt(100000000)
+    
+    targets := []struct {
+        name   string
+        sizing *notionalSizing
+        asset  uint32
+        price  *big.Int
+        want   int64
+    }{
+        {"fixed size ignores the price", sizing, 2, big.NewInt(10000000000), 500000000},
+        {"asset notional", sizing, 1, big.NewInt(25000000000), 200000000},
+        {"global notional", sizing, 0, big.NewInt(10000000000), 1000000000},
+        {"rounds down", sizing, 0, big.NewInt(30000000000), 333333333},
+        {"no price", sizing, 0, nil, 100000000},
+        {"zero price", sizing, 1, new(big.Int), 100000000},
+        {"no target", unsized, 0, big.NewInt(10000000000), 100000000},
+    }
+    for _, tt := range targets {
+        t.Run("target/"+tt.name, func(t *testing.T) {
+            if got := tt.sizing.target(tt.asset, tt.price, fallback); got.Int64() != tt.want {
+                t.Fatalf("target = %v, want %d", got, tt.want)
+            }
+        })
+    }
+    
+    caps := []struct {
+        name   string
+        asset  uint32
+        amount int64
+        price  *big.Int
+        want   int64
+    }{
+        {"above the cap", 0, 1000000000, big.NewInt(10000000000), 200000000},
+        {"at the cap", 0, 200000000, big.NewInt(10000000000), 200000000},
+        {"below the cap", 0, 100000000, big.NewInt(10000000000), 100000000},
+        {"cap rounds down", 0, 1000000000, big.NewInt(30000000000), 66666666},
+        {"no cap", 1, 1000000000, big.NewInt(10000000000), 1000000000},
+        {"no price", 0, 1000000000, nil, 1000000000},
+    }
+    for _, tt := range caps {
+        t.Run("capped/"+tt.name, func(t *testing.T) {
+            if got := sizing.capped(tt.asset, big.NewInt(tt.amount), tt.price); got.Int64() != tt.want {
+                t.Fatalf("capped = %v, want %d", got, tt.want)
+            }
+        })
+    }
+}
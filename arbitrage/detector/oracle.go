@@ -0,0 +1,149 @@
+package detector
+
+import (
+    "math/big"
+    "sync"
+
+    "github.com/hypercore-suite/arbitrage/config"
+    "github.com/hypercore-suite/arbitrage/registry"
+)
+
+// Reasons a decoded oracle price is refused, as reported to
+// Monitor.RecordOracleSanityFail.
+const (
+    sanityLayout   = "layout"
+    sanityBelowMin = "below_min"
+    sanityAboveMax = "above_max"
+    sanityJump     = "jump"
+)
+
+// oracleLayout is one version of the price precompiles' return layout. A
+// result of any other length than words ABI words is taken as a layout the
+// bot does not understand, not decoded into a price.
+type oracleLayout struct {
+    perpDecimals int
+    spotDecimals int
+    sized        bool
+    words        []int
+}
+
+// oracleLayouts are the layouts DetectorConfig.OracleLayout selects between.
+// v1 is a price at fixed decimals with an optional update time; v2 is a bare
+// price whose decimals shrink by the asset's size decimals.
+var oracleLayouts = map[string]oracleLayout{
+    "v1": {perpDecimals: perpOracleDecimals, spotDecimals: spotOracleDecimals, words: []int{1, 2}},
+    "v2": {perpDecimals: perpOracleDecimals, spotDecimals: spotOracleDecimals, sized: true, words: []int{1}},
+}
+
+// fits reports whether result has one of the layout's lengths.
+func (l oracleLayout) fits(result []byte) bool {
+    for _, words := range l.words {
+        if len(result) == words*32 {
+            return true
+        }
+    }
+    return false
+}
+
+// decimals returns the raw price decimals of asset from the layout's base
+// decimals for its market. Assets missing from the registry keep the base.
+func (l oracleLayout) decimals(assets *registry.Registry, asset uint32, base int) int {
+    if !l.sized {
+        return base
+    }
+    a, err := assets.Lookup(asset)
+    switch {
+    case err != nil:
+        return base
+    case a.Decimals >= base:
+        return 0
+    }
+    return base - a.Decimals
+}
+
+// oracleSanity refuses decoded prices no real market would quote, which is
+// what a precompile upgrade changing the layout under a fixed decode turns
+// into. Each price must sit within its asset's bounds, else the global ones,
+// and move less than maxJumpBps from the previous read of the same market,
+// so a garbage read is refused while a sustained move passes from its second
+// read on. Scan workers share it.
+type oracleSanity struct {
+    global     priceBounds
+    assets     map[uint32]priceBounds
+    maxJumpBps int64
+    
+    mu   sync.Mutex
+    last map[oracleKey]*big.Int
+}
+
+// priceBounds is a price range at PriceDecimals; a nil side is unbounded.
+type priceBounds struct {
+    min *big.Int
+    max *big.Int
+}
+
+func newPriceBounds(min, max int64) priceBounds {
+    var b priceBounds
+    if min > 0 {
+        b.min = big.NewInt(min)
+    }
+    if max > 0 {
+        b.max = big.NewInt(max)
+    }
+    return b
+}
+
+type oracleKey struct {
+    asset  uint32
+    market string
+}
+
+func newOracleSanity(detCfg config.DetectorConfig) *oracleSanity {
+    s := &oracleSanity{
+        global:     newPriceBounds(detCfg.OracleMinPrice, detCfg.OracleMaxPrice),
+        assets:     make(map[uint32]priceBounds, len(detCfg.OracleAssetBounds)),
+        maxJumpBps: detCfg.OracleMaxJumpBps,
+        last:       make(map[oracleKey]*big.Int),
+    }
+    for asset, bounds := range detCfg.OracleAssetBounds {
+        s.assets[asset] = newPriceBounds(bounds.Min, bounds.Max)
+    }
+    return s
+}
+
+// bounds returns the range asset's prices must fall within.
+func (s *oracleSanity) bounds(asset uint32) priceBounds {
+    if b, ok := s.assets[asset]; ok {
+        return b
+    }
+    return s.global
+}
+
+// check returns why price, at PriceDecimals, is refused for asset on market,
+// or "" when it may be used.
+func (s *oracleSanity) check(asset uint32, market string, price *big.Int) string {
+    bounds := s.bounds(asset)
+    if bounds.min != nil && price.Cmp(bounds.min) < 0 {
+        return sanityBelowMin
+    }
+    if bounds.max != nil && price.Cmp(bounds.max) > 0 {
+        return sanityAboveMax
+    }
+    
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    
+    key := oracleKey{asset: asset, market: market}
+    last := s.last[key]
+    s.last[key] = price
+    if last == nil || s.maxJumpBps <= 0 {
+        return ""
+    }
+    
+    move := new(big.Int).Sub(price, last)
+    move.Abs(move).Mul(move, big.NewInt(10000))
+    if move.Cmp(new(big.Int).Mul(last, big.NewInt(s.maxJumpBps))) > 0 {
+        return sanityJump
+    }
+    return ""
+}
\ No newline at end of file
@@ -0,0 +1,50 @@
+package detector
+
+import (
+    "math/big"
+    "testing"
+
+    "github.com/hypercore-suite/arbitrage/config"
+)
+
+func TestOracleSanityCheck(t *testing.T) {
+    // Prices between $1 and $1M pass, except BTC (0), which must be at
+    // least $10k with no upper bound, and no market may move over 10%.
+    s := newOracleSanity(config.DetectorConfig{
+        OracleMinPrice:    100000000,
+        OracleMaxPrice:    100000000000000,
+        OracleAssetBounds: map[uint32]config.PriceBounds{0: {Min: 1000000000000}},
+        OracleMaxJumpBps:  1000,
+    })
+    
+    steps := []struct {
+        name   string
+        asset  uint32
+        market string
+        price  int64
+        want   string
+    }{
+        {"first read", 1, "perp", 200000000000, ""},
+        {"below the global minimum", 1, "perp", 50000000, sanityBelowMin},
+        {"above the global maximum", 1, "perp", 200000000000000, sanityAboveMax},
+        {"small move", 1, "perp", 210000000000, ""},
+        {"jump", 1, "perp", 240000000000, sanityJump},
+        {"sustained move passes on its second read", 1, "perp", 245000000000, ""},
+        {"exactly the maximum jump", 1, "perp", 269500000000, ""},
+        {"other market tracked apart", 1, "spot", 100000000000, ""},
+        {"below the asset minimum", 0, "perp", 500000000000, sanityBelowMin},
+        {"asset without a maximum", 0, "perp", 500000000000000, ""},
+    }
+    for _, step := range steps {
+        if got := s.check(step.asset, step.market, big.NewInt(step.price)); got != step.want {
+            t.Fatalf("%s: check = %q, want %q", step.name, got, step.want)
+        }
+    }
+    
+    unbounded := newOracleSanity(config.DetectorConfig{})
+    for _, price := range []int64{1, 100000000000000000, 1} {
+        if got := unbounded.check(0, "perp", big.NewInt(price)); got != "" {
+            t.Fatalf("check without bounds or a jump limit = %q for %d", got, price)
+        }
+    }
+}
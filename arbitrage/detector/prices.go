@@ -0,0 +1,133 @@
+package detector
+
+import (
+    "context"
+    "math/big"
+    "strings"
+    "time"
+
+    "github.com/ethereum/go-ethereum"
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/hypercore-suite/arbitrage/config"
+    "github.com/hypercore-suite/arbitrage/registry"
+    "github.com/sirupsen/logrus"
+)
+
+// PriceSource supplies the perp and spot prices the detector compares,
+// already at PriceDecimals. updated is when the source reports the price last
+// changed, or the zero time when it does not know; the detector then treats
+// a change of value as the update. A nil price means unavailable, including
+// when ctx is done before the price could be read.
+type PriceSource interface {
+    PerpPrice(ctx context.Context, asset uint32) (price *big.Int, updated time.Time)
+    SpotPrice(ctx context.Context, asset uint32) (price *big.Int, updated time.Time)
+}
+
+// precompilePrices reads prices from the HyperCore oracle precompiles,
+// decoded with the configured layout. Prices failing the sanity checks are
+// reported as unavailable.
+type precompilePrices struct {
+    logger   *logrus.Logger
+    client   *rpcClient
+    monitor  Monitor
+    assets   *registry.Registry
+    layout   oracleLayout
+    sanity   *oracleSanity
+    perpAddr common.Address
+    spotAddr common.Address
+}
+
+func newPrecompilePrices(logger *logrus.Logger, client *rpcClient, monitor Monitor, cfg *config.Config) *precompilePrices {
+    return &precompilePrices{
+        logger:   logger,
+        client:   client,
+        monitor:  monitor,
+        assets:   cfg.Registry(),
+        layout:   oracleLayouts[cfg.Detector.OracleLayout],
+        sanity:   newOracleSanity(cfg.Detector),
+        perpAddr: common.HexToAddress("0x0000000000000000000000000000000000000807"),
+        spotAddr: common.HexToAddress("0x0000000000000000000000000000000000000808"),
+    }
+}
+
+func (p *precompilePrices) PerpPrice(ctx context.Context, asset uint32) (*big.Int, time.Time) {
+    return p.read(ctx, asset, p.perpAddr, p.layout.decimals(p.assets, asset, p.layout.perpDecimals), "getPerpPrice", "Perp")
+}
+
+func (p *precompilePrices) SpotPrice(ctx context.Context, asset uint32) (*big.Int, time.Time) {
+    return p.read(ctx, asset, p.spotAddr, p.layout.decimals(p.assets, asset, p.layout.spotDecimals), "getSpotPrice", "Spot")
+}
+
+// read calls the oracle precompile at addr for asset and normalizes the
+// price from decimals. Each attempt is bounded by the RPC call timeout
+// within ctx. method labels the RPC latency metric; market prefixes log
+// messages.
+func (p *precompilePrices) read(ctx context.Context, asset uint32, addr common.Address, decimals int, method, market string) (*big.Int, time.Time) {
+    start := time.Now()
+    result, err := p.client.CallContract(ctx, ethereum.CallMsg{
+        To:   &addr,
+        Data: encodeAsset(asset),
+    }, nil)
+    p.monitor.RecordRPCLatency(method, time.Since(start))
+    if err != nil {
+        p.logger.WithError(err).WithField("asset", asset).Debug(market + " oracle call failed")
+        return nil, time.Time{}
+    }
+    
+    price := decodePrice(result)
+    if price == nil {
+        p.logger.WithField("asset", asset).Debug(market + " oracle price unavailable")
+        return nil, time.Time{}
+    }
+    if !p.layout.fits(result) {
+        p.refuse(asset, market, sanityLayout, len(result))
+        return nil, time.Time{}
+    }
+    
+    price = normalizePrice(price, decimals)
+    if reason := p.sanity.check(asset, strings.ToLower(market), price); reason != "" {
+        p.refuse(asset, market, reason, price)
+        return nil, time.Time{}
+    }
+    return price, decodeTimestamp(result)
+}
+
+// refuse logs and counts an oracle read that failed a sanity check; value is
+// what failed it.
+func (p *precompilePrices) refuse(asset uint32, market, reason string, value interface{}) {
+    p.monitor.RecordOracleSanityFail(asset, strings.ToLower(market), reason)
+    p.logger.WithFields(logrus.Fields{
+        "asset":  asset,
+        "reason": reason,
+        "value":  value,
+    }).Debug(market + " oracle price failed sanity check, refusing it")
+}
+
+// StaticPrices is an in-memory PriceSource holding the latest Quote per
+// asset, each reported as updated at its Time. Replay detectors read from
+// one, and it makes detection deterministic in tests. Set must not be called
+// while a scan is reading it.
+type StaticPrices map[uint32]Quote
+
+// Set makes q the current quote for q.Asset.
+func (s StaticPrices) Set(q Quote) {
+    s[q.Asset] = q
+}
+
+func (s StaticPrices) PerpPrice(_ context.Context, asset uint32) (*big.Int, time.Time) {
+    q := s[asset]
+    return positive(q.PerpPrice), q.Time
+}
+
+func (s StaticPrices) SpotPrice(_ context.Context, asset uint32) (*big.Int, time.Time) {
+    q := s[asset]
+    return positive(q.SpotPrice), q.Time
+}
+
+// positive returns price, or nil when it is missing or not positive.
+func positive(price *big.Int) *big.Int {
+    if price == nil || price.Sign() <= 0 {
+        return nil
+    }
+    return price
+}
\ No newline at end of file
@@ -0,0 +1,127 @@
+package detector
+
+import (
+    "bytes"
+    "context"
+    "encoding/json"
+    "errors"
+    "math/big"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/ethereum/go-ethereum/common/hexutil"
+    "github.com/hypercore-suite/arbitrage/config"
+)
+
+// callArgs is the transaction object of an eth_call request.
+type callArgs struct {
+    To    common.Address `json:"to"`
+    Input hexutil.Bytes  `json:"input"`
+    Data  hexutil.Bytes  `json:"data"`
+}
+
+// calldata returns the call's input, whichever field the client sent it in.
+func (a callArgs) calldata() []byte {
+    if len(a.Input) > 0 {
+        return a.Input
+    }
+    return a.Data
+}
+
+// stubNode serves JSON-RPC requests with call, which returns the result of
+// one method call or an error reported to the client.
+func stubNode(t *testing.T, call func(method string, params []json.RawMessage) (interface{}, error)) string {
+    t.Helper()
+    
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        var req struct {
+            ID     json.RawMessage   `json:"id"`
+            Method string            `json:"method"`
+            Params []json.RawMessage `json:"params"`
+        }
+        if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+            http.Error(w, err.Error(), http.StatusBadRequest)
+            return
+        }
+        
+        resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+        if result, err := call(req.Method, req.Params); err != nil {
+            resp["error"] = map[string]interface{}{"code": -32000, "message": err.Error()}
+        } else {
+            resp["result"] = result
+        }
+        w.Header().Set("Content-Type", "application/json")
+        json.NewEncoder(w).Encode(resp)
+    }))
+    t.Cleanup(server.Close)
+    return server.URL
+}
+
+// dialStub dials a node serving call, without retries.
+func dialStub(t *testing.T, call func(method string, params []json.RawMessage) (interface{}, error)) *rpcClient {
+    t.Helper()
+    
+    client, err := dialRPC("stub", stubNode(t, call), quietLogger(), nopMonitor{}, 0, 1000, time.Second)
+    if err != nil {
+        t.Fatalf("dial stub node: %v", err)
+    }
+    return client
+}
+
+// word is value as one ABI word.
+func word(value int64) []byte {
+    return common.LeftPadBytes(big.NewInt(value).Bytes(), 32)
+}
+
+func TestPrecompilePerpPrice(t *testing.T) {
+    perpOracle := common.HexToAddress("0x0000000000000000000000000000000000000807")
+    tests := []struct {
+        name   string
+        result []byte
+        err    error
+        want   *big.Int
+    }{
+        {"six decimal price", word(65000123456), nil, big.NewInt(6500012345600)},
+        {"price with update time", append(word(2500000000), word(1767366245)...), nil, big.NewInt(250000000000)},
+        {"zero price", word(0), nil, nil},
+        {"empty result", []byte{}, nil, nil},
+        {"call error", nil, errors.New("execution aborted"), nil},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            client := dialStub(t, func(method string, params []json.RawMessage) (interface{}, error) {
+                if method != "eth_call" {
+                    t.Errorf("method = %s, want eth_call", method)
+                }
+                var args callArgs
+                if err := json.Unmarshal(params[0], &args); err != nil {
+                    t.Fatalf("decode call args: %v", err)
+                }
+                if args.To != perpOracle {
+                    t.Errorf("call to %s, want the perp oracle %s", args.To, perpOracle)
+                }
+                if got := args.calldata(); !bytes.Equal(got, word(3)) {
+                    t.Errorf("calldata = %x, want asset 3 as one word", got)
+                }
+                if tt.err != nil {
+                    return nil, tt.err
+                }
+                return hexutil.Bytes(tt.result), nil
+            })
+            
+            prices := newPrecompilePrices(quietLogger(), client, nopMonitor{}, config.Default())
+            
+            got, _ := prices.PerpPrice(context.Background(), 3)
+            switch {
+            case tt.want == nil && got != nil:
+                t.Fatalf("price = %v, want unavailable", got)
+            case tt.want != nil && (got == nil || got.Cmp(tt.want) != 0):
+                t.Fatalf("price = %v, want %v", got, tt.want)
+            }
+        })
+    }
+}
\ No newline at end of file
@@ -0,0 +1,68 @@
+package detector
+
+import (
+    "context"
+    "math/big"
+    "time"
+
+    "github.com/hypercore-suite/arbitrage/config"
+    "github.com/hypercore-suite/arbitrage/thresholds"
+    "github.com/hypercore-suite/arbitrage/trade"
+    "github.com/sirupsen/logrus"
+)
+
+// Quote is one recorded pair of oracle prices for an asset, already at
+// PriceDecimals.
+type Quote struct {
+    Time      time.Time
+    Asset     uint32
+    PerpPrice *big.Int
+    SpotPrice *big.Int
+}
+
+// NewReplayDetector builds a detector whose Prices are the quotes passed to
+// Evaluate instead of the oracles. It dials nothing; funding is treated as
+// zero and opportunities are sized as configured at the quoted price.
+func NewReplayDetector(logger *logrus.Logger, monitor Monitor, cfg *config.Config) *Detector {
+    detCfg := cfg.Detector
+    
+    replay := &Detector{
+        logger:         logger,
+        monitor:        monitor,
+        assets:         cfg.Registry(),
+        Mode:           ModePoll,
+        PollInterval:   detCfg.PollInterval,
+        Assets:         append([]uint32(nil), detCfg.Assets...),
+        Thresholds:     thresholds.New(thresholds.FromConfig(cfg)),
+        HoldingPeriod:  detCfg.HoldingPeriod,
+        MaxSlippageBps: detCfg.MaxSlippageBps,
+        MinTradeSize:   big.NewInt(detCfg.MinTradeSize),
+        MaxTradeSize:   big.NewInt(detCfg.MaxTradeSize),
+        MaxPriceAge:    detCfg.MaxPriceAge,
+        ScanWorkers:    1,
+        Fees:           trade.BpsFees{},
+        perpFreshness:  newPriceFreshness(),
+        spotFreshness:  newPriceFreshness(),
+        dedup:          newEmitDedup(detCfg.DedupWindow, detCfg.DedupMinChangeBps),
+        sizing:         newNotionalSizing(detCfg),
+        replay:         make(StaticPrices),
+    }
+    replay.Prices = replay.replay
+    replay.now = func() time.Time { return replay.clock }
+    return replay
+}
+
+// Evaluate runs the live detection logic, including deduplication, against
+// q as if it were the current oracle state at q.Time. It returns the
+// opportunity that would have been emitted, or nil.
+func (d *Detector) Evaluate(q Quote) *Opportunity {
+    d.replay.Set(q)
+    d.clock = q.Time
+    
+    opp := d.detectOpportunity(context.Background(), q.Asset)
+    if opp == nil || d.dedup.duplicate(opp, opp.Timestamp) {
+        return nil
+    }
+    d.dedup.record(opp, opp.Timestamp)
+    return opp
+}
\ No newline at end of file
@@ -0,0 +1,183 @@
+package detector
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "math/big"
+    "math/rand"
+    "strings"
+    "sync"
+    "time"
+
+    "github.com/ethereum/go-ethereum"
+    "github.com/ethereum/go-ethereum/ethclient"
+    "github.com/ethereum/go-ethereum/rpc"
+    "github.com/hypercore-suite/arbitrage/trade"
+    "github.com/sirupsen/logrus"
+)
+
+const (
+    rpcBaseBackoff = 20 * time.Millisecond
+    rpcMaxBackoff  = time.Second
+)
+
+// ErrRPCUnavailable wraps failures to reach a node or get an answer from it
+// in time. They are transient: the same call may succeed later. It is
+// trade.ErrRPCUnavailable, so the executor can match it without importing
+// the detector.
+var ErrRPCUnavailable = trade.ErrRPCUnavailable
+
+// rpcClient wraps an ethclient connection with retries, exponential backoff
+// with jitter, and re-dialing after repeated failures. Health transitions are
+// logged and reported to the monitor.
+type rpcClient struct {
+    name    string
+    url     string
+    logger  *logrus.Logger
+    monitor Monitor
+    
+    maxRetries  int
+    redialAfter int
+    timeout     time.Duration
+    
+    mu       sync.RWMutex
+    client   *ethclient.Client
+    failures int
+    degraded bool
+}
+
+func dialRPC(name, url string, logger *logrus.Logger, monitor Monitor, maxRetries, redialAfter int, timeout time.Duration) (*rpcClient, error) {
+    client, err := ethclient.Dial(url)
+    if err != nil {
+        return nil, err
+    }
+    
+    monitor.RecordRPCHealth(name, true)
+    return &rpcClient{
+        name:        name,
+        url:         url,
+        logger:      logger,
+        monitor:     monitor,
+        maxRetries:  maxRetries,
+        redialAfter: redialAfter,
+        timeout:     timeout,
+        client:      client,
+    }, nil
+}
+
+func (c *rpcClient) CallContract(ctx context.Context, msg ethereum.CallMsg, block *big.Int) ([]byte, error) {
+    var result []byte
+    err := c.retry(ctx, func(ctx context.Context, client *ethclient.Client) error {
+        var err error
+        result, err = client.CallContract(ctx, msg, block)
+        return err
+    })
+    return result, err
+}
+
+func (c *rpcClient) BlockNumber(ctx context.Context) (uint64, error) {
+    var number uint64
+    err := c.retry(ctx, func(ctx context.Context, client *ethclient.Client) error {
+        var err error
+        number, err = client.BlockNumber(ctx)
+        return err
+    })
+    return number, err
+}
+
+// retry runs call up to maxRetries+1 times, backing off between attempts.
+// Each attempt is bounded by the call timeout; an attempt that times out is
+// not retried, so a hung node costs one timeout rather than one per retry,
+// and neither is a reverted call, which would revert again. Failures other
+// than reverts are returned wrapped in ErrRPCUnavailable.
+func (c *rpcClient) retry(ctx context.Context, call func(context.Context, *ethclient.Client) error) error {
+    var err error
+    for attempt := 0; attempt <= c.maxRetries; attempt++ {
+        if attempt > 0 {
+            select {
+            case <-ctx.Done():
+                return ctx.Err()
+            case <-time.After(backoff(attempt)):
+            }
+        }
+        
+        c.mu.RLock()
+        client := c.client
+        c.mu.RUnlock()
+        
+        attemptCtx, cancel := context.WithTimeout(ctx, c.timeout)
+        err = call(attemptCtx, client)
+        cancel()
+        if err == nil {
+            c.recordSuccess()
+            return nil
+        }
+        if isRevert(err) {
+            c.recordSuccess()
+            return err
+        }
+        if errors.Is(err, context.DeadlineExceeded) {
+            break
+        }
+    }
+    
+    c.recordFailure(err)
+    return fmt.Errorf("%s: %w: %w", c.name, ErrRPCUnavailable, err)
+}
+
+// isRevert reports whether err is the node reporting that a call reverted,
+// as opposed to the node failing to answer.
+func isRevert(err error) bool {
+    var rpcErr rpc.Error
+    return errors.As(err, &rpcErr) && strings.Contains(strings.ToLower(rpcErr.Error()), "revert")
+}
+
+// backoff returns the delay before the given retry attempt: exponential in
+// the attempt number, capped at rpcMaxBackoff, with up to 50% jitter.
+func backoff(attempt int) time.Duration {
+    delay := rpcBaseBackoff << uint(attempt-1)
+    if delay > rpcMaxBackoff || delay <= 0 {
+        delay = rpcMaxBackoff
+    }
+    return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+func (c *rpcClient) recordSuccess() {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    
+    c.failures = 0
+    if c.degraded {
+        c.degraded = false
+        c.logger.WithField("endpoint", c.name).Info("RPC endpoint healthy")
+        c.monitor.RecordRPCHealth(c.name, true)
+    }
+}
+
+func (c *rpcClient) recordFailure(err error) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    
+    c.failures++
+    if !c.degraded {
+        c.degraded = true
+        c.logger.WithError(err).WithField("endpoint", c.name).Warn("RPC endpoint degraded")
+        c.monitor.RecordRPCHealth(c.name, false)
+    }
+    
+    if c.failures < c.redialAfter {
+        return
+    }
+    
+    c.failures = 0
+    client, dialErr := ethclient.Dial(c.url)
+    if dialErr != nil {
+        c.logger.WithError(dialErr).WithField("endpoint", c.name).Error("RPC re-dial failed")
+        return
+    }
+    
+    c.client.Close()
+    c.client = client
+    c.logger.WithField("endpoint", c.name).Info("RPC endpoint re-dialed")
+}
@@ -0,0 +1,60 @@
+package detector
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "sync/atomic"
+    "testing"
+    "time"
+
+    "github.com/ethereum/go-ethereum"
+    "github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestCallTimesOutOnHungNode(t *testing.T) {
+    const timeout = 50 * time.Millisecond
+    tests := []struct {
+        name         string
+        delay        time.Duration
+        wantErr      error
+        wantAttempts int32
+    }{
+        {"prompt node answers", 0, nil, 1},
+        {"hung node times out once", 4 * timeout, context.DeadlineExceeded, 1},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            var attempts atomic.Int32
+            url := stubNode(t, func(method string, params []json.RawMessage) (interface{}, error) {
+                attempts.Add(1)
+                time.Sleep(tt.delay)
+                return hexutil.Bytes(word(1)), nil
+            })
+            client, err := dialRPC("stub", url, quietLogger(), nopMonitor{}, 2, 1000, timeout)
+            if err != nil {
+                t.Fatalf("dial stub node: %v", err)
+            }
+            
+            start := time.Now()
+            _, err = client.CallContract(context.Background(), ethereum.CallMsg{}, nil)
+            elapsed := time.Since(start)
+            
+            switch {
+            case tt.wantErr == nil && err != nil:
+                t.Fatalf("call: %v", err)
+            case tt.wantErr != nil && !errors.Is(err, tt.wantErr):
+                t.Fatalf("call error = %v, want %v", err, tt.wantErr)
+            case tt.wantErr != nil && !errors.Is(err, ErrRPCUnavailable):
+                t.Fatalf("call error = %v, want it wrapped in ErrRPCUnavailable", err)
+            }
+            if elapsed > 3*timeout {
+                t.Fatalf("call took %v with a %v timeout", elapsed, timeout)
+            }
+            if got := attempts.Load(); got != tt.wantAttempts {
+                t.Fatalf("node saw %d attempts, want %d", got, tt.wantAttempts)
+            }
+        })
+    }
+}
\ No newline at end of file
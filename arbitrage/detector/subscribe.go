@@ -0,0 +1,46 @@
+package detector
+
+import (
+    "context"
+    "fmt"
+
+    "github.com/ethereum/go-ethereum/core/types"
+    "github.com/ethereum/go-ethereum/ethclient"
+)
+
+const (
+    ModePoll      = "poll"
+    ModeSubscribe = "subscribe"
+)
+
+// subscribe scans all assets on every new block head from the WebSocket
+// endpoint. It returns nil when ctx is cancelled and an error when the
+// endpoint cannot be reached, does not support subscriptions, or the
+// subscription drops, so the caller can fall back to polling.
+func (d *Detector) subscribe(ctx context.Context, opportunities chan<- *Opportunity) error {
+    client, err := ethclient.DialContext(ctx, d.WSURL)
+    if err != nil {
+        return fmt.Errorf("dial %s: %w", d.WSURL, err)
+    }
+    defer client.Close()
+    
+    heads := make(chan *types.Header, 16)
+    sub, err := client.SubscribeNewHead(ctx, heads)
+    if err != nil {
+        return fmt.Errorf("subscribe to new heads: %w", err)
+    }
+    defer sub.Unsubscribe()
+    
+    d.logger.WithField("url", d.WSURL).Info("Detector subscribed to new heads")
+    
+    for {
+        select {
+        case <-ctx.Done():
+            return nil
+        case err := <-sub.Err():
+            return fmt.Errorf("head subscription dropped: %w", err)
+        case <-heads:
+            d.scan(ctx, opportunities)
+        }
+    }
+}
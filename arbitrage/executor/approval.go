@@ -0,0 +1,150 @@
+package executor
+
+import (
+    "context"
+    "fmt"
+    "math/big"
+    "sync"
+    "time"
+
+    "github.com/ethereum/go-ethereum"
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/ethereum/go-ethereum/common/math"
+    "github.com/ethereum/go-ethereum/core/types"
+    "github.com/hypercore-suite/arbitrage/config"
+    "github.com/sirupsen/logrus"
+)
+
+const approvalGasLimit = 100000
+
+// approvalManager tracks which ERC20 tokens have a sufficient allowance for
+// each route's arbitrage contract. Verified allowances are cached for ttl so
+// they are not re-read on every execution.
+type approvalManager struct {
+    tokens []common.Address
+    amount *big.Int
+    ttl    time.Duration
+    
+    mu       sync.Mutex
+    verified map[approvalKey]time.Time
+}
+
+// approvalKey identifies one token allowance granted to one spender.
+type approvalKey struct {
+    token, spender common.Address
+}
+
+func newApprovalManager(cfg config.ApprovalConfig) *approvalManager {
+    m := &approvalManager{
+        amount:   math.MaxBig256,
+        ttl:      cfg.TTL,
+        verified: make(map[approvalKey]time.Time),
+    }
+    
+    for _, token := range cfg.Tokens {
+        m.tokens = append(m.tokens, common.HexToAddress(token))
+    }
+    
+    if cfg.Amount != "max" {
+        m.amount, _ = new(big.Int).SetString(cfg.Amount, 10)
+    }
+    return m
+}
+
+func (m *approvalManager) fresh(token, spender common.Address, now time.Time) bool {
+    m.mu.Lock()
+    defer m.mu.Unlock()
+    
+    at, ok := m.verified[approvalKey{token, spender}]
+    return ok && now.Sub(at) < m.ttl
+}
+
+func (m *approvalManager) markVerified(token, spender common.Address, now time.Time) {
+    m.mu.Lock()
+    defer m.mu.Unlock()
+    m.verified[approvalKey{token, spender}] = now
+}
+
+// sufficient reports whether allowance still covers the configured approval.
+// Allowances are refreshed once they drop below half the approved amount so
+// that a max approval is not re-sent after every trade.
+func (m *approvalManager) sufficient(allowance *big.Int) bool {
+    return allowance.Cmp(new(big.Int).Rsh(m.amount, 1)) >= 0
+}
+
+// ensureApprovals makes sure every configured token has an allowance from w
+// for spender, a route's arbitrage contract, sending and confirming approve
+// transactions through the wallet's nonce manager where needed.
+func (e *Executor) ensureApprovals(ctx context.Context, w *wallet, spender common.Address) error {
+    now := time.Now()
+    
+    for _, token := range w.approvals.tokens {
+        if w.approvals.fresh(token, spender, now) {
+            continue
+        }
+        
+        allowance, err := e.allowance(ctx, w, token, spender)
+        if err != nil {
+            return err
+        }
+        
+        if !w.approvals.sufficient(allowance) {
+            if err := e.approve(ctx, w, token, spender, w.approvals.amount); err != nil {
+                return err
+            }
+        }
+        
+        w.approvals.markVerified(token, spender, now)
+    }
+    
+    return nil
+}
+
+// allowance returns how much of token spender may move from w.
+func (e *Executor) allowance(ctx context.Context, w *wallet, token, spender common.Address) (*big.Int, error) {
+    data, err := erc20ABI.Pack("allowance", w.address, spender)
+    if err != nil {
+        return nil, err
+    }
+    
+    result, err := e.client.CallContract(ctx, ethereum.CallMsg{To: &token, Data: data}, nil)
+    if err != nil {
+        return nil, fmt.Errorf("fetch allowance for %s: %w", token.Hex(), err)
+    }
+    
+    out, err := erc20ABI.Unpack("allowance", result)
+    if err != nil {
+        return nil, fmt.Errorf("decode allowance for %s: %w", token.Hex(), err)
+    }
+    return out[0].(*big.Int), nil
+}
+
+// approve lets spender move amount of token from w and waits for the
+// approval to be mined.
+func (e *Executor) approve(ctx context.Context, w *wallet, token, spender common.Address, amount *big.Int) error {
+    data, err := erc20ABI.Pack("approve", spender, amount)
+    if err != nil {
+        return err
+    }
+    
+    tx, err := e.broadcast(ctx, w, token, approvalGasLimit, data, nil, false)
+    if err != nil {
+        return fmt.Errorf("approve %s: %w", token.Hex(), err)
+    }
+    
+    e.logger.WithFields(logrus.Fields{
+        "wallet":  w.address.Hex(),
+        "token":   token.Hex(),
+        "spender": spender.Hex(),
+        "tx_hash": tx.Hash().Hex(),
+    }).Info("Approval sent")
+    
+    receipt, err := e.waitForReceipt(ctx, w, tx)
+    if err != nil {
+        return fmt.Errorf("approve %s not confirmed: %w", token.Hex(), err)
+    }
+    if receipt.Status != types.ReceiptStatusSuccessful {
+        return fmt.Errorf("approve %s reverted in %s", token.Hex(), tx.Hash().Hex())
+    }
+    return nil
+}
@@ -0,0 +1,73 @@
+package executor
+
+import (
+    "math/big"
+    "time"
+
+    "github.com/hypercore-suite/arbitrage/config"
+    "github.com/hypercore-suite/arbitrage/thresholds"
+    "github.com/hypercore-suite/arbitrage/trade"
+    "github.com/sirupsen/logrus"
+)
+
+// BacktestResult is the theoretical outcome of one opportunity. Rejected
+// says why an opportunity that would not have traded was turned down; an
+// unprofitable one still carries its estimated profits.
+type BacktestResult struct {
+    Executed    bool
+    Rejected    Rejection
+    GrossProfit *big.Int
+    NetProfit   *big.Int
+}
+
+// NewBacktestExecutor builds an executor that only evaluates opportunities.
+// It has no RPC connection or key, so Start and anything that broadcasts
+// must not be called on it.
+func NewBacktestExecutor(logger *logrus.Logger, cfg *config.Config) *Executor {
+    execCfg := cfg.Executor
+    
+    return &Executor{
+        logger:          logger,
+        defaultGasLimit: execCfg.DefaultGasLimit,
+        nativePrice:     big.NewInt(execCfg.NativeTokenPrice),
+        routes:          newRoutes(execCfg),
+        spotHalfSpread:  execCfg.SpotHalfSpreadBps,
+        perpHalfSpread:  execCfg.PerpHalfSpreadBps,
+        
+        MaxOpportunityAge: execCfg.MaxOpportunityAge,
+        Thresholds:        thresholds.New(thresholds.FromConfig(cfg)),
+    }
+}
+
+// Backtest applies the live validation and simulation to opp at now and
+// returns what executing it through the most profitable route would have
+// earned. Gas is charged at the default gas limit and the gas price cap
+// because there is no chain to estimate against.
+func (e *Executor) Backtest(opp *trade.Opportunity, now time.Time) BacktestResult {
+    if reason := e.validateOpportunity(opp, now); reason != "" {
+        return BacktestResult{Rejected: reason}
+    }
+    
+    var profit *big.Int
+    success := false
+    gasPrice := e.lastGasPrice()
+    for _, r := range e.routes {
+        routeProfit, routeSuccess := e.simulateExecution(opp, r, e.defaultGasLimit, gasPrice, nil)
+        if profit == nil || routeProfit.Cmp(profit) > 0 {
+            profit, success = routeProfit, routeSuccess
+        }
+    }
+    if !success || profit.Cmp(e.minNetProfit()) < 0 {
+        return BacktestResult{
+            Rejected:    RejectUnprofitable,
+            GrossProfit: grossProfit(opp, nil),
+            NetProfit:   profit,
+        }
+    }
+    
+    return BacktestResult{
+        Executed:    true,
+        GrossProfit: grossProfit(opp, nil),
+        NetProfit:   profit,
+    }
+}
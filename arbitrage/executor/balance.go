@@ -0,0 +1,148 @@
+package executor
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "math/big"
+
+    "github.com/ethereum/go-ethereum"
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/hypercore-suite/arbitrage/config"
+    "github.com/hypercore-suite/arbitrage/pricing"
+    "github.com/hypercore-suite/arbitrage/trade"
+    "github.com/sirupsen/logrus"
+)
+
+// errNoNativePrice is returned when capital is held in the native token but
+// there is no price to value it at.
+var errNoNativePrice = errors.New("no native token price to value native capital")
+
+// capitalConfig describes where the executor's trading capital is held; token
+// is nil for the native balance. A token balance is taken as quote (USD)
+// value, a native balance is valued at the native token price.
+type capitalConfig struct {
+    token       *common.Address
+    decimals    int
+    fractionBps uint64
+    minSize     *big.Int
+}
+
+func newCapitalConfig(cfg config.CapitalConfig) capitalConfig {
+    c := capitalConfig{
+        decimals:    cfg.Decimals,
+        fractionBps: cfg.FractionBps,
+        minSize:     new(big.Int).SetUint64(cfg.MinTradeSize),
+    }
+    
+    if cfg.Token != "" {
+        token := common.HexToAddress(cfg.Token)
+        c.token = &token
+    }
+    
+    if c.decimals == 0 {
+        c.decimals = 18
+        if c.token != nil {
+            c.decimals = 6
+        }
+    }
+    return c
+}
+
+// availableCapital returns w's trading capital as USD value in
+// trade.PriceDecimals fixed point. A native balance is priced at
+// nativeTokenPrice; without a price it fails with errNoNativePrice rather
+// than counting wei as dollars.
+func (e *Executor) availableCapital(ctx context.Context, w *wallet) (*big.Int, error) {
+    if e.capital.token != nil {
+        token, err := e.tokenBalance(ctx, *e.capital.token, w.address)
+        if err != nil {
+            return nil, err
+        }
+        return pricing.Rescale(token, e.capital.decimals, trade.PriceDecimals), nil
+    }
+    
+    price := e.nativeTokenPrice()
+    if price == nil || price.Sign() <= 0 {
+        return nil, errNoNativePrice
+    }
+    native, err := e.client.BalanceAt(ctx, w.address, nil)
+    if err != nil {
+        return nil, fmt.Errorf("fetch native balance: %w", classifyCallError(err))
+    }
+    return pricing.MulDiv(native, price, pricing.Pow10(e.capital.decimals), pricing.RoundDown), nil
+}
+
+// tokenBalance returns account's balance of the ERC20 token, in the token's
+// own units.
+func (e *Executor) tokenBalance(ctx context.Context, token, account common.Address) (*big.Int, error) {
+    data, err := erc20ABI.Pack("balanceOf", account)
+    if err != nil {
+        return nil, err
+    }
+    
+    result, err := e.client.CallContract(ctx, ethereum.CallMsg{To: &token, Data: data}, nil)
+    if err != nil {
+        return nil, fmt.Errorf("fetch token balance: %w", classifyCallError(err))
+    }
+    
+    out, err := erc20ABI.Unpack("balanceOf", result)
+    if err != nil {
+        return nil, fmt.Errorf("decode token balance: %w", err)
+    }
+    return out[0].(*big.Int), nil
+}
+
+// sizeForBalance returns a copy of opp whose amount is capped so its notional
+// stays within fractionBps of w's available capital. It is rejected when the
+// balance cannot be read or the capped size falls below the minimum viable
+// trade size.
+func (e *Executor) sizeForBalance(ctx context.Context, w *wallet, opp *trade.Opportunity) (*trade.Opportunity, Rejection) {
+    capital, err := e.availableCapital(ctx, w)
+    if !errors.Is(err, errNoNativePrice) {
+        e.monitor.RecordRPCHealth("executor", err == nil)
+    }
+    if err != nil {
+        e.logger.WithError(err).WithFields(opp.LogFields()).Warn("Balance check failed, skipping opportunity")
+        return nil, RejectBalanceUnknown
+    }
+    e.monitor.RecordWalletBalance(w.address.Hex(), capital)
+    
+    price := opp.FillPrice
+    if price == nil {
+        price = opp.EVMPrice
+    }
+    
+    amount := capTradeSize(opp.Amount, capital, price, e.capital.fractionBps)
+    if amount.Cmp(e.capital.minSize) < 0 {
+        e.logger.WithFields(opp.LogFields()).WithFields(logrus.Fields{
+            "wallet":   w.address.Hex(),
+            "capital":  capital,
+            "max_size": amount,
+        }).Info("Insufficient balance for minimum trade size, skipping opportunity")
+        return nil, RejectBalanceTooLow
+    }
+    
+    sized := *opp
+    sized.Amount = amount
+    return &sized, ""
+}
+
+// capTradeSize limits amount so that amount*price stays within fractionBps of
+// capital. All values share trade.PriceDecimals fixed point.
+func capTradeSize(amount, capital, price *big.Int, fractionBps uint64) *big.Int {
+    if price == nil || price.Sign() <= 0 {
+        return new(big.Int)
+    }
+    
+    budget := new(big.Int).Mul(capital, new(big.Int).SetUint64(fractionBps))
+    budget.Quo(budget, big.NewInt(10000))
+    
+    maxSize := budget.Mul(budget, pricing.Pow10(trade.PriceDecimals))
+    maxSize.Quo(maxSize, price)
+    
+    if amount.Cmp(maxSize) < 0 {
+        return new(big.Int).Set(amount)
+    }
+    return maxSize
+}
@@ -0,0 +1,39 @@
+package executor
+
+import (
+    "context"
+    "errors"
+    "testing"
+
+    "github.com/hypercore-suite/arbitrage/config"
+)
+
+func TestAvailableCapitalPricesNativeBalance(t *testing.T) {
+    // The stub node holds 1000 native tokens in every wallet.
+    tests := []struct {
+        name        string
+        nativePrice int64
+        want        int64
+        wantErr     error
+    }{
+        {"valued at the native price", 2000000000, 2000000000000, nil},
+        {"sub-dollar native price", 50000000, 50000000000, nil},
+        {"refused without a price", 0, 0, errNoNativePrice},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            e, _ := newTestExecutor(t, newStubNode(t), func(cfg *config.Config) {
+                cfg.Executor.NativeTokenPrice = tt.nativePrice
+            })
+            
+            capital, err := e.availableCapital(context.Background(), e.wallets.wallets[0])
+            if !errors.Is(err, tt.wantErr) {
+                t.Fatalf("availableCapital error = %v, want %v", err, tt.wantErr)
+            }
+            if err == nil && capital.Int64() != tt.want {
+                t.Fatalf("capital = %v, want %d", capital, tt.want)
+            }
+        })
+    }
+}
@@ -0,0 +1,104 @@
+package executor
+
+import (
+    "math/big"
+    "sync"
+    "time"
+)
+
+// circuitBreaker halts trading after too many failed executions or too large
+// a cumulative loss within a rolling window. Once tripped it stays open for
+// the cooldown period or until reset.
+type circuitBreaker struct {
+    mu sync.Mutex
+    
+    maxFailures int
+    maxLoss     *big.Int
+    window      time.Duration
+    cooldown    time.Duration
+    
+    outcomes     []executionOutcome
+    trippedUntil time.Time
+}
+
+type executionOutcome struct {
+    at      time.Time
+    success bool
+    pnl     *big.Int
+}
+
+func newCircuitBreaker(maxFailures int, maxLoss *big.Int, window, cooldown time.Duration) *circuitBreaker {
+    return &circuitBreaker{
+        maxFailures: maxFailures,
+        maxLoss:     maxLoss,
+        window:      window,
+        cooldown:    cooldown,
+    }
+}
+
+// record adds an execution outcome and reports whether it tripped the breaker.
+func (b *circuitBreaker) record(success bool, pnl *big.Int, now time.Time) bool {
+    b.mu.Lock()
+    defer b.mu.Unlock()
+    
+    b.outcomes = append(b.outcomes, executionOutcome{at: now, success: success, pnl: pnl})
+    b.evict(now)
+    
+    if now.Before(b.trippedUntil) {
+        return false
+    }
+    
+    failures := 0
+    loss := new(big.Int)
+    for _, o := range b.outcomes {
+        if !o.success {
+            failures++
+        }
+        if o.pnl.Sign() < 0 {
+            loss.Sub(loss, o.pnl)
+        }
+    }
+    
+    tripped := (b.maxFailures > 0 && failures >= b.maxFailures) ||
+        (b.maxLoss.Sign() > 0 && loss.Cmp(b.maxLoss) >= 0)
+    if tripped {
+        b.trippedUntil = now.Add(b.cooldown)
+    }
+    return tripped
+}
+
+// open reports whether the breaker is currently halting trading. The window
+// is cleared when a cooldown expires so old failures do not re-trip it.
+func (b *circuitBreaker) open(now time.Time) bool {
+    b.mu.Lock()
+    defer b.mu.Unlock()
+    
+    if b.trippedUntil.IsZero() {
+        return false
+    }
+    if now.Before(b.trippedUntil) {
+        return true
+    }
+    
+    b.trippedUntil = time.Time{}
+    b.outcomes = nil
+    return false
+}
+
+// reset closes the breaker immediately and forgets recorded outcomes.
+func (b *circuitBreaker) reset() {
+    b.mu.Lock()
+    defer b.mu.Unlock()
+    
+    b.trippedUntil = time.Time{}
+    b.outcomes = nil
+}
+
+func (b *circuitBreaker) evict(now time.Time) {
+    cutoff := now.Add(-b.window)
+    i := 0
+    for i < len(b.outcomes) && b.outcomes[i].at.Before(cutoff) {
+        i++
+    }
+    b.outcomes = b.outcomes[i:]
+}
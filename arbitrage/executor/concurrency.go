@@ -0,0 +1,33 @@
+package executor
+
+import "context"
+
+// executionSlots bounds how many executions are in flight at once. A slot is
+// taken before a wallet and held until the execution settles, so at most
+// that many transactions await confirmation even with more wallets idle.
+// Each execution still holds its wallet throughout, so nonces are never
+// shared however many slots there are.
+type executionSlots chan struct{}
+
+// newExecutionSlots allows max concurrent executions. Zero, or a limit above
+// the wallet count, leaves the wallets as the only bound.
+func newExecutionSlots(max, wallets int) executionSlots {
+    if max <= 0 || max > wallets {
+        max = wallets
+    }
+    return make(executionSlots, max)
+}
+
+// acquire blocks until a slot is free or ctx is done.
+func (s executionSlots) acquire(ctx context.Context) error {
+    select {
+    case s <- struct{}{}:
+        return nil
+    case <-ctx.Done():
+        return ctx.Err()
+    }
+}
+
+func (s executionSlots) release() {
+    <-s
+}
\ No newline at end of file
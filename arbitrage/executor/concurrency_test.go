@@ -0,0 +1,92 @@
+package executor
+
+import (
+    "context"
+    "encoding/json"
+    "strconv"
+    "sync"
+    "testing"
+    "time"
+
+    "github.com/hypercore-suite/arbitrage/config"
+    "github.com/hypercore-suite/arbitrage/trade"
+)
+
+func TestStartCapsConcurrentExecutions(t *testing.T) {
+    const burst = 6
+    
+    // Dry runs block until released, so every execution started is held
+    // in flight and the peak shows how many ran at once.
+    node := newStubNode(t)
+    var (
+        mu           sync.Mutex
+        active, peak int
+    )
+    entered := make(chan struct{}, burst*4)
+    proceed := make(chan struct{})
+    node.handle("eth_call", func([]json.RawMessage) (interface{}, error) {
+        mu.Lock()
+        active++
+        if active > peak {
+            peak = active
+        }
+        mu.Unlock()
+        entered <- struct{}{}
+        <-proceed
+        mu.Lock()
+        active--
+        mu.Unlock()
+        return dryRunResult(40000000)
+    })
+    e, _ := newTestExecutor(t, node, func(cfg *config.Config) {
+        cfg.Executor.PrivateKeys = []string{
+            "8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a",
+            "6370fd033278c143179d81c5526140625662b8daa446c22ee2d73db3707e620c",
+        }
+        cfg.Executor.MaxConcurrentExecutions = 2
+        cfg.Executor.WarmUp = 0
+        cfg.Executor.NativeTokenPrice = 2000000000
+        cfg.Executor.MinExecutionInterval = 0
+    })
+    
+    opportunities := make(chan *trade.Opportunity, burst)
+    for i := 0; i < burst; i++ {
+        opp := testOpportunity()
+        opp.ID = "opp-" + strconv.Itoa(i)
+        opportunities <- opp
+    }
+    close(opportunities)
+    
+    ctx, cancel := context.WithCancel(context.Background())
+    defer cancel()
+    done := make(chan struct{})
+    go func() {
+        e.Start(ctx, opportunities)
+        close(done)
+    }()
+    
+    // Two executions start with three wallets idle; a third must wait.
+    for i := 0; i < 2; i++ {
+        select {
+        case <-entered:
+        case <-time.After(5 * time.Second):
+            t.Fatalf("%d of 2 executions started", i)
+        }
+    }
+    select {
+    case <-entered:
+        t.Fatal("third execution started while two were in flight")
+    case <-time.After(100 * time.Millisecond):
+    }
+    
+    close(proceed)
+    <-done
+    e.Shutdown(5 * time.Second)
+    
+    if peak != 2 {
+        t.Fatalf("peak concurrency = %d, want 2", peak)
+    }
+    if got := len(node.transactions()); got != burst {
+        t.Fatalf("sent %d transactions, want %d", got, burst)
+    }
+}
@@ -0,0 +1,207 @@
+package executor
+
+import (
+    "fmt"
+    "math/big"
+    "strings"
+
+    "github.com/ethereum/go-ethereum/accounts/abi"
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/hypercore-suite/arbitrage/trade"
+)
+
+// arbitrageABIJSON is the subset of the CoreEVMArbitrage ABI the executor
+// calls.
+const arbitrageABIJSON = `[
+    {
+        "type": "function",
+        "name": "executeArbitrage",
+        "stateMutability": "nonpayable",
+        "inputs": [
+            {
+                "name": "params",
+                "type": "tuple",
+                "components": [
+                    {"name": "asset", "type": "uint32"},
+                    {"name": "amount", "type": "uint64"},
+                    {"name": "minProfit", "type": "uint64"},
+                    {"name": "isBuy", "type": "bool"},
+                    {"name": "path", "type": "address[]"}
+                ]
+            }
+        ],
+        "outputs": [
+            {
+                "name": "",
+                "type": "tuple",
+                "components": [
+                    {"name": "executed", "type": "bool"},
+                    {"name": "profit", "type": "uint64"},
+                    {"name": "gasUsed", "type": "uint256"},
+                    {"name": "executionData", "type": "bytes"}
+                ]
+            }
+        ]
+    }
+]`
+
+// legacyArbitrageABIJSON is executeArbitrage as deployed before the
+// ArbitrageParams path field was added. Its results decode like the current
+// ABI's.
+const legacyArbitrageABIJSON = `[
+    {
+        "type": "function",
+        "name": "executeArbitrage",
+        "stateMutability": "nonpayable",
+        "inputs": [
+            {
+                "name": "params",
+                "type": "tuple",
+                "components": [
+                    {"name": "asset", "type": "uint32"},
+                    {"name": "amount", "type": "uint64"},
+                    {"name": "minProfit", "type": "uint64"},
+                    {"name": "isBuy", "type": "bool"}
+                ]
+            }
+        ],
+        "outputs": [
+            {
+                "name": "",
+                "type": "tuple",
+                "components": [
+                    {"name": "executed", "type": "bool"},
+                    {"name": "profit", "type": "uint64"},
+                    {"name": "gasUsed", "type": "uint256"},
+                    {"name": "executionData", "type": "bytes"}
+                ]
+            }
+        ]
+    }
+]`
+
+// erc20ABIJSON is the subset of the ERC20 ABI the executor calls.
+const erc20ABIJSON = `[
+    {
+        "type": "function",
+        "name": "balanceOf",
+        "stateMutability": "view",
+        "inputs": [{"name": "account", "type": "address"}],
+        "outputs": [{"name": "", "type": "uint256"}]
+    },
+    {
+        "type": "function",
+        "name": "allowance",
+        "stateMutability": "view",
+        "inputs": [
+            {"name": "owner", "type": "address"},
+            {"name": "spender", "type": "address"}
+        ],
+        "outputs": [{"name": "", "type": "uint256"}]
+    },
+    {
+        "type": "function",
+        "name": "approve",
+        "stateMutability": "nonpayable",
+        "inputs": [
+            {"name": "spender", "type": "address"},
+            {"name": "amount", "type": "uint256"}
+        ],
+        "outputs": [{"name": "", "type": "bool"}]
+    }
+]`
+
+// routerABIJSON is the subset of the Uniswap V2 router ABI used to convert
+// profit.
+const routerABIJSON = `[
+    {
+        "type": "function",
+        "name": "getAmountsOut",
+        "stateMutability": "view",
+        "inputs": [
+            {"name": "amountIn", "type": "uint256"},
+            {"name": "path", "type": "address[]"}
+        ],
+        "outputs": [{"name": "amounts", "type": "uint256[]"}]
+    },
+    {
+        "type": "function",
+        "name": "swapExactTokensForTokens",
+        "stateMutability": "nonpayable",
+        "inputs": [
+            {"name": "amountIn", "type": "uint256"},
+            {"name": "amountOutMin", "type": "uint256"},
+            {"name": "path", "type": "address[]"},
+            {"name": "to", "type": "address"},
+            {"name": "deadline", "type": "uint256"}
+        ],
+        "outputs": [{"name": "amounts", "type": "uint256[]"}]
+    }
+]`
+
+var (
+    arbitrageABI       = mustParseABI(arbitrageABIJSON)
+    legacyArbitrageABI = mustParseABI(legacyArbitrageABIJSON)
+    erc20ABI           = mustParseABI(erc20ABIJSON)
+    routerABI          = mustParseABI(routerABIJSON)
+)
+
+// ArbitrageParams mirrors CoreEVMArbitrage.ArbitrageParams.
+type ArbitrageParams struct {
+    Asset     uint32
+    Amount    uint64
+    MinProfit uint64
+    IsBuy     bool
+    Path      []common.Address
+}
+
+func mustParseABI(raw string) abi.ABI {
+    parsed, err := abi.JSON(strings.NewReader(raw))
+    if err != nil {
+        panic(err)
+    }
+    return parsed
+}
+
+// paramsFromOpportunity converts an opportunity into contract arguments
+// routed through path. The contract reverts unless the perp/spot oracle
+// price gap exceeds minProfit, a per-unit spread at trade.PriceDecimals.
+func paramsFromOpportunity(opp *trade.Opportunity, minProfit *big.Int, path []common.Address) (ArbitrageParams, error) {
+    if opp.Amount == nil || opp.Amount.Sign() <= 0 || !opp.Amount.IsUint64() {
+        return ArbitrageParams{}, fmt.Errorf("amount %v does not fit in uint64", opp.Amount)
+    }
+    if minProfit.Sign() < 0 || !minProfit.IsUint64() {
+        return ArbitrageParams{}, fmt.Errorf("min profit %v does not fit in uint64", minProfit)
+    }
+    
+    return ArbitrageParams{
+        Asset:     opp.Asset,
+        Amount:    opp.Amount.Uint64(),
+        MinProfit: minProfit.Uint64(),
+        IsBuy:     opp.IsBuy,
+        Path:      path,
+    }, nil
+}
+
+// arbitrageResult mirrors CoreEVMArbitrage.ArbitrageResult.
+type arbitrageResult struct {
+    Executed      bool
+    Profit        uint64
+    GasUsed       *big.Int
+    ExecutionData []byte
+}
+
+// DecodeExecuteArbitrageProfit extracts the profit reported in the return data
+// of executeArbitrage.
+func DecodeExecuteArbitrageProfit(data []byte) (*big.Int, error) {
+    out, err := arbitrageABI.Unpack("executeArbitrage", data)
+    if err != nil {
+        return nil, err
+    }
+    
+    result := abi.ConvertType(out[0], new(arbitrageResult)).(*arbitrageResult)
+    if !result.Executed {
+        return nil, fmt.Errorf("executeArbitrage reported executed=false")
+    }
+    return new(big.Int).SetUint64(result.Profit), nil
+}
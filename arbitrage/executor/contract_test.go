@@ -0,0 +1,92 @@
+package executor
+
+import (
+    "bytes"
+    "math/big"
+    "reflect"
+    "testing"
+
+    "github.com/ethereum/go-ethereum/accounts/abi"
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/hypercore-suite/arbitrage/trade"
+)
+
+func TestRouteCalldataRoundTrip(t *testing.T) {
+    hops := []common.Address{common.HexToAddress("0x00000000000000000000000000000000000000aa")}
+    tests := []struct {
+        name      string
+        abi       abi.ABI
+        opp       *trade.Opportunity
+        minProfit *big.Int
+        want      ArbitrageParams
+    }{
+        {
+            name:      "path buy",
+            abi:       arbitrageABI,
+            opp:       &trade.Opportunity{Asset: 3, Amount: big.NewInt(150000000), IsBuy: true},
+            minProfit: big.NewInt(2500000),
+            want:      ArbitrageParams{Asset: 3, Amount: 150000000, MinProfit: 2500000, IsBuy: true, Path: hops},
+        },
+        {
+            name:      "path sell at zero min profit",
+            abi:       arbitrageABI,
+            opp:       &trade.Opportunity{Asset: 0, Amount: big.NewInt(1)},
+            minProfit: new(big.Int),
+            want:      ArbitrageParams{Amount: 1, Path: hops},
+        },
+        {
+            name:      "legacy drops the path",
+            abi:       legacyArbitrageABI,
+            opp:       &trade.Opportunity{Asset: 7, Amount: big.NewInt(42), IsBuy: true},
+            minProfit: big.NewInt(9),
+            want:      ArbitrageParams{Asset: 7, Amount: 42, MinProfit: 9, IsBuy: true},
+        },
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            r := &route{abi: tt.abi, path: hops}
+            data, err := r.calldata(tt.opp, tt.minProfit)
+            if err != nil {
+                t.Fatalf("calldata: %v", err)
+            }
+            
+            method := tt.abi.Methods["executeArbitrage"]
+            if !bytes.Equal(data[:4], method.ID) {
+                t.Fatalf("selector = %x, want %x", data[:4], method.ID)
+            }
+            out, err := method.Inputs.Unpack(data[4:])
+            if err != nil {
+                t.Fatalf("unpack: %v", err)
+            }
+            got := abi.ConvertType(out[0], new(ArbitrageParams)).(*ArbitrageParams)
+            if !reflect.DeepEqual(*got, tt.want) {
+                t.Fatalf("params = %+v, want %+v", *got, tt.want)
+            }
+        })
+    }
+}
+
+func TestRouteCalldataRejectsOutOfRange(t *testing.T) {
+    tooLarge := new(big.Int).Lsh(big.NewInt(1), 64)
+    tests := []struct {
+        name      string
+        amount    *big.Int
+        minProfit *big.Int
+    }{
+        {"nil amount", nil, new(big.Int)},
+        {"zero amount", new(big.Int), new(big.Int)},
+        {"amount overflows uint64", tooLarge, new(big.Int)},
+        {"negative min profit", big.NewInt(1), big.NewInt(-1)},
+        {"min profit overflows uint64", big.NewInt(1), tooLarge},
+    }
+    
+    r := &route{abi: arbitrageABI, path: []common.Address{}}
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if _, err := r.calldata(&trade.Opportunity{Amount: tt.amount}, tt.minProfit); err == nil {
+                t.Fatal("calldata succeeded, want an error")
+            }
+        })
+    }
+}
\ No newline at end of file
@@ -0,0 +1,254 @@
+package executor
+
+import (
+    "context"
+    "fmt"
+    "math/big"
+    "sync"
+    "time"
+
+    "github.com/ethereum/go-ethereum"
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/ethereum/go-ethereum/core/types"
+    "github.com/hypercore-suite/arbitrage/config"
+    "github.com/hypercore-suite/arbitrage/ledger"
+    "github.com/hypercore-suite/arbitrage/pricing"
+    "github.com/sirupsen/logrus"
+)
+
+// profitConverter tracks each wallet's realized profit that has not been
+// converted into the settlement token yet. It is nil when conversion is
+// disabled.
+type profitConverter struct {
+    router      common.Address
+    path        []common.Address
+    decimals    int
+    tokenPrice  *big.Int
+    threshold   *big.Int
+    slippageBps uint64
+    gasLimit    uint64
+    
+    mu      sync.Mutex
+    pending map[common.Address]*big.Int
+}
+
+func newProfitConverter(cfg config.ConversionConfig) *profitConverter {
+    if !cfg.Enabled {
+        return nil
+    }
+    return &profitConverter{
+        router:      common.HexToAddress(cfg.Router),
+        path:        []common.Address{common.HexToAddress(cfg.Token), common.HexToAddress(cfg.SettlementToken)},
+        decimals:    cfg.Decimals,
+        tokenPrice:  big.NewInt(cfg.TokenPrice),
+        threshold:   big.NewInt(cfg.Threshold),
+        slippageBps: cfg.SlippageBps,
+        gasLimit:    cfg.GasLimit,
+        pending:     make(map[common.Address]*big.Int),
+    }
+}
+
+// accrue adds a realized profit for wallet and returns its unconverted total
+// with whether it has reached the threshold. Losses are not deducted: they
+// were paid out of capital, not out of the profit token.
+func (c *profitConverter) accrue(wallet common.Address, profit *big.Int) (*big.Int, bool) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    
+    total, ok := c.pending[wallet]
+    if !ok {
+        total = new(big.Int)
+        c.pending[wallet] = total
+    }
+    if profit.Sign() > 0 {
+        total.Add(total, profit)
+    }
+    return new(big.Int).Set(total), total.Cmp(c.threshold) >= 0
+}
+
+// settle deducts converted, in USD, from wallet's unconverted total.
+func (c *profitConverter) settle(wallet common.Address, converted *big.Int) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    
+    total, ok := c.pending[wallet]
+    if !ok {
+        return
+    }
+    total.Sub(total, converted)
+    if total.Sign() < 0 {
+        total.SetInt64(0)
+    }
+}
+
+// tokenAmount converts usd at trade.PriceDecimals into units of the
+// profit token.
+func (c *profitConverter) tokenAmount(usd *big.Int) *big.Int {
+    amount := new(big.Int).Mul(usd, pricing.Pow10(c.decimals))
+    return amount.Quo(amount, c.tokenPrice)
+}
+
+// usdValue converts amount of the profit token into USD at
+// trade.PriceDecimals.
+func (c *profitConverter) usdValue(amount *big.Int) *big.Int {
+    usd := new(big.Int).Mul(amount, c.tokenPrice)
+    return usd.Quo(usd, pricing.Pow10(c.decimals))
+}
+
+// minAmountOut returns quote less slippageBps.
+func minAmountOut(quote *big.Int, slippageBps uint64) *big.Int {
+    out := new(big.Int).Mul(quote, new(big.Int).SetUint64(10000-slippageBps))
+    return out.Quo(out, big.NewInt(10000))
+}
+
+// convertProfit adds profit to w's unconverted total and, once the total
+// reaches the threshold, swaps it into the settlement token. The swap is
+// skipped while its gas would cost at least as much as the profit being
+// converted; the profit stays pending for a later attempt.
+func (e *Executor) convertProfit(ctx context.Context, w *wallet, log *logrus.Entry, profit *big.Int) {
+    c := e.conversion
+    pending, due := c.accrue(w.address, profit)
+    if !due {
+        return
+    }
+    log = log.WithField("pending_profit", pending)
+    
+    amount := c.tokenAmount(pending)
+    balance, err := e.tokenBalance(ctx, c.path[0], w.address)
+    if err != nil {
+        log.WithError(err).Warn("Profit token balance unavailable, postponing conversion")
+        return
+    }
+    if balance.Cmp(amount) < 0 {
+        amount = balance
+    }
+    if amount.Sign() == 0 {
+        return
+    }
+    
+    allowance, err := e.allowance(ctx, w, c.path[0], c.router)
+    if err != nil {
+        log.WithError(err).Warn("Router allowance unavailable, postponing conversion")
+        return
+    }
+    gasLimit := c.gasLimit
+    needsApproval := allowance.Cmp(amount) < 0
+    if needsApproval {
+        gasLimit += approvalGasLimit
+    }
+    
+    gasPrice, err := e.client.SuggestGasPrice(ctx)
+    if err != nil {
+        log.WithError(err).Warn("Gas price unavailable, postponing conversion")
+        return
+    }
+    cost := e.gasCostUSD(new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gasLimit)))
+    if cost.Cmp(c.usdValue(amount)) >= 0 {
+        log.WithField("conversion_cost", cost).Info("Conversion would cost more than it converts, postponing")
+        e.monitor.RecordConversion("skipped")
+        return
+    }
+    
+    quote, err := e.quoteConversion(ctx, amount)
+    if err != nil {
+        log.WithError(err).Warn("Conversion quote failed, postponing conversion")
+        return
+    }
+    minOut := minAmountOut(quote, c.slippageBps)
+    
+    if needsApproval {
+        if err := e.approve(ctx, w, c.path[0], c.router, amount); err != nil {
+            log.WithError(err).Error("Router approval failed, postponing conversion")
+            e.monitor.RecordConversion("failed")
+            return
+        }
+    }
+    
+    receipt, err := e.swap(ctx, w, amount, minOut)
+    if err != nil {
+        log.WithError(err).Error("Profit conversion failed")
+        e.monitor.RecordConversion("failed")
+        return
+    }
+    
+    success := receipt.Status == types.ReceiptStatusSuccessful
+    spent := gasCost(receipt)
+    e.recordConversion(log, receipt.TxHash, success, amount, minOut, spent)
+    if !success {
+        log.WithField("tx_hash", receipt.TxHash.Hex()).Error("Profit conversion reverted")
+        e.monitor.RecordConversion("failed")
+        return
+    }
+    
+    c.settle(w.address, c.usdValue(amount))
+    log.WithFields(logrus.Fields{
+        "tx_hash":        receipt.TxHash.Hex(),
+        "amount_in":      amount,
+        "min_amount_out": minOut,
+    }).Info("Profit converted")
+    e.monitor.RecordConversion("converted")
+}
+
+// quoteConversion asks the router how much settlement token amount buys.
+func (e *Executor) quoteConversion(ctx context.Context, amount *big.Int) (*big.Int, error) {
+    c := e.conversion
+    data, err := routerABI.Pack("getAmountsOut", amount, c.path)
+    if err != nil {
+        return nil, err
+    }
+    
+    result, err := e.client.CallContract(ctx, ethereum.CallMsg{To: &c.router, Data: data}, nil)
+    if err != nil {
+        return nil, fmt.Errorf("fetch conversion quote: %w", err)
+    }
+    
+    out, err := routerABI.Unpack("getAmountsOut", result)
+    if err != nil {
+        return nil, fmt.Errorf("decode conversion quote: %w", err)
+    }
+    amounts := out[0].([]*big.Int)
+    if len(amounts) != len(c.path) {
+        return nil, fmt.Errorf("conversion quote has %d amounts, want %d", len(amounts), len(c.path))
+    }
+    return amounts[len(amounts)-1], nil
+}
+
+// swap sends amount of the profit token through the router to w and waits
+// for the receipt.
+func (e *Executor) swap(ctx context.Context, w *wallet, amount, minOut *big.Int) (*types.Receipt, error) {
+    c := e.conversion
+    deadline := big.NewInt(time.Now().Add(e.receiptTimeout).Unix())
+    data, err := routerABI.Pack("swapExactTokensForTokens", amount, minOut, c.path, w.address, deadline)
+    if err != nil {
+        return nil, err
+    }
+    
+    tx, err := e.broadcast(ctx, w, c.router, c.gasLimit, data, nil, false)
+    if err != nil {
+        return nil, err
+    }
+    return e.waitForReceipt(ctx, w, tx)
+}
+
+// recordConversion appends a conversion to the trade ledger, when one is
+// configured, with its gas charged against net profit.
+func (e *Executor) recordConversion(log *logrus.Entry, txHash common.Hash, success bool, amountIn, minOut, gasCostWei *big.Int) {
+    if e.ledger == nil {
+        return
+    }
+    
+    err := e.ledger.Append(ledger.Entry{
+        Time:         time.Now(),
+        Kind:         ledger.KindConversion,
+        TxHash:       txHash.Hex(),
+        Success:      success,
+        GrossProfit:  big.NewInt(0),
+        GasCostWei:   gasCostWei,
+        NetProfit:    new(big.Int).Neg(weiToUSD(gasCostWei, e.nativeTokenPrice(), pricing.RoundHalfUp)),
+        AmountIn:     amountIn,
+        MinAmountOut: minOut,
+    })
+    if err != nil {
+        log.WithError(err).WithField("tx_hash", txHash.Hex()).Error("Failed to record conversion in ledger")
+    }
+}
\ No newline at end of file
@@ -0,0 +1,75 @@
+package executor
+
+import (
+    "context"
+    "math/big"
+    "testing"
+
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/hypercore-suite/arbitrage/config"
+)
+
+func TestProfitConverterThreshold(t *testing.T) {
+    wallet := common.HexToAddress("0x0000000000000000000000000000000000000001")
+    c := newProfitConverter(config.ConversionConfig{Enabled: true, Decimals: 6, TokenPrice: 100000000, Threshold: 1000})
+    
+    steps := []struct {
+        profit    int64
+        wantTotal int64
+        wantDue   bool
+    }{
+        {400, 400, false},
+        {-300, 400, false}, // losses are not deducted
+        {599, 999, false},
+        {1, 1000, true},
+        {500, 1500, true},
+    }
+    for i, step := range steps {
+        total, due := c.accrue(wallet, big.NewInt(step.profit))
+        if total.Int64() != step.wantTotal || due != step.wantDue {
+            t.Fatalf("step %d: accrue(%d) = (%v, %v), want (%d, %v)", i, step.profit, total, due, step.wantTotal, step.wantDue)
+        }
+    }
+    
+    c.settle(wallet, big.NewInt(1200))
+    if total, due := c.accrue(wallet, new(big.Int)); total.Int64() != 300 || due {
+        t.Fatalf("after settling, accrue = (%v, %v), want (300, false)", total, due)
+    }
+    c.settle(wallet, big.NewInt(1000))
+    if total, _ := c.accrue(wallet, new(big.Int)); total.Sign() != 0 {
+        t.Fatalf("settling more than pending left %v", total)
+    }
+}
+
+func TestConvertProfitWaitsForThreshold(t *testing.T) {
+    node := newStubNode(t)
+    e, _ := newTestExecutor(t, node, func(cfg *config.Config) {
+        cfg.Executor.NativeTokenPrice = 2000000000
+        cfg.Executor.Conversion.Enabled = true
+        cfg.Executor.Conversion.Router = "0x0000000000000000000000000000000000000a11"
+        cfg.Executor.Conversion.Token = "0x00000000000000000000000000000000000070c3"
+        cfg.Executor.Conversion.SettlementToken = "0x00000000000000000000000000000000000070c4"
+        cfg.Executor.Conversion.Threshold = 1000000000
+    })
+    w := e.wallets.wallets[0]
+    log := e.logger.WithField("test", t.Name())
+    
+    // Below the $10 threshold nothing is looked up on-chain.
+    e.convertProfit(context.Background(), w, log, big.NewInt(600000000))
+    if got := node.count("eth_call"); got != 0 {
+        t.Fatalf("%d calls below the threshold, want none", got)
+    }
+    
+    // Reaching it starts a conversion. The node answers no calls, so the
+    // balance lookup fails and the profit stays pending.
+    e.convertProfit(context.Background(), w, log, big.NewInt(400000000))
+    if got := node.count("eth_call"); got != 1 {
+        t.Fatalf("%d calls at the threshold, want the balance lookup", got)
+    }
+    if len(node.transactions()) != 0 {
+        t.Fatal("conversion went ahead without a balance")
+    }
+    if total, _ := e.conversion.accrue(w.address, new(big.Int)); total.Int64() != 1000000000 {
+        t.Fatalf("pending profit = %v after a postponed conversion, want 1000000000", total)
+    }
+}
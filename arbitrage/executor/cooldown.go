@@ -0,0 +1,51 @@
+package executor
+
+import (
+    "sync"
+    "time"
+)
+
+// assetCooldowns holds back an asset for a while after a failed execution,
+// since trading it again at once is likely to fail the same way. Other
+// assets keep trading; a success ends the asset's cooldown early and a zero
+// duration disables cooldowns.
+type assetCooldowns struct {
+    duration time.Duration
+    
+    mu    sync.Mutex
+    until map[uint32]time.Time
+}
+
+func newAssetCooldowns(duration time.Duration) *assetCooldowns {
+    return &assetCooldowns{
+        duration: duration,
+        until:    make(map[uint32]time.Time),
+    }
+}
+
+// remaining returns how long asset is still cooling down at now, or zero.
+func (c *assetCooldowns) remaining(asset uint32, now time.Time) time.Duration {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    
+    until, ok := c.until[asset]
+    if !ok || !now.Before(until) {
+        return 0
+    }
+    return until.Sub(now)
+}
+
+// record updates asset's cooldown after an execution at now and returns
+// when the cooldown ends, the zero time when there is none.
+func (c *assetCooldowns) record(asset uint32, success bool, now time.Time) time.Time {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    
+    if success || c.duration == 0 {
+        delete(c.until, asset)
+        return time.Time{}
+    }
+    until := now.Add(c.duration)
+    c.until[asset] = until
+    return until
+}
\ No newline at end of file
@@ -0,0 +1,48 @@
+package executor
+
+import (
+    "testing"
+    "time"
+)
+
+func TestAssetCooldowns(t *testing.T) {
+    now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+    c := newAssetCooldowns(30 * time.Second)
+    
+    if until := c.record(0, false, now); !until.Equal(now.Add(30 * time.Second)) {
+        t.Fatalf("failure cools down until %v, want 30s on", until)
+    }
+    
+    steps := []struct {
+        name  string
+        asset uint32
+        after time.Duration
+        want  time.Duration
+    }{
+        {"skipped right after the failure", 0, 0, 30 * time.Second},
+        {"still skipped", 0, 10 * time.Second, 20 * time.Second},
+        {"other assets trade", 1, 10 * time.Second, 0},
+        {"allowed once the cooldown ends", 0, 30 * time.Second, 0},
+        {"allowed after it", 0, time.Minute, 0},
+    }
+    for _, step := range steps {
+        if got := c.remaining(step.asset, now.Add(step.after)); got != step.want {
+            t.Errorf("%s: remaining = %v, want %v", step.name, got, step.want)
+        }
+    }
+    
+    // A success ends the cooldown early.
+    c.record(0, false, now)
+    if until := c.record(0, true, now.Add(time.Second)); !until.IsZero() {
+        t.Fatalf("success cools down until %v", until)
+    }
+    if got := c.remaining(0, now.Add(2*time.Second)); got != 0 {
+        t.Fatalf("remaining after a success = %v, want 0", got)
+    }
+    
+    disabled := newAssetCooldowns(0)
+    disabled.record(0, false, now)
+    if got := disabled.remaining(0, now); got != 0 {
+        t.Fatalf("zero duration cooled down for %v", got)
+    }
+}
@@ -0,0 +1,157 @@
+package executor
+
+import (
+    "math/big"
+    "sync"
+    "time"
+
+    "github.com/hypercore-suite/arbitrage/trade"
+    "github.com/sirupsen/logrus"
+)
+
+// dailyLimits accumulates realized PnL and traded notional for the current
+// UTC day. Once either cap is reached trading stops until the day rolls
+// over, when the totals reset. Executions in flight hold their notional
+// against the volume cap until they settle, so concurrent executions cannot
+// together overshoot it.
+type dailyLimits struct {
+    mu sync.Mutex
+    
+    maxLoss   *big.Int
+    maxVolume *big.Int
+    
+    day      time.Time
+    pnl      *big.Int
+    volume   *big.Int
+    reserved *big.Int
+}
+
+func newDailyLimits(maxLoss, maxVolume int64) *dailyLimits {
+    return &dailyLimits{
+        maxLoss:   big.NewInt(maxLoss),
+        maxVolume: big.NewInt(maxVolume),
+        pnl:       new(big.Int),
+        volume:    new(big.Int),
+        reserved:  new(big.Int),
+    }
+}
+
+// rollover resets the totals when now falls on a later UTC day. Reservations
+// carry over, as their executions settle into the new day. Callers must hold
+// the mutex.
+func (d *dailyLimits) rollover(now time.Time) {
+    day := now.UTC().Truncate(24 * time.Hour)
+    if day.Equal(d.day) {
+        return
+    }
+    d.day = day
+    d.pnl.SetInt64(0)
+    d.volume.SetInt64(0)
+}
+
+// committed returns the day's settled volume plus the notional reserved by
+// executions in flight. Callers must hold the mutex.
+func (d *dailyLimits) committed() *big.Int {
+    return new(big.Int).Add(d.volume, d.reserved)
+}
+
+// reached reports whether a daily cap is used up: the day's loss has reached
+// the loss cap, or settled and in-flight volume has reached the volume cap.
+// Callers must hold the mutex.
+func (d *dailyLimits) reached() bool {
+    if d.maxLoss.Sign() > 0 && new(big.Int).Neg(d.pnl).Cmp(d.maxLoss) >= 0 {
+        return true
+    }
+    return d.maxVolume.Sign() > 0 && d.committed().Cmp(d.maxVolume) >= 0
+}
+
+// fits reports whether notional more volume stays within the volume cap.
+// Callers must hold the mutex.
+func (d *dailyLimits) fits(notional *big.Int) bool {
+    if d.maxVolume.Sign() == 0 {
+        return true
+    }
+    total := d.committed()
+    return total.Add(total, notional).Cmp(d.maxVolume) <= 0
+}
+
+// halted reports whether a daily cap is reached, halting trading for the rest
+// of the day.
+func (d *dailyLimits) halted(now time.Time) bool {
+    d.mu.Lock()
+    defer d.mu.Unlock()
+    d.rollover(now)
+    return d.reached()
+}
+
+// allows reports whether a trade of notional fits under the day's caps.
+func (d *dailyLimits) allows(notional *big.Int, now time.Time) bool {
+    d.mu.Lock()
+    defer d.mu.Unlock()
+    d.rollover(now)
+    return !d.reached() && d.fits(notional)
+}
+
+// reserve holds notional against the volume cap for an execution about to be
+// sent, or reports false if the caps no longer allow it. record releases the
+// reservation.
+func (d *dailyLimits) reserve(notional *big.Int, now time.Time) bool {
+    d.mu.Lock()
+    defer d.mu.Unlock()
+    d.rollover(now)
+    
+    if d.reached() || !d.fits(notional) {
+        return false
+    }
+    d.reserved.Add(d.reserved, notional)
+    return true
+}
+
+// record settles an execution that reserved notional, adding its realized
+// pnl and, if it traded, its notional.
+func (d *dailyLimits) record(pnl, notional *big.Int, traded bool, now time.Time) {
+    d.mu.Lock()
+    defer d.mu.Unlock()
+    d.rollover(now)
+    
+    d.reserved.Sub(d.reserved, notional)
+    d.pnl.Add(d.pnl, pnl)
+    if traded {
+        d.volume.Add(d.volume, notional)
+    }
+}
+
+// notional returns the USD value of opp's spot leg at trade.PriceDecimals,
+// rounded up since it is charged against the daily volume cap.
+func (e *Executor) notional(opp *trade.Opportunity) *big.Int {
+    spot, _ := e.legs(opp, nil)
+    return spot.notional(opp.Amount)
+}
+
+// checkDailyLimits rejects opp while a daily loss or volume cap is reached,
+// logging and flagging the transition so the halt is visible. Below the caps,
+// it also rejects opp if trading it would take the day's volume past its cap;
+// smaller opportunities may still fit.
+func (e *Executor) checkDailyLimits(log *logrus.Entry, opp *trade.Opportunity, now time.Time) Rejection {
+    halted := e.daily.halted(now)
+    if halted != e.dailyCapped.Swap(halted) {
+        e.monitor.RecordDailyCap(halted)
+        if halted {
+            log.WithFields(logrus.Fields{
+                "daily_max_loss":   e.daily.maxLoss,
+                "daily_max_volume": e.daily.maxVolume,
+            }).Error("Daily loss or volume cap reached, halting executions until UTC midnight")
+        } else {
+            log.Warn("Daily limits reset, resuming executions")
+        }
+    }
+    
+    if halted {
+        return RejectDailyCap
+    }
+    if notional := e.notional(opp); !e.daily.allows(notional, now) {
+        log.WithField("notional", notional).Debug("Trade would exceed the daily volume cap, skipping opportunity")
+        return RejectDailyCap
+    }
+    return ""
+}
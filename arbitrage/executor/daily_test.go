@@ -0,0 +1,111 @@
+package executor
+
+import (
+    "context"
+    "math/big"
+    "testing"
+    "time"
+
+    "github.com/hypercore-suite/arbitrage/config"
+)
+
+func TestDailyLimitsVolumeCap(t *testing.T) {
+    now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+    d := newDailyLimits(0, 250)
+    
+    for _, notional := range []int64{100, 100} {
+        if !d.reserve(big.NewInt(notional), now) {
+            t.Fatalf("reserve(%d) refused under the cap", notional)
+        }
+    }
+    if d.allows(big.NewInt(100), now) {
+        t.Error("allows a trade taking in-flight volume past the cap")
+    }
+    if d.halted(now) {
+        t.Error("halted while 50 of the cap is left")
+    }
+    if !d.allows(big.NewInt(50), now) || !d.reserve(big.NewInt(50), now) {
+        t.Fatal("refused a trade filling the cap exactly")
+    }
+    if !d.halted(now) {
+        t.Error("not halted with the cap fully reserved")
+    }
+    
+    // A failed execution frees its reservation; a traded one keeps its
+    // notional as volume.
+    d.record(big.NewInt(-5), big.NewInt(100), false, now)
+    if d.halted(now) || !d.allows(big.NewInt(100), now) {
+        t.Error("failed execution still holds its notional")
+    }
+    d.record(big.NewInt(10), big.NewInt(100), true, now)
+    if d.allows(big.NewInt(101), now) {
+        t.Error("traded volume released with its reservation")
+    }
+    
+    // The next day's volume starts over; the execution still in flight keeps
+    // its reservation.
+    tomorrow := now.Add(24 * time.Hour)
+    if d.allows(big.NewInt(201), tomorrow) || !d.allows(big.NewInt(200), tomorrow) {
+        t.Error("rollover did not reset volume to the in-flight reservation")
+    }
+}
+
+func TestDailyLimitsLossCap(t *testing.T) {
+    now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+    d := newDailyLimits(100, 0)
+    
+    d.reserve(big.NewInt(1000), now)
+    d.record(big.NewInt(-100), big.NewInt(1000), true, now)
+    if !d.halted(now) || d.allows(big.NewInt(1), now) {
+        t.Error("trading allowed with the loss cap reached")
+    }
+    if d.halted(now.Add(24 * time.Hour)) {
+        t.Error("loss cap still reached the next day")
+    }
+}
+
+func TestExecuteCrossingDailyVolumeCap(t *testing.T) {
+    // The cap fits one $100 trade and half of another.
+    node := newStubNode(t)
+    node.reportProfit(40000000)
+    e, monitor := newTestExecutor(t, node, func(cfg *config.Config) {
+        cfg.Executor.WarmUp = 0
+        cfg.Executor.NativeTokenPrice = 2000000000
+        cfg.Breaker.DailyMaxVolume = 15000000000
+    })
+    w := e.wallets.wallets[0]
+    
+    e.execute(context.Background(), w, testOpportunity())
+    if len(node.transactions()) != 1 {
+        t.Fatalf("sent %d transactions under the cap, want 1", len(node.transactions()))
+    }
+    
+    second := testOpportunity()
+    second.ID = "opp-2"
+    e.execute(context.Background(), w, second)
+    if got := monitor.rejected(RejectDailyCap); got != 1 {
+        t.Fatalf("rejected for the daily cap %d times, want once", got)
+    }
+    if e.dailyCapped.Load() {
+        t.Error("a trade that would cross the cap halted trading")
+    }
+    
+    half := testOpportunity()
+    half.ID = "opp-3"
+    half.Amount = big.NewInt(50000000)
+    e.execute(context.Background(), w, half)
+    if len(node.transactions()) != 2 {
+        t.Fatalf("sent %d transactions, want the half-size trade filling the cap", len(node.transactions()))
+    }
+    
+    last := testOpportunity()
+    last.ID = "opp-4"
+    last.Amount = big.NewInt(50000000)
+    e.execute(context.Background(), w, last)
+    if !e.dailyCapped.Load() {
+        t.Error("trading not halted with the cap used up")
+    }
+    if got := monitor.rejected(RejectDailyCap); got != 2 {
+        t.Errorf("rejected for the daily cap %d times, want twice", got)
+    }
+}
@@ -0,0 +1,104 @@
+package executor
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "strings"
+
+    "github.com/ethereum/go-ethereum/rpc"
+    "github.com/hypercore-suite/arbitrage/trade"
+)
+
+// Failure classes wrapped into the errors of the execution path, so callers
+// can tell them apart with errors.Is.
+var (
+    // ErrRPCUnavailable is the shared class for a node that could not be
+    // reached or did not answer in time, so one check covers detection and
+    // execution.
+    ErrRPCUnavailable = trade.ErrRPCUnavailable
+    // ErrInsufficientBalance means the wallet cannot pay for the trade or
+    // its gas.
+    ErrInsufficientBalance = errors.New("insufficient balance")
+    // ErrSimulationReverted means the node ran the call and it reverted.
+    ErrSimulationReverted = errors.New("simulation reverted")
+    // ErrNonceConflict means the node rejected the transaction's nonce; it
+    // clears once the nonce is resynced.
+    ErrNonceConflict = errors.New("nonce conflict")
+)
+
+// classifyCallError wraps an error from a read-only call: a revert reported
+// by the node is ErrSimulationReverted, anything else ErrRPCUnavailable.
+func classifyCallError(err error) error {
+    if err == nil || errors.Is(err, ErrRPCUnavailable) {
+        return err
+    }
+    if isRevert(err) {
+        return fmt.Errorf("%w: %w", ErrSimulationReverted, err)
+    }
+    return fmt.Errorf("%w: %w", ErrRPCUnavailable, err)
+}
+
+// classifySendError wraps an error from submitting a transaction. Errors the
+// node answered with are classified by message; any other error means the
+// node could not be reached.
+func classifySendError(err error) error {
+    if err == nil || errors.Is(err, ErrRPCUnavailable) {
+        return err
+    }
+    
+    var rpcErr rpc.Error
+    if !errors.As(err, &rpcErr) {
+        return fmt.Errorf("%w: %w", ErrRPCUnavailable, err)
+    }
+    
+    msg := strings.ToLower(rpcErr.Error())
+    switch {
+    case strings.Contains(msg, "nonce too low"), strings.Contains(msg, "nonce too high"),
+        strings.Contains(msg, "already known"), strings.Contains(msg, "replacement transaction underpriced"):
+        return fmt.Errorf("%w: %w", ErrNonceConflict, err)
+    case strings.Contains(msg, "pool is full"), strings.Contains(msg, "mempool full"):
+        // The node is up but cannot take transactions right now.
+        return fmt.Errorf("%w: %w", ErrRPCUnavailable, err)
+    case strings.Contains(msg, "insufficient funds"):
+        return fmt.Errorf("%w: %w", ErrInsufficientBalance, err)
+    case strings.Contains(msg, "revert"):
+        return fmt.Errorf("%w: %w", ErrSimulationReverted, err)
+    }
+    return err
+}
+
+// isRevert reports whether err is the node reporting that a call reverted.
+func isRevert(err error) bool {
+    if revertData(err) != nil {
+        return true
+    }
+    var rpcErr rpc.Error
+    return errors.As(err, &rpcErr) && strings.Contains(strings.ToLower(rpcErr.Error()), "revert")
+}
+
+// errorClass labels err by failure class for metrics and logs.
+func errorClass(err error) string {
+    switch {
+    case errors.Is(err, context.Canceled):
+        return "cancelled"
+    case errors.Is(err, ErrSimulationReverted):
+        return "simulation_reverted"
+    case errors.Is(err, ErrInsufficientBalance):
+        return "insufficient_balance"
+    case errors.Is(err, ErrNonceConflict):
+        return "nonce_conflict"
+    case errors.Is(err, ErrRPCUnavailable):
+        return "rpc_unavailable"
+    case errors.Is(err, context.DeadlineExceeded):
+        return "timeout"
+    default:
+        return "other"
+    }
+}
+
+// transient reports whether retrying the operation that failed with err may
+// succeed. Reverts and missing funds are permanent until state changes.
+func transient(err error) bool {
+    return errors.Is(err, ErrRPCUnavailable) || errors.Is(err, ErrNonceConflict) || errors.Is(err, context.DeadlineExceeded)
+}
\ No newline at end of file
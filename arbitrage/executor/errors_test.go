@@ -0,0 +1,78 @@
+package executor
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "testing"
+
+    "github.com/ethereum/go-ethereum/rpc"
+    "github.com/hypercore-suite/arbitrage/trade"
+)
+
+// nodeError is an error answered by a JSON-RPC node.
+type nodeError string
+
+func (e nodeError) Error() string  { return string(e) }
+func (e nodeError) ErrorCode() int { return -32000 }
+
+func TestClassifySendError(t *testing.T) {
+    tests := []struct {
+        name          string
+        err           error
+        want          error
+        wantClass     string
+        wantTransient bool
+    }{
+        {"unreachable", errors.New("connection refused"), ErrRPCUnavailable, "rpc_unavailable", true},
+        {"nonce too low", nodeError("nonce too low"), ErrNonceConflict, "nonce_conflict", true},
+        {"underpriced replacement", nodeError("replacement transaction underpriced"), ErrNonceConflict, "nonce_conflict", true},
+        {"mempool full", nodeError("txpool is full"), ErrRPCUnavailable, "rpc_unavailable", true},
+        {"insufficient funds", nodeError("insufficient funds for gas * price + value"), ErrInsufficientBalance, "insufficient_balance", false},
+        {"reverted", nodeError("execution reverted"), ErrSimulationReverted, "simulation_reverted", false},
+        {"timeout", context.DeadlineExceeded, ErrRPCUnavailable, "rpc_unavailable", true},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            // Callers add context on top; the class must survive it.
+            err := fmt.Errorf("wallet 0x01: %w", classifySendError(tt.err))
+            
+            if !errors.Is(err, tt.want) {
+                t.Fatalf("%v is not %v", err, tt.want)
+            }
+            if !errors.Is(err, tt.err) {
+                t.Fatalf("%v lost the node error", err)
+            }
+            if got := errorClass(err); got != tt.wantClass {
+                t.Fatalf("errorClass = %s, want %s", got, tt.wantClass)
+            }
+            if got := transient(err); got != tt.wantTransient {
+                t.Fatalf("transient = %v, want %v", got, tt.wantTransient)
+            }
+        })
+    }
+}
+
+func TestClassifyCallError(t *testing.T) {
+    if classifyCallError(nil) != nil {
+        t.Fatal("nil error classified")
+    }
+    
+    reverted := classifyCallError(nodeError("execution reverted: too little profit"))
+    if !errors.Is(reverted, ErrSimulationReverted) || errors.Is(reverted, ErrRPCUnavailable) {
+        t.Fatalf("revert classified as %v", reverted)
+    }
+    var rpcErr rpc.Error
+    if !errors.As(reverted, &rpcErr) || rpcErr.ErrorCode() != -32000 {
+        t.Fatal("node error not reachable through errors.As")
+    }
+    
+    unavailable := classifyCallError(nodeError("header not found"))
+    if !errors.Is(unavailable, ErrRPCUnavailable) || !errors.Is(unavailable, trade.ErrRPCUnavailable) {
+        t.Fatalf("node failure classified as %v", unavailable)
+    }
+    if again := classifyCallError(unavailable); again != unavailable {
+        t.Fatalf("classified error wrapped twice: %v", again)
+    }
+}
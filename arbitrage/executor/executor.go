@@ -3,134 +3,833 @@ package executor
 import (
     "context"
     "crypto/ecdsa"
+    "errors"
+    "fmt"
     "math/big"
+    "strings"
+    "sync"
+    "sync/atomic"
     "time"
 
+    "github.com/ethereum/go-ethereum"
     "github.com/ethereum/go-ethereum/common"
+    "github.com/ethereum/go-ethereum/core/types"
     "github.com/ethereum/go-ethereum/crypto"
     "github.com/ethereum/go-ethereum/ethclient"
-    "github.com/hypercore-suite/arbitrage/detector"
+    "github.com/ethereum/go-ethereum/rpc"
+    "github.com/hypercore-suite/arbitrage/config"
+    "github.com/hypercore-suite/arbitrage/ledger"
+    "github.com/hypercore-suite/arbitrage/pricing"
+    "github.com/hypercore-suite/arbitrage/thresholds"
+    "github.com/hypercore-suite/arbitrage/trade"
     "github.com/sirupsen/logrus"
 )
 
+// Executor validates, simulates and sends arbitrage transactions. Each
+// execution runs on a wallet taken from the pool, so with several wallets
+// configured that many executions can be in flight at once, up to the
+// configured concurrency limit.
 type Executor struct {
-    logger      *logrus.Logger
-    client      *ethclient.Client
-    privateKey  *ecdsa.PrivateKey
-    monitor     Monitor
+    logger  *logrus.Logger
+    client  *timeoutClient
+    wallets *walletPool
+    slots   executionSlots
+    monitor Monitor
     
-    arbContract common.Address
-    maxGasPrice *big.Int
+    chainID  *big.Int
+    routes   []*route
+    fees     *feeCurve
+    gasQuote atomic.Pointer[big.Int]
+    
+    defaultGasLimit  uint64
+    gasBufferPercent uint64
+    receiptTimeout   time.Duration
+    drainTimeout     time.Duration
+    dryRun           bool
+    warmUp           time.Duration
+    breaker          *circuitBreaker
+    capital          capitalConfig
+    nativePrice      *big.Int
+    native           *nativeOracle
+    spotHalfSpread   uint64
+    perpHalfSpread   uint64
+    slippageBps      uint64
+    lotSizes         map[uint32]lotSize
+    maxExposure      map[uint32]*big.Int
+    
+    MaxOpportunityAge time.Duration
+    
+    // Thresholds supplies the minimum profit and spread, the gas price cap
+    // and the gas/profit guard, read on every use so a change applies from
+    // the next opportunity on.
+    Thresholds *thresholds.Holder
+    
+    // Filter, when set, skips opportunities for disabled assets.
+    Filter AssetFilter
+    // Prices, when set, supplies the native token's oracle price when
+    // executor.native_price_asset is configured.
+    Prices SpotPriceSource
+    
+    ledger *ledger.Ledger
+    relay  *privateRelay
+    
+    replaceAfter       time.Duration
+    replaceBumpPercent uint64
+    replaceMode        string
+    sendRetries        int
+    sendRetryBackoff   time.Duration
+    
+    minGasBalance      *big.Int
+    gasBalanceInterval time.Duration
+    
+    paused      atomic.Bool
+    pauseMu     sync.Mutex
+    pausedBy    map[string]bool
+    killSwitch  string
+    daily       *dailyLimits
+    dailyCapped atomic.Bool
+    replay      *replayGuard
+    cooldowns   *assetCooldowns
+    limiter     *executionLimiter
+    reconcile   *profitReconciler
+    queue       opportunityQueue
+    warming     atomic.Bool
+    conversion  *profitConverter
+    
+    execCtx    context.Context
+    cancelExec context.CancelFunc
+    flightMu   sync.Mutex
+    inFlight   sync.WaitGroup
+    closing    bool
+    pending    map[common.Hash]*trade.Opportunity
 }
 
 type Monitor interface {
     RecordExecution(asset uint32, profit *big.Int, success bool)
+    RecordDryRun(asset uint32, profit *big.Int)
+    RecordBreakerState(tripped bool)
+    RecordRPCHealth(endpoint string, healthy bool)
+    RecordRPCLatency(method string, elapsed time.Duration)
+    RecordSettled(asset uint32, grossProfit, gasCostWei, netProfit *big.Int)
+    RecordWalletBalance(wallet string, balance *big.Int)
+    RecordWalletExecution(wallet string, success bool)
+    RecordPaused(sources []string)
+    RecordRejection(asset uint32, reason string)
+    RecordExecutedAge(age time.Duration, success bool)
+    RecordExposure(wallet string, asset uint32, perp, spot *big.Int)
+    RecordDailyCap(reached bool)
+    RecordConversion(result string)
+    RecordError(stage, class string)
+    RecordPhase(phase string)
+    RecordGasBalance(wallet string, balance *big.Int, low bool)
+    RecordSendRetry(class string)
+    RecordExecutedOpportunity(id string)
+    RecordCooldown(asset uint32, until time.Time)
+    RecordRouteSelected(route string)
+    RecordReconciliation(recorded, actual *big.Int)
+    RecordTradeRate(perMinute float64)
+}
+
+// AssetFilter reports whether an asset is enabled for trading.
+type AssetFilter interface {
+    Enabled(asset uint32) bool
 }
 
-func NewExecutor(logger *logrus.Logger, monitor Monitor) (*Executor, error) {
-    client, err := ethclient.Dial("https://rpc.hyperliquid.xyz/evm")
+// NewExecutor builds an executor from an already validated configuration.
+func NewExecutor(logger *logrus.Logger, monitor Monitor, cfg *config.Config) (*Executor, error) {
+    execCfg := cfg.Executor
+    
+    dialed, err := ethclient.Dial(cfg.RPC.EVMURL)
     if err != nil {
         return nil, err
     }
+    client := &timeoutClient{Client: dialed, timeout: cfg.RPC.CallTimeout}
     
-    // In production, load private key from environment
-    privateKey, err := crypto.HexToECDSA("0000000000000000000000000000000000000000000000000000000000000001")
+    nonceState, err := openNonceStore(execCfg.NonceStatePath, logger)
     if err != nil {
         return nil, err
     }
+    wallets, err := loadWallets(context.Background(), client, nonceState, logger, execCfg)
+    if err != nil {
+        return nil, err
+    }
+    for _, w := range wallets {
+        logger.WithField("address", w.address.Hex()).Info("Executor wallet loaded")
+    }
+    
+    chainID, err := client.ChainID(context.Background())
+    if err != nil {
+        return nil, fmt.Errorf("fetch chain id: %w", err)
+    }
+    
+    if execCfg.DryRun {
+        logger.Warn("Dry run enabled: opportunities are simulated but no transactions are sent")
+    }
+    
+    var native *nativeOracle
+    if execCfg.NativePriceAsset != "" {
+        native = &nativeOracle{asset: execCfg.NativePriceAssetID}
+    } else if execCfg.NativeTokenPrice == 0 {
+        logger.Warn("Native token price not set; gas costs are not deducted from USD profit")
+    }
+    
+    logger.WithFields(logrus.Fields{
+        "min_net_profit":      execCfg.MinNetProfit,
+        "min_spread_bps":      execCfg.MinSpreadBps,
+        "max_opportunity_age": execCfg.MaxOpportunityAge,
+        "max_gas_ratio_bps":   execCfg.MaxGasProfitRatioBps,
+    }).Info("Executor thresholds")
+    
+    var relay *privateRelay
+    if execCfg.RelayURL != "" {
+        relay, err = dialRelay(execCfg.RelayURL, cfg.RPC.CallTimeout)
+        if err != nil {
+            return nil, err
+        }
+        logger.WithField("relay", execCfg.RelayURL).Info("Arbitrage transactions are submitted through the private relay")
+    }
+    
+    var trades *ledger.Ledger
+    if execCfg.LedgerPath != "" {
+        trades, err = ledger.Open(execCfg.LedgerPath)
+        if err != nil {
+            return nil, err
+        }
+    }
+    
+    execCtx, cancelExec := context.WithCancel(context.Background())
     
     return &Executor{
-        logger:      logger,
-        client:      client,
-        privateKey:  privateKey,
-        monitor:     monitor,
-        arbContract: common.HexToAddress("0x0000000000000000000000000000000000000000"),
-        maxGasPrice: big.NewInt(100000000000),
+        logger:  logger,
+        client:  client,
+        wallets: newWalletPool(wallets),
+        slots:   newExecutionSlots(execCfg.MaxConcurrentExecutions, len(wallets)),
+        monitor: monitor,
+        chainID: chainID,
+        routes:  newRoutes(execCfg),
+        fees:    newFeeCurve(execCfg.PriorityFeeBase, execCfg.PriorityFeePerDollar, execCfg.PriorityFeeMax, execCfg.MaxOpportunityAge),
+        
+        defaultGasLimit:  execCfg.DefaultGasLimit,
+        gasBufferPercent: execCfg.GasBufferPercent,
+        receiptTimeout:   execCfg.ReceiptTimeout,
+        drainTimeout:     cfg.DrainTimeout,
+        dryRun:           execCfg.DryRun,
+        warmUp:           execCfg.WarmUp,
+        breaker:          newCircuitBreaker(cfg.Breaker.MaxFailures, big.NewInt(cfg.Breaker.MaxLoss), cfg.Breaker.Window, cfg.Breaker.Cooldown),
+        daily:            newDailyLimits(cfg.Breaker.DailyMaxLoss, cfg.Breaker.DailyMaxVolume),
+        replay:           newReplayGuard(execCfg.ReplayWindow),
+        cooldowns:        newAssetCooldowns(execCfg.FailureCooldown),
+        limiter:          newExecutionLimiter(execCfg.MinExecutionInterval),
+        reconcile:        newProfitReconciler(execCfg.ReconcileInterval, execCfg.ReconcileTolerance),
+        conversion:       newProfitConverter(execCfg.Conversion),
+        capital:          newCapitalConfig(execCfg.Capital),
+        nativePrice:      big.NewInt(execCfg.NativeTokenPrice),
+        native:           native,
+        spotHalfSpread:   execCfg.SpotHalfSpreadBps,
+        perpHalfSpread:   execCfg.PerpHalfSpreadBps,
+        slippageBps:      execCfg.SlippageToleranceBps,
+        lotSizes:         newLotSizes(execCfg.LotSizes, cfg.Registry()),
+        maxExposure:      exposureLimits(execCfg.MaxExposure),
+        
+        MaxOpportunityAge: execCfg.MaxOpportunityAge,
+        Thresholds:        thresholds.New(thresholds.FromConfig(cfg)),
+        
+        ledger: trades,
+        relay:  relay,
+        
+        replaceAfter:       execCfg.ReplaceAfter,
+        replaceBumpPercent: execCfg.ReplaceBumpPercent,
+        replaceMode:        execCfg.ReplaceMode,
+        sendRetries:        execCfg.SendRetries,
+        sendRetryBackoff:   execCfg.SendRetryBackoff,
+        killSwitch:         execCfg.KillSwitchPath,
+        
+        minGasBalance:      new(big.Int).SetUint64(execCfg.MinGasBalance),
+        gasBalanceInterval: execCfg.GasBalanceInterval,
+        
+        execCtx:    execCtx,
+        cancelExec: cancelExec,
+        pending:    make(map[common.Hash]*trade.Opportunity),
     }, nil
 }
 
-func (e *Executor) Start(ctx context.Context, opportunities <-chan *detector.Opportunity) {
+// loadPrivateKey parses a hex-encoded private key, with or without a 0x
+// prefix.
+func loadPrivateKey(raw string) (*ecdsa.PrivateKey, error) {
+    privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(strings.TrimSpace(raw), "0x"))
+    if err != nil {
+        return nil, fmt.Errorf("invalid executor private key: %w", err)
+    }
+    
+    return privateKey, nil
+}
+
+// Addresses returns the address of every executor wallet, so the detector
+// can tell our own pending transactions from competitors'.
+func (e *Executor) Addresses() []common.Address {
+    addresses := make([]common.Address, 0, len(e.wallets.wallets))
+    for _, w := range e.wallets.wallets {
+        addresses = append(addresses, w.address)
+    }
+    return addresses
+}
+
+// Start executes opportunities as they arrive. Opportunities are only taken
+// from the channel once an execution slot is free and a wallet is idle, and
+// the wallet then goes to the most profitable one waiting; each runs in its
+// own goroutine holding that slot and wallet until it settles. Once ctx is
+// cancelled, Start keeps executing what is still queued until opportunities
+// is closed or drainTimeout passes, then returns; Shutdown should only be
+// called after.
+func (e *Executor) Start(ctx context.Context, opportunities <-chan *trade.Opportunity) {
+    e.beginWarmUp(ctx)
+    go e.watchGasBalances(ctx)
+    go e.watchKillSwitch(ctx, e.killSwitch)
+    go e.watchReconciliation(ctx)
+    
+    if !e.consume(ctx, opportunities) {
+        return
+    }
+    e.drain(opportunities)
+}
+
+// consume executes opportunities until ctx is cancelled or the channel is
+// closed with nothing left queued, reporting whether it stopped for ctx.
+func (e *Executor) consume(ctx context.Context, opportunities <-chan *trade.Opportunity) bool {
     for {
-        select {
-        case <-ctx.Done():
-            return
-        case opp := <-opportunities:
-            if opp == nil {
-                continue
-            }
-            
-            e.execute(ctx, opp)
+        if err := e.slots.acquire(ctx); err != nil {
+            return true
+        }
+        w, err := e.wallets.acquire(ctx)
+        if err != nil {
+            e.slots.release()
+            return true
         }
+        
+        opp, err := e.nextOpportunity(ctx, opportunities)
+        if err != nil {
+            e.wallets.release(w)
+            e.slots.release()
+            return !errors.Is(err, errQueueClosed)
+        }
+        if !e.track() {
+            e.wallets.release(w)
+            e.slots.release()
+            return false
+        }
+        
+        // Executions run on a context that outlives ctx so a broadcast
+        // transaction is followed to its receipt during shutdown. The slot is
+        // released last, after the wallet is back in the pool.
+        go func() {
+            defer e.inFlight.Done()
+            defer e.slots.release()
+            defer e.wallets.release(w)
+            e.execute(e.execCtx, w, opp)
+        }()
     }
 }
 
-func (e *Executor) execute(ctx context.Context, opp *detector.Opportunity) {
+func (e *Executor) execute(ctx context.Context, w *wallet, opp *trade.Opportunity) {
     start := time.Now()
+    log := e.logger.WithFields(opp.LogFields()).WithField("wallet", w.address.Hex())
     
-    if !e.validateOpportunity(opp) {
-        e.logger.Debug("Opportunity validation failed")
+    if e.paused.Load() {
+        log.Debug("Trading paused, skipping opportunity")
+        e.reject(opp, RejectPaused)
+        return
+    }
+    if e.Filter != nil && !e.Filter.Enabled(opp.Asset) {
+        log.Debug("Asset disabled, skipping opportunity")
+        e.reject(opp, RejectAssetDisabled)
+        return
+    }
+    e.monitor.RecordTradeRate(e.limiter.rate(start))
+    if remaining := e.limiter.remaining(start); remaining > 0 {
+        log.WithField("limit_remaining", remaining).Debug("Minimum execution interval not reached, skipping opportunity")
+        e.reject(opp, RejectRateLimited)
+        return
+    }
+    if remaining := e.cooldowns.remaining(opp.Asset, start); remaining > 0 {
+        log.WithField("cooldown_remaining", remaining).Debug("Asset cooling down after a failure, skipping opportunity")
+        e.reject(opp, RejectCooldown)
+        return
+    }
+    
+    tripped := e.breaker.open(start)
+    e.monitor.RecordBreakerState(tripped)
+    if tripped {
+        log.Info("Circuit breaker open, skipping opportunity")
+        e.reject(opp, RejectBreakerOpen)
+        return
+    }
+    
+    if w.lowGas.Load() && !e.paperTrading() {
+        log.Debug("Wallet low on gas, skipping opportunity")
+        e.reject(opp, RejectGasBalanceLow)
+        return
+    }
+    
+    if reason := e.validateOpportunity(opp, start); reason != "" {
+        log.WithField("reason", reason).Debug("Opportunity validation failed")
+        e.reject(opp, reason)
+        return
+    }
+    
+    sized, reason := e.sizeForBalance(ctx, w, opp)
+    if reason == "" {
+        sized, reason = e.applyLotSize(log, sized)
+    }
+    if reason == "" {
+        reason = e.checkExposure(ctx, log, w, sized)
+    }
+    if reason == "" {
+        reason = e.checkDailyLimits(log, sized, start)
+    }
+    var gasPrice *big.Int
+    if reason == "" {
+        gasPrice = e.gasPrice(ctx, log)
+        reason = e.checkGasRatio(log, sized, gasPrice)
+    }
+    if reason != "" {
+        e.reject(opp, reason)
+        return
+    }
+    opp = sized
+    
+    quote, reason := e.bestRoute(ctx, log, w, opp, gasPrice)
+    if reason != "" {
+        if reason == RejectUnprofitable {
+            log.Debug("Simulation failed or insufficient profit")
+        }
+        e.reject(opp, reason)
+        return
+    }
+    r, expectedProfit, gasLimit, profit := quote.route, quote.expectedProfit, quote.gasLimit, quote.profit
+    log = log.WithField("route", r.name)
+    e.monitor.RecordRouteSelected(r.name)
+    
+    // The replay guard is claimed first, so a duplicate, whether traded live
+    // or on paper, is turned away without using up a rate-limit slot.
+    if !e.replay.claim(opp, time.Now()) {
+        log.Warn("Opportunity already sent, skipping duplicate")
+        e.reject(opp, RejectDuplicate)
+        return
+    }
+    
+    // Opportunities validated concurrently may both have passed the early
+    // check; only the first to get here executes.
+    if !e.limiter.claim(time.Now()) {
+        e.replay.release(opp)
+        log.Debug("Another execution started within the minimum interval, skipping opportunity")
+        e.reject(opp, RejectRateLimited)
+        return
+    }
+    e.monitor.RecordTradeRate(e.limiter.rate(time.Now()))
+    
+    if !e.paperTrading() {
+        if err := e.ensureApprovals(ctx, w, r.contract); err != nil {
+            e.replay.release(opp)
+            log.WithError(err).Error("Token approval failed, skipping opportunity")
+            e.reject(opp, RejectApprovalFailed)
+            return
+        }
+    } else {
+        log.WithFields(logrus.Fields{
+            "direction":       e.direction(opp),
+            "gas_limit":       gasLimit,
+            "expected_profit": expectedProfit,
+            "profit":          profit,
+            "warm_up":         !e.dryRun,
+        }).Info("Dry run: arbitrage not sent")
+        e.monitor.RecordDryRun(opp.Asset, profit)
         return
     }
     
-    profit, success := e.simulateExecution(opp)
-    if !success || profit.Cmp(big.NewInt(1000000)) < 0 {
-        e.logger.Debug("Simulation failed or insufficient profit")
+    // The notional is held against the daily volume cap from here until
+    // recordExecution settles it, whatever the outcome.
+    if !e.daily.reserve(e.notional(opp), time.Now()) {
+        e.replay.release(opp)
+        log.Debug("Daily cap taken up by executions in flight, skipping opportunity")
+        e.reject(opp, RejectDailyCap)
         return
     }
     
-    txHash, err := e.sendTransaction(opp)
+    age := time.Since(opp.Timestamp)
+    tx, err := e.sendTransaction(ctx, w, opp, quote, e.relay != nil)
+    if err != nil {
+        e.recordError(log, "send", err)
+        e.recordExecution(w, opp, age, big.NewInt(0), false)
+        return
+    }
+    
+    receipt, cancelled, err := e.awaitSettlement(ctx, w, log, opp, tx)
     if err != nil {
-        e.logger.WithError(err).Error("Failed to send transaction")
-        e.monitor.RecordExecution(opp.Asset, big.NewInt(0), false)
+        e.recordError(log.WithField("tx_hash", tx.Hash().Hex()), "settle", err)
+        e.recordExecution(w, opp, age, big.NewInt(0), false)
+        return
+    }
+    txHash := receipt.TxHash
+    
+    spent := gasCost(receipt)
+    nativePrice := e.nativeTokenPrice()
+    gasUSD := weiToUSD(spent, nativePrice, pricing.RoundHalfUp)
+    
+    if cancelled || receipt.Status != types.ReceiptStatusSuccessful {
+        loss := new(big.Int).Neg(gasUSD)
+        costs := costBreakdown(receipt, nativePrice, big.NewInt(0), loss)
+        if cancelled {
+            log.WithFields(costs).WithFields(logrus.Fields{
+                "tx_hash":   tx.Hash().Hex(),
+                "cancel_tx": txHash.Hex(),
+            }).Warn("Arbitrage transaction cancelled by replacement")
+        } else {
+            log.WithFields(costs).WithField("tx_hash", txHash.Hex()).Error("Arbitrage transaction reverted")
+            e.logRevertReason(ctx, log, receipt)
+        }
+        
+        e.monitor.RecordSettled(opp.Asset, big.NewInt(0), spent, loss)
+        e.recordLedger(log, opp, txHash, false, big.NewInt(0), spent, loss)
+        e.recordExecution(w, opp, age, loss, false)
         return
     }
     
-    // In production, we would wait for the transaction to be mined
-    // For now, we'll simulate success
+    gross := grossProfit(opp, expectedProfit)
+    realized := new(big.Int).Sub(gross, e.legFees(opp, r))
+    realized.Sub(realized, gasUSD)
+    e.monitor.RecordSettled(opp.Asset, gross, spent, realized)
+    e.recordLedger(log, opp, txHash, true, gross, spent, realized)
     
     executionTime := time.Since(start)
     
-    e.logger.WithFields(logrus.Fields{
-        "asset":          opp.Asset,
-        "tx_hash":        txHash.Hex(),
-        "gas_used":       500000,
-        "profit":         profit,
-        "execution_time": executionTime,
+    log.WithFields(costBreakdown(receipt, nativePrice, gross, realized)).WithFields(logrus.Fields{
+        "direction":       e.direction(opp),
+        "tx_hash":         txHash.Hex(),
+        "expected_profit": expectedProfit,
+        "execution_time":  executionTime,
     }).Info("Arbitrage executed")
     
-    e.monitor.RecordExecution(opp.Asset, profit, true)
+    e.recordExecution(w, opp, age, realized, true)
+    
+    if e.conversion != nil {
+        e.convertProfit(ctx, w, log, realized)
+    }
 }
 
-func (e *Executor) validateOpportunity(opp *detector.Opportunity) bool {
-    age := time.Since(opp.Timestamp)
-    if age > 500*time.Millisecond {
-        return false
+// recordExecution reports a settled execution to the monitor, the circuit
+// breaker, the daily limits, the asset's cooldown and the profit
+// reconciliation, releasing its daily volume reservation. pnl is the
+// realized result; failed executions record zero profit.
+// age is how old the opportunity was when its transaction was sent.
+func (e *Executor) recordExecution(w *wallet, opp *trade.Opportunity, age time.Duration, pnl *big.Int, success bool) {
+    profit := pnl
+    if !success {
+        profit = big.NewInt(0)
+    }
+    e.monitor.RecordExecution(opp.Asset, profit, success)
+    e.monitor.RecordExecutedAge(age, success)
+    
+    if success {
+        e.monitor.RecordExecutedOpportunity(opp.ID)
+    }
+    e.daily.record(pnl, e.notional(opp), success, time.Now())
+    e.reconcile.add(pnl)
+    e.monitor.RecordCooldown(opp.Asset, e.cooldowns.record(opp.Asset, success, time.Now()))
+    e.monitor.RecordWalletExecution(w.address.Hex(), success)
+    
+    if e.breaker.record(success, pnl, time.Now()) {
+        e.logger.WithFields(opp.LogFields()).Error("Circuit breaker tripped, pausing executions")
+        e.monitor.RecordBreakerState(true)
+    }
+}
+
+// recordError logs err with its failure class and counts it against stage.
+// Dry-run failures are expected often and logged at debug.
+func (e *Executor) recordError(log *logrus.Entry, stage string, err error) {
+    class := errorClass(err)
+    log = log.WithError(err).WithFields(logrus.Fields{"stage": stage, "error_class": class})
+    if stage == "dry_run" {
+        log.Debug("Dry run failed")
+    } else {
+        log.Error("Execution failed")
+    }
+    e.monitor.RecordError(stage, class)
+}
+
+// recordLedger appends a settled execution to the trade ledger, when one is
+// configured. A failed write is logged but does not affect the execution.
+func (e *Executor) recordLedger(log *logrus.Entry, opp *trade.Opportunity, txHash common.Hash, success bool, gross, gasCostWei, net *big.Int) {
+    if e.ledger == nil {
+        return
+    }
+    
+    err := e.ledger.Append(ledger.Entry{
+        Time:          time.Now(),
+        Asset:         opp.Asset,
+        OpportunityID: opp.ID,
+        TxHash:        txHash.Hex(),
+        Success:       success,
+        GrossProfit:   gross,
+        GasCostWei:    gasCostWei,
+        NetProfit:     net,
+    })
+    if err != nil {
+        log.WithError(err).WithField("tx_hash", txHash.Hex()).Error("Failed to record trade in ledger")
+    }
+}
+
+// ResetBreaker closes the circuit breaker so trading resumes immediately.
+func (e *Executor) ResetBreaker() {
+    e.breaker.reset()
+    e.monitor.RecordBreakerState(false)
+    e.logger.Warn("Circuit breaker manually reset")
+}
+
+// gasCost returns the fee paid for a mined transaction.
+func gasCost(receipt *types.Receipt) *big.Int {
+    if receipt.EffectiveGasPrice == nil {
+        return new(big.Int)
+    }
+    return new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice)
+}
+
+// validateOpportunity checks opp's kind, age and spread, returning why it is
+// rejected or the zero Rejection when it may proceed. Only perp/spot
+// opportunities can be traded; cycles need path-aware calldata.
+func (e *Executor) validateOpportunity(opp *trade.Opportunity, now time.Time) Rejection {
+    if opp.Kind != trade.KindPerpSpot {
+        return RejectUnsupported
+    }
+    age := now.Sub(opp.Timestamp)
+    if age > e.MaxOpportunityAge {
+        return RejectStale
+    }
+    
+    if opp.SpreadBps < e.Thresholds.Load().ExecutorMinSpreadBps {
+        return RejectSpreadTooSmall
+    }
+    
+    return ""
+}
+
+// estimateGas estimates the gas for executing opp through r, adding
+// gasBufferPercent on top. It falls back to defaultGasLimit when the
+// calldata cannot be built or estimation fails.
+func (e *Executor) estimateGas(ctx context.Context, w *wallet, r *route, opp *trade.Opportunity) uint64 {
+    data, err := r.calldata(opp, new(big.Int))
+    if err != nil {
+        return e.defaultGasLimit
+    }
+    
+    start := time.Now()
+    estimate, err := e.client.EstimateGas(ctx, ethereum.CallMsg{
+        From: w.address,
+        To:   &r.contract,
+        Data: data,
+    })
+    e.monitor.RecordRPCLatency("estimateGas", time.Since(start))
+    if err != nil {
+        e.logger.WithError(err).WithFields(opp.LogFields()).Debug("Gas estimation failed, using default gas limit")
+        return e.defaultGasLimit
     }
     
-    minSpread := big.NewInt(20000000)
-    if opp.Spread.Cmp(minSpread) < 0 {
-        return false
+    return estimate + estimate*e.gasBufferPercent/100
+}
+
+// dryRunCall executes opp through r with eth_call against the pending state
+// and returns the profit the contract reports. An ErrSimulationReverted
+// error means the trade would revert.
+func (e *Executor) dryRunCall(ctx context.Context, w *wallet, r *route, opp *trade.Opportunity) (*big.Int, error) {
+    data, err := r.calldata(opp, new(big.Int))
+    if err != nil {
+        return nil, err
+    }
+    
+    start := time.Now()
+    result, err := e.client.PendingCallContract(ctx, ethereum.CallMsg{
+        From: w.address,
+        To:   &r.contract,
+        Data: data,
+    })
+    e.monitor.RecordRPCLatency("dryRun", time.Since(start))
+    if err != nil {
+        return nil, classifyCallError(err)
     }
     
-    return true
+    return DecodeExecuteArbitrageProfit(result)
+}
+
+// grossProfit returns the expected profit of opp before gas. expectedProfit,
+// when non-nil, is the profit reported by the on-chain dry run and takes
+// precedence over the arithmetic estimate from the funding-adjusted spread,
+// which is rounded down.
+func grossProfit(opp *trade.Opportunity, expectedProfit *big.Int) *big.Int {
+    if expectedProfit != nil {
+        return expectedProfit
+    }
+    return pricing.MulDiv(opp.NetSpread, opp.Amount, pricing.Pow10(trade.PriceDecimals), pricing.RoundDown)
 }
 
-func (e *Executor) simulateExecution(opp *detector.Opportunity) (*big.Int, bool) {
-    estimatedProfit := new(big.Int).Mul(opp.Spread, opp.Amount)
-    estimatedProfit.Div(estimatedProfit, big.NewInt(100000000))
+// simulateExecution returns the net profit of opp through r after both legs'
+// trading fees, the half-spread each leg crosses and gasLimit gas at
+// gasPrice wei, in USD at trade.PriceDecimals.
+func (e *Executor) simulateExecution(opp *trade.Opportunity, r *route, gasLimit uint64, gasPrice, expectedProfit *big.Int) (*big.Int, bool) {
+    estimatedProfit := new(big.Int).Sub(grossProfit(opp, expectedProfit), e.tradingCosts(opp, r))
     
-    gasPrice := big.NewInt(50000000000)
-    gasLimit := uint64(500000)
-    gasCost := new(big.Int).Mul(gasPrice, big.NewInt(int64(gasLimit)))
+    gasCost := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gasLimit))
     
-    netProfit := new(big.Int).Sub(estimatedProfit, gasCost)
+    netProfit := new(big.Int).Sub(estimatedProfit, e.gasCostUSD(gasCost))
     
     return netProfit, netProfit.Sign() > 0
 }
 
-func (e *Executor) sendTransaction(opp *detector.Opportunity) (*common.Hash, error) {
-    // Placeholder for actual transaction sending
-    // In production, this would interact with the smart contract
-    hash := common.Hash{}
-    return &hash, nil
+// gasCostUSD converts a fee in wei to USD at trade.PriceDecimals using the
+// current native token price, rounded up as the cost of a trade not yet made.
+func (e *Executor) gasCostUSD(wei *big.Int) *big.Int {
+    return weiToUSD(wei, e.nativeTokenPrice(), pricing.RoundUp)
+}
+
+// minNetProfit returns the current minimum net profit, in USD at
+// trade.PriceDecimals.
+func (e *Executor) minNetProfit() *big.Int {
+    return big.NewInt(e.Thresholds.Load().MinNetProfit)
+}
+
+// maxGasPrice returns the current cap on the gas price paid, in wei.
+func (e *Executor) maxGasPrice() *big.Int {
+    return new(big.Int).SetUint64(e.Thresholds.Load().MaxGasPrice)
+}
+
+// gasPrice returns the price per gas, in wei, an execution is costed at: the
+// node's suggested gas price, capped at maxGasPrice as buildTx caps the fee
+// it pays. When the lookup fails it is the cap, the most a transaction can
+// pay. The result is kept for lastGasPrice.
+func (e *Executor) gasPrice(ctx context.Context, log *logrus.Entry) *big.Int {
+    price, err := e.client.SuggestGasPrice(ctx)
+    if err != nil {
+        log.WithError(err).Debug("Gas price lookup failed, costing gas at the price cap")
+        return e.maxGasPrice()
+    }
+    if maxGasPrice := e.maxGasPrice(); price.Cmp(maxGasPrice) > 0 {
+        price = maxGasPrice
+    }
+    e.gasQuote.Store(price)
+    return price
+}
+
+// lastGasPrice returns the gas price the last execution was costed at, for
+// estimates made without a chain call, capped at the current maxGasPrice.
+// Before any, and always in backtests, it is the cap.
+func (e *Executor) lastGasPrice() *big.Int {
+    maxGasPrice := e.maxGasPrice()
+    if price := e.gasQuote.Load(); price != nil && price.Cmp(maxGasPrice) < 0 {
+        return price
+    }
+    return maxGasPrice
+}
+
+// sendTransaction broadcasts the arbitrage for opp through the quoted route,
+// reverting on-chain if it would realize less than its minimum profit. The
+// priority fee follows the fee curve for the quoted profit and opp's age,
+// when one is configured. private requests the private relay; it is ignored
+// when no relay is configured.
+func (e *Executor) sendTransaction(ctx context.Context, w *wallet, opp *trade.Opportunity, quote *routeQuote, private bool) (*types.Transaction, error) {
+    data, err := quote.route.calldata(opp, e.minProfit(opp))
+    if err != nil {
+        return nil, err
+    }
+    
+    tip := e.fees.tip(quote.profit, time.Since(opp.Timestamp))
+    return e.broadcast(ctx, w, quote.route.contract, quote.gasLimit, data, tip, private)
+}
+
+// broadcast builds, signs and sends a transaction from w to the given address
+// using the wallet's nonce manager, returning the signed transaction. A nil
+// tip pays the node's suggested priority fee.
+func (e *Executor) broadcast(ctx context.Context, w *wallet, to common.Address, gasLimit uint64, data []byte, tip *big.Int, private bool) (*types.Transaction, error) {
+    tx, err := e.buildTx(ctx, w, to, gasLimit, data, tip)
+    if err != nil {
+        return nil, err
+    }
+    
+    signed, err := types.SignTx(tx, types.LatestSignerForChainID(e.chainID), w.key)
+    if err != nil {
+        return nil, fmt.Errorf("sign transaction: %w", err)
+    }
+    
+    route, err := e.submitWithRetry(ctx, signed, private)
+    if err != nil {
+        if errors.Is(err, ErrNonceConflict) {
+            e.logger.WithError(err).WithField("nonce", signed.Nonce()).Warn("Nonce conflict, resyncing from chain")
+        }
+        // The reserved nonce was not consumed; re-read it so the next
+        // transaction does not leave a gap.
+        if syncErr := w.nonces.resync(ctx); syncErr != nil {
+            e.logger.WithError(syncErr).Error("Failed to resync nonce")
+        }
+        return nil, fmt.Errorf("broadcast transaction: %w", err)
+    }
+    
+    w.nonces.track(signed)
+    
+    e.logger.WithFields(logrus.Fields{
+        "tx_hash": signed.Hash().Hex(),
+        "nonce":   signed.Nonce(),
+        "route":   route,
+    }).Info("Transaction submitted")
+    return signed, nil
+}
+
+// buildTx builds an EIP-1559 transaction to the given address, falling
+// back to a legacy gas-price transaction when the chain reports no base fee.
+// tip replaces the suggested priority fee when set, and is added to the
+// suggested gas price of a legacy transaction. maxGasPrice caps the fee paid
+// per gas in both modes. The nonce is reserved last so a failed fee lookup
+// does not consume one.
+func (e *Executor) buildTx(ctx context.Context, w *wallet, to common.Address, gasLimit uint64, data []byte, tip *big.Int) (*types.Transaction, error) {
+    start := time.Now()
+    header, err := e.client.HeaderByNumber(ctx, big.NewInt(int64(rpc.PendingBlockNumber)))
+    e.monitor.RecordRPCLatency("getPendingHeader", time.Since(start))
+    if err != nil {
+        return nil, fmt.Errorf("fetch pending header: %w", classifyCallError(err))
+    }
+    
+    if header.BaseFee == nil {
+        gasPrice, err := e.client.SuggestGasPrice(ctx)
+        if err != nil {
+            return nil, fmt.Errorf("suggest gas price: %w", classifyCallError(err))
+        }
+        if tip != nil {
+            gasPrice = new(big.Int).Add(gasPrice, tip)
+        }
+        if maxGasPrice := e.maxGasPrice(); gasPrice.Cmp(maxGasPrice) > 0 {
+            gasPrice = maxGasPrice
+        }
+        
+        return types.NewTx(&types.LegacyTx{
+            Nonce:    w.nonces.reserve(),
+            GasPrice: gasPrice,
+            Gas:      gasLimit,
+            To:       &to,
+            Data:     data,
+        }), nil
+    }
+    
+    if tip == nil {
+        if tip, err = e.client.SuggestGasTipCap(ctx); err != nil {
+            return nil, fmt.Errorf("suggest gas tip: %w", classifyCallError(err))
+        }
+    }
+    
+    tipCap, feeCap := dynamicFees(header.BaseFee, tip, e.maxGasPrice())
+    
+    return types.NewTx(&types.DynamicFeeTx{
+        ChainID:   e.chainID,
+        Nonce:     w.nonces.reserve(),
+        GasTipCap: tipCap,
+        GasFeeCap: feeCap,
+        Gas:       gasLimit,
+        To:        &to,
+        Data:      data,
+    }), nil
 }
\ No newline at end of file
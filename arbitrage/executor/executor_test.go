@@ -0,0 +1,313 @@
+package executor
+
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "math/big"
+    "net/http"
+    "net/http/httptest"
+    "sync"
+    "testing"
+    "time"
+
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/ethereum/go-ethereum/common/hexutil"
+    "github.com/ethereum/go-ethereum/core/types"
+    "github.com/hypercore-suite/arbitrage/config"
+    "github.com/hypercore-suite/arbitrage/trade"
+    "github.com/sirupsen/logrus"
+)
+
+// testKey is the key the test executors sign with.
+const testKey = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+
+var (
+    testChainID  = big.NewInt(999)
+    testContract = common.HexToAddress("0x00000000000000000000000000000000000a4b17")
+)
+
+// rpcHandler answers one JSON-RPC method call.
+type rpcHandler func(params []json.RawMessage) (interface{}, error)
+
+// stubNode is a JSON-RPC node answering from per-method handlers. It starts
+// with handlers for a healthy EIP-1559 chain that accepts and mines every
+// transaction; tests replace the ones they exercise.
+type stubNode struct {
+    url string
+    
+    mu       sync.Mutex
+    handlers map[string]rpcHandler
+    calls    map[string]int
+    sent     []*types.Transaction
+}
+
+func newStubNode(t *testing.T) *stubNode {
+    t.Helper()
+    
+    n := &stubNode{calls: make(map[string]int)}
+    n.handlers = map[string]rpcHandler{
+        "eth_chainId": func([]json.RawMessage) (interface{}, error) {
+            return (*hexutil.Big)(testChainID), nil
+        },
+        "eth_getTransactionCount": func([]json.RawMessage) (interface{}, error) {
+            return hexutil.Uint64(0), nil
+        },
+        "eth_getBlockByNumber": func([]json.RawMessage) (interface{}, error) {
+            return &types.Header{Number: big.NewInt(1), Difficulty: new(big.Int), BaseFee: big.NewInt(1000000000)}, nil
+        },
+        "eth_maxPriorityFeePerGas": func([]json.RawMessage) (interface{}, error) {
+            return (*hexutil.Big)(big.NewInt(1000000000)), nil
+        },
+        "eth_gasPrice": func([]json.RawMessage) (interface{}, error) {
+            return (*hexutil.Big)(big.NewInt(2000000000)), nil
+        },
+        "eth_estimateGas": func([]json.RawMessage) (interface{}, error) {
+            return hexutil.Uint64(200000), nil
+        },
+        "eth_getBalance": func([]json.RawMessage) (interface{}, error) {
+            return (*hexutil.Big)(new(big.Int).Exp(big.NewInt(10), big.NewInt(21), nil)), nil
+        },
+        "eth_sendRawTransaction": func(params []json.RawMessage) (interface{}, error) {
+            tx, err := rawTransaction(params)
+            if err != nil {
+                return nil, err
+            }
+            return tx.Hash(), nil
+        },
+        "eth_getTransactionReceipt": func(params []json.RawMessage) (interface{}, error) {
+            var hash common.Hash
+            if err := json.Unmarshal(params[0], &hash); err != nil {
+                return nil, err
+            }
+            return minedReceipt(hash), nil
+        },
+    }
+    
+    server := httptest.NewServer(http.HandlerFunc(n.serve))
+    t.Cleanup(server.Close)
+    n.url = server.URL
+    return n
+}
+
+// handle replaces the handler for method.
+func (n *stubNode) handle(method string, h rpcHandler) {
+    n.mu.Lock()
+    defer n.mu.Unlock()
+    n.handlers[method] = h
+}
+
+// handler returns the current handler for method, so a replacement can
+// wrap it.
+func (n *stubNode) handler(method string) rpcHandler {
+    n.mu.Lock()
+    defer n.mu.Unlock()
+    return n.handlers[method]
+}
+
+// count returns how many times method was called.
+func (n *stubNode) count(method string) int {
+    n.mu.Lock()
+    defer n.mu.Unlock()
+    return n.calls[method]
+}
+
+// transactions returns the transactions the node accepted, in order.
+func (n *stubNode) transactions() []*types.Transaction {
+    n.mu.Lock()
+    defer n.mu.Unlock()
+    return append([]*types.Transaction(nil), n.sent...)
+}
+
+func (n *stubNode) serve(w http.ResponseWriter, r *http.Request) {
+    var req struct {
+        ID     json.RawMessage   `json:"id"`
+        Method string            `json:"method"`
+        Params []json.RawMessage `json:"params"`
+    }
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        http.Error(w, err.Error(), http.StatusBadRequest)
+        return
+    }
+    
+    n.mu.Lock()
+    n.calls[req.Method]++
+    h := n.handlers[req.Method]
+    n.mu.Unlock()
+    
+    var result interface{}
+    err := fmt.Errorf("method %s not stubbed", req.Method)
+    if h != nil {
+        result, err = h(req.Params)
+    }
+    if err == nil && req.Method == "eth_sendRawTransaction" {
+        tx, _ := rawTransaction(req.Params)
+        n.mu.Lock()
+        n.sent = append(n.sent, tx)
+        n.mu.Unlock()
+    }
+    
+    resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+    if err != nil {
+        resp["error"] = map[string]interface{}{"code": -32000, "message": err.Error()}
+    } else {
+        resp["result"] = result
+    }
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(resp)
+}
+
+// rawTransaction decodes the signed transaction of an eth_sendRawTransaction
+// call.
+func rawTransaction(params []json.RawMessage) (*types.Transaction, error) {
+    var raw hexutil.Bytes
+    if err := json.Unmarshal(params[0], &raw); err != nil {
+        return nil, err
+    }
+    tx := new(types.Transaction)
+    if err := tx.UnmarshalBinary(raw); err != nil {
+        return nil, errors.New("invalid raw transaction")
+    }
+    return tx, nil
+}
+
+// minedReceipt is a successful receipt for hash.
+func minedReceipt(hash common.Hash) *types.Receipt {
+    return &types.Receipt{
+        Type:              types.DynamicFeeTxType,
+        Status:            types.ReceiptStatusSuccessful,
+        CumulativeGasUsed: 150000,
+        GasUsed:           150000,
+        EffectiveGasPrice: big.NewInt(2000000000),
+        TxHash:            hash,
+        BlockNumber:       big.NewInt(2),
+        Logs:              []*types.Log{},
+    }
+}
+
+// dryRunResult is the return data of an executeArbitrage dry run reporting
+// profit.
+func dryRunResult(profit uint64) (hexutil.Bytes, error) {
+    return arbitrageABI.Methods["executeArbitrage"].Outputs.Pack(arbitrageResult{
+        Executed:      true,
+        Profit:        profit,
+        GasUsed:       big.NewInt(150000),
+        ExecutionData: []byte{},
+    })
+}
+
+// reportProfit makes every dry run on node report profit.
+func (n *stubNode) reportProfit(profit uint64) {
+    n.handle("eth_call", func([]json.RawMessage) (interface{}, error) {
+        return dryRunResult(profit)
+    })
+}
+
+// testMonitor records what the executor reports.
+type testMonitor struct {
+    mu         sync.Mutex
+    executions []bool
+    dryRuns    int
+    rejections map[string]int
+}
+
+func newTestMonitor() *testMonitor {
+    return &testMonitor{rejections: make(map[string]int)}
+}
+
+func (m *testMonitor) RecordExecution(asset uint32, profit *big.Int, success bool) {
+    m.mu.Lock()
+    defer m.mu.Unlock()
+    m.executions = append(m.executions, success)
+}
+
+func (m *testMonitor) RecordDryRun(asset uint32, profit *big.Int) {
+    m.mu.Lock()
+    defer m.mu.Unlock()
+    m.dryRuns++
+}
+
+func (m *testMonitor) RecordRejection(asset uint32, reason string) {
+    m.mu.Lock()
+    defer m.mu.Unlock()
+    m.rejections[reason]++
+}
+
+// rejected returns how many opportunities were rejected for reason.
+func (m *testMonitor) rejected(reason Rejection) int {
+    m.mu.Lock()
+    defer m.mu.Unlock()
+    return m.rejections[string(reason)]
+}
+
+func (m *testMonitor) RecordBreakerState(tripped bool)                                         {}
+func (m *testMonitor) RecordRPCHealth(endpoint string, healthy bool)                           {}
+func (m *testMonitor) RecordSettled(asset uint32, grossProfit, gasCostWei, netProfit *big.Int) {}
+func (m *testMonitor) RecordWalletBalance(wallet string, balance *big.Int)                     {}
+func (m *testMonitor) RecordWalletExecution(wallet string, success bool)                       {}
+func (m *testMonitor) RecordPaused(sources []string)                                           {}
+func (m *testMonitor) RecordSkipped(asset uint32, reason string)                               {}
+func (m *testMonitor) RecordRPCLatency(method string, elapsed time.Duration)                   {}
+func (m *testMonitor) RecordExecutedAge(age time.Duration, success bool)                       {}
+func (m *testMonitor) RecordExposure(wallet string, asset uint32, perp, spot *big.Int)         {}
+func (m *testMonitor) RecordDailyCap(reached bool)                                             {}
+func (m *testMonitor) RecordConversion(result string)                                          {}
+func (m *testMonitor) RecordError(stage, class string)                                         {}
+func (m *testMonitor) RecordPhase(phase string)                                                {}
+func (m *testMonitor) RecordGasBalance(wallet string, balance *big.Int, low bool)              {}
+func (m *testMonitor) RecordSendRetry(class string)                                            {}
+func (m *testMonitor) RecordExecutedOpportunity(id string)                                     {}
+func (m *testMonitor) RecordCooldown(asset uint32, until time.Time)                            {}
+func (m *testMonitor) RecordRouteSelected(route string)                                        {}
+func (m *testMonitor) RecordReconciliation(recorded, actual *big.Int)                          {}
+func (m *testMonitor) RecordTradeRate(perMinute float64)                                       {}
+
+// quietLogger returns a logger that writes nowhere.
+func quietLogger() *logrus.Logger {
+    logger := logrus.New()
+    logger.SetOutput(io.Discard)
+    return logger
+}
+
+// newTestExecutor builds an executor trading through testContract against
+// node, from the default configuration as adjusted by configure.
+func newTestExecutor(t *testing.T, node *stubNode, configure func(*config.Config)) (*Executor, *testMonitor) {
+    t.Helper()
+    
+    cfg := config.Default()
+    cfg.RPC.EVMURL = node.url
+    cfg.Executor.PrivateKey = testKey
+    cfg.Executor.ArbContract = testContract.Hex()
+    cfg.Executor.NonceStatePath = ""
+    if configure != nil {
+        configure(cfg)
+    }
+    
+    monitor := newTestMonitor()
+    e, err := NewExecutor(quietLogger(), monitor, cfg)
+    if err != nil {
+        t.Fatalf("NewExecutor: %v", err)
+    }
+    t.Cleanup(e.cancelExec)
+    return e, monitor
+}
+
+// testOpportunity is a fresh opportunity buying 1 unit of asset 0 at a 50
+// bps spread on a $100 price.
+func testOpportunity() *trade.Opportunity {
+    return &trade.Opportunity{
+        ID:          "opp-1",
+        Asset:       0,
+        CorePrice:   big.NewInt(10050000000),
+        EVMPrice:    big.NewInt(10000000000),
+        Spread:      big.NewInt(50000000),
+        SpreadBps:   50,
+        FundingRate: new(big.Int),
+        NetSpread:   big.NewInt(50000000),
+        FillPrice:   big.NewInt(10000000000),
+        IsBuy:       true,
+        Amount:      big.NewInt(100000000),
+        Timestamp:   time.Now(),
+    }
+}
\ No newline at end of file
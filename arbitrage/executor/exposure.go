@@ -0,0 +1,98 @@
+package executor
+
+import (
+    "context"
+    "fmt"
+    "math/big"
+
+    "github.com/ethereum/go-ethereum"
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/hypercore-suite/arbitrage/trade"
+    "github.com/sirupsen/logrus"
+)
+
+// HyperCore read precompiles for an account's perp position and spot
+// balance. Both take (address user, uint asset) and report sizes in
+// trade.PriceDecimals fixed point in their first word; the position is
+// signed, negative when short.
+var (
+    positionPrecompile    = common.HexToAddress("0x0000000000000000000000000000000000000800")
+    spotBalancePrecompile = common.HexToAddress("0x0000000000000000000000000000000000000801")
+)
+
+func exposureLimits(cfg map[uint32]int64) map[uint32]*big.Int {
+    limits := make(map[uint32]*big.Int, len(cfg))
+    for asset, limit := range cfg {
+        limits[asset] = big.NewInt(limit)
+    }
+    return limits
+}
+
+// readExposure returns w's current perp position and spot balance in asset.
+func (e *Executor) readExposure(ctx context.Context, w *wallet, asset uint32) (perp, spot *big.Int, err error) {
+    data := append(common.LeftPadBytes(w.address.Bytes(), 32), common.LeftPadBytes(new(big.Int).SetUint64(uint64(asset)).Bytes(), 32)...)
+    
+    perpWord, err := e.readPrecompileWord(ctx, positionPrecompile, data)
+    if err != nil {
+        return nil, nil, fmt.Errorf("read perp position: %w", err)
+    }
+    spotWord, err := e.readPrecompileWord(ctx, spotBalancePrecompile, data)
+    if err != nil {
+        return nil, nil, fmt.Errorf("read spot balance: %w", err)
+    }
+    
+    perp = new(big.Int).SetBytes(perpWord)
+    if perpWord[0]&0x80 != 0 {
+        perp.Sub(perp, new(big.Int).Lsh(big.NewInt(1), 256))
+    }
+    return perp, new(big.Int).SetBytes(spotWord), nil
+}
+
+func (e *Executor) readPrecompileWord(ctx context.Context, to common.Address, data []byte) ([]byte, error) {
+    result, err := e.client.CallContract(ctx, ethereum.CallMsg{To: &to, Data: data}, nil)
+    if err != nil {
+        return nil, err
+    }
+    if len(result) < 32 {
+        return nil, fmt.Errorf("short result of %d bytes", len(result))
+    }
+    return result[:32], nil
+}
+
+// checkExposure rejects opp when executing it would take w's spot balance or
+// perp position in the asset past the configured limit. Buying spot grows
+// the spot balance and shortens the perp; selling does the reverse. Assets
+// without a limit always pass; a failed read fails closed.
+func (e *Executor) checkExposure(ctx context.Context, log *logrus.Entry, w *wallet, opp *trade.Opportunity) Rejection {
+    limit, ok := e.maxExposure[opp.Asset]
+    if !ok {
+        return ""
+    }
+    
+    perp, spot, err := e.readExposure(ctx, w, opp.Asset)
+    e.monitor.RecordRPCHealth("executor", err == nil)
+    if err != nil {
+        log.WithError(err).Warn("Exposure check failed, skipping opportunity")
+        return RejectExposureUnknown
+    }
+    e.monitor.RecordExposure(w.address.Hex(), opp.Asset, perp, spot)
+    
+    nextPerp, nextSpot := new(big.Int).Set(perp), new(big.Int).Set(spot)
+    if opp.IsBuy {
+        nextSpot.Add(nextSpot, opp.Amount)
+        nextPerp.Sub(nextPerp, opp.Amount)
+    } else {
+        nextSpot.Sub(nextSpot, opp.Amount)
+        nextPerp.Add(nextPerp, opp.Amount)
+    }
+    
+    if new(big.Int).Abs(nextPerp).Cmp(limit) > 0 || new(big.Int).Abs(nextSpot).Cmp(limit) > 0 {
+        log.WithFields(logrus.Fields{
+            "perp_position": perp,
+            "spot_balance":  spot,
+            "max_exposure":  limit,
+        }).Info("Trade would exceed exposure limit, skipping opportunity")
+        return RejectExposureLimit
+    }
+    return ""
+}
\ No newline at end of file
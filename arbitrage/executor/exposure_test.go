@@ -0,0 +1,82 @@
+package executor
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "math/big"
+    "testing"
+
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/ethereum/go-ethereum/common/hexutil"
+    "github.com/hypercore-suite/arbitrage/config"
+)
+
+// signedWord is v as a two's complement 32-byte word.
+func signedWord(v int64) hexutil.Bytes {
+    word := big.NewInt(v)
+    if word.Sign() < 0 {
+        word.Add(word, new(big.Int).Lsh(big.NewInt(1), 256))
+    }
+    return word.FillBytes(make([]byte, 32))
+}
+
+// stubExposure makes node's position and spot balance precompiles report
+// perp and spot for every account and asset, or fail with err.
+func (n *stubNode) stubExposure(perp, spot int64, err error) {
+    n.handle("eth_call", func(params []json.RawMessage) (interface{}, error) {
+        var call struct {
+            To common.Address `json:"to"`
+        }
+        if len(params) == 0 || json.Unmarshal(params[0], &call) != nil {
+            return nil, errors.New("invalid call")
+        }
+        switch {
+        case err != nil:
+            return nil, err
+        case call.To == positionPrecompile:
+            return signedWord(perp), nil
+        case call.To == spotBalancePrecompile:
+            return signedWord(spot), nil
+        }
+        return nil, errors.New("unexpected call")
+    })
+}
+
+func TestCheckExposure(t *testing.T) {
+    // Asset 0 is limited to 5 units either way; each trade moves 1 unit.
+    tests := []struct {
+        name       string
+        asset      uint32
+        buy        bool
+        perp, spot int64
+        readErr    error
+        want       Rejection
+    }{
+        {"within the limit", 0, true, -100000000, 100000000, nil, ""},
+        {"up to the limit", 0, true, -400000000, 400000000, nil, ""},
+        {"spot balance at the limit", 0, true, 0, 500000000, nil, RejectExposureLimit},
+        {"short perp at the limit", 0, true, -500000000, 0, nil, RejectExposureLimit},
+        {"selling unwinds a full spot balance", 0, false, -500000000, 500000000, nil, ""},
+        {"long perp at the limit", 0, false, 500000000, 0, nil, RejectExposureLimit},
+        {"read fails closed", 0, true, 0, 0, errors.New("precompile unavailable"), RejectExposureUnknown},
+        {"asset without a limit", 1, true, 0, 900000000, nil, ""},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            node := newStubNode(t)
+            node.stubExposure(tt.perp, tt.spot, tt.readErr)
+            e, _ := newTestExecutor(t, node, func(cfg *config.Config) {
+                cfg.Executor.MaxExposure = map[uint32]int64{0: 500000000}
+            })
+            opp := testOpportunity()
+            opp.Asset, opp.IsBuy = tt.asset, tt.buy
+            
+            log := e.logger.WithField("test", t.Name())
+            if got := e.checkExposure(context.Background(), log, e.wallets.wallets[0], opp); got != tt.want {
+                t.Fatalf("checkExposure = %q, want %q", got, tt.want)
+            }
+        })
+    }
+}
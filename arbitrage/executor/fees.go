@@ -0,0 +1,77 @@
+package executor
+
+import (
+    "math/big"
+    "time"
+
+    "github.com/hypercore-suite/arbitrage/pricing"
+)
+
+// dynamicFees derives EIP-1559 fee caps from the suggested tip and the latest
+// base fee. The fee cap leaves room for the base fee to double before the
+// transaction is priced out, and is clamped to ceiling; the tip never exceeds
+// the fee cap.
+func dynamicFees(baseFee, tip, ceiling *big.Int) (tipCap, feeCap *big.Int) {
+    feeCap = new(big.Int).Mul(baseFee, big.NewInt(2))
+    feeCap.Add(feeCap, tip)
+    if feeCap.Cmp(ceiling) > 0 {
+        feeCap = new(big.Int).Set(ceiling)
+    }
+    
+    tipCap = new(big.Int).Set(tip)
+    if tipCap.Cmp(feeCap) > 0 {
+        tipCap = new(big.Int).Set(feeCap)
+    }
+    
+    return tipCap, feeCap
+}
+
+// feeCurve prices an arbitrage's priority fee from how much it stands to make
+// and how fresh it is, so lucrative new opportunities outbid competitors
+// while marginal or stale ones pay little. Fees are in wei per gas.
+type feeCurve struct {
+    base      *big.Int
+    perDollar *big.Int
+    ceiling   *big.Int
+    horizon   time.Duration
+}
+
+// newFeeCurve returns nil, leaving the node's suggested tip in place, when
+// neither a base tip nor a per-dollar rate is configured. The profit share
+// decays linearly to nothing as an opportunity ages towards horizon; zero
+// ceiling leaves maxGasPrice as the only cap.
+func newFeeCurve(base, perDollar, ceiling uint64, horizon time.Duration) *feeCurve {
+    if base == 0 && perDollar == 0 {
+        return nil
+    }
+    return &feeCurve{
+        base:      new(big.Int).SetUint64(base),
+        perDollar: new(big.Int).SetUint64(perDollar),
+        ceiling:   new(big.Int).SetUint64(ceiling),
+        horizon:   horizon,
+    }
+}
+
+// tip returns the priority fee for an opportunity expected to net profit, in
+// 1e8 USD, that is age old. A nil curve returns nil.
+func (c *feeCurve) tip(profit *big.Int, age time.Duration) *big.Int {
+    if c == nil {
+        return nil
+    }
+    
+    bonus := new(big.Int)
+    if profit != nil && profit.Sign() > 0 && (c.horizon <= 0 || age < c.horizon) {
+        bonus.Mul(c.perDollar, profit)
+        bonus.Quo(bonus, pricing.Pow10(pricing.USDDecimals))
+        if c.horizon > 0 && age > 0 {
+            bonus.Mul(bonus, big.NewInt(int64(c.horizon-age)))
+            bonus.Quo(bonus, big.NewInt(int64(c.horizon)))
+        }
+    }
+    
+    tip := bonus.Add(bonus, c.base)
+    if c.ceiling.Sign() > 0 && tip.Cmp(c.ceiling) > 0 {
+        tip.Set(c.ceiling)
+    }
+    return tip
+}
\ No newline at end of file
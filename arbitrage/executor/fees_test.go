@@ -0,0 +1,111 @@
+package executor
+
+import (
+    "context"
+    "encoding/json"
+    "math/big"
+    "testing"
+    "time"
+
+    "github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestDynamicFees(t *testing.T) {
+    tests := []struct {
+        name                string
+        baseFee, tip, ceil  int64
+        wantTip, wantFeeCap int64
+    }{
+        {"twice base fee plus tip", 10, 2, 100, 2, 22},
+        {"fee cap clamped to ceiling", 40, 5, 60, 5, 60},
+        {"tip clamped to fee cap", 40, 90, 60, 60, 60},
+        {"zero base fee", 0, 3, 100, 3, 3},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            tipCap, feeCap := dynamicFees(big.NewInt(tt.baseFee), big.NewInt(tt.tip), big.NewInt(tt.ceil))
+            if tipCap.Int64() != tt.wantTip || feeCap.Int64() != tt.wantFeeCap {
+                t.Fatalf("dynamicFees = (%v, %v), want (%d, %d)", tipCap, feeCap, tt.wantTip, tt.wantFeeCap)
+            }
+        })
+    }
+}
+
+func TestBuildTxFeeMode(t *testing.T) {
+    tests := []struct {
+        name       string
+        baseFee    *big.Int
+        tip        *big.Int
+        wantType   uint8
+        wantTip    int64
+        wantFeeCap int64
+    }{
+        {"dynamic fee with suggested tip", big.NewInt(1000000000), nil, types.DynamicFeeTxType, 1000000000, 3000000000},
+        {"dynamic fee with curve tip", big.NewInt(1000000000), big.NewInt(5000000000), types.DynamicFeeTxType, 5000000000, 7000000000},
+        {"dynamic fee clamped to max gas price", big.NewInt(80000000000), nil, types.DynamicFeeTxType, 1000000000, 100000000000},
+        {"legacy without base fee", nil, nil, types.LegacyTxType, 2000000000, 2000000000},
+        {"legacy adds the tip", nil, big.NewInt(500000000), types.LegacyTxType, 2500000000, 2500000000},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            node := newStubNode(t)
+            node.handle("eth_getBlockByNumber", func([]json.RawMessage) (interface{}, error) {
+                return &types.Header{Number: big.NewInt(1), Difficulty: new(big.Int), BaseFee: tt.baseFee}, nil
+            })
+            e, _ := newTestExecutor(t, node, nil)
+            
+            tx, err := e.buildTx(context.Background(), e.wallets.wallets[0], testContract, 100000, nil, tt.tip)
+            if err != nil {
+                t.Fatalf("buildTx: %v", err)
+            }
+            if tx.Type() != tt.wantType {
+                t.Fatalf("type = %d, want %d", tx.Type(), tt.wantType)
+            }
+            if tx.GasTipCap().Int64() != tt.wantTip || tx.GasFeeCap().Int64() != tt.wantFeeCap {
+                t.Fatalf("fees = (tip %v, cap %v), want (%d, %d)", tx.GasTipCap(), tx.GasFeeCap(), tt.wantTip, tt.wantFeeCap)
+            }
+        })
+    }
+}
+
+func TestFeeCurveTip(t *testing.T) {
+    // A 1 gwei base tip plus 0.1 gwei per dollar of profit, decaying over
+    // ten seconds and capped at 5 gwei.
+    curve := newFeeCurve(1000000000, 100000000, 5000000000, 10*time.Second)
+    ageless := newFeeCurve(1000000000, 100000000, 5000000000, 0)
+    uncapped := newFeeCurve(1000000000, 100000000, 0, 10*time.Second)
+    
+    tests := []struct {
+        name   string
+        curve  *feeCurve
+        profit *big.Int
+        age    time.Duration
+        want   int64
+    }{
+        {"no profit pays the base", curve, new(big.Int), 0, 1000000000},
+        {"unknown profit pays the base", curve, nil, 0, 1000000000},
+        {"loss pays the base", curve, big.NewInt(-1000000000), 0, 1000000000},
+        {"fresh $10", curve, big.NewInt(1000000000), 0, 2000000000},
+        {"fresh $0.50", curve, big.NewInt(50000000), 0, 1050000000},
+        {"$10 half way to the horizon", curve, big.NewInt(1000000000), 5 * time.Second, 1500000000},
+        {"$10 at the horizon", curve, big.NewInt(1000000000), 10 * time.Second, 1000000000},
+        {"$10 past the horizon", curve, big.NewInt(1000000000), time.Minute, 1000000000},
+        {"$100 clamped to the ceiling", curve, big.NewInt(10000000000), 0, 5000000000},
+        {"no horizon never decays", ageless, big.NewInt(1000000000), time.Hour, 2000000000},
+        {"no ceiling", uncapped, big.NewInt(10000000000), 0, 11000000000},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := tt.curve.tip(tt.profit, tt.age); got.Int64() != tt.want {
+                t.Fatalf("tip = %v, want %d", got, tt.want)
+            }
+        })
+    }
+    
+    if c := newFeeCurve(0, 0, 5000000000, 10*time.Second); c != nil || c.tip(big.NewInt(1000000000), 0) != nil {
+        t.Fatal("a curve without a base tip or per-dollar rate should leave the suggested tip")
+    }
+}
\ No newline at end of file
@@ -0,0 +1,64 @@
+package executor
+
+import (
+    "context"
+    "math/big"
+    "time"
+
+    "github.com/sirupsen/logrus"
+)
+
+// belowGasFloor reports whether balance, in wei, is under floor. A nil or
+// zero floor disables the check.
+func belowGasFloor(balance, floor *big.Int) bool {
+    return floor != nil && floor.Sign() > 0 && balance.Cmp(floor) < 0
+}
+
+// watchGasBalances polls every wallet's native balance until ctx is
+// cancelled. A wallet that drops below the floor is marked low and sends
+// nothing until a later poll sees it topped up again.
+func (e *Executor) watchGasBalances(ctx context.Context) {
+    if e.minGasBalance.Sign() == 0 {
+        return
+    }
+    
+    ticker := time.NewTicker(e.gasBalanceInterval)
+    defer ticker.Stop()
+    
+    for {
+        for _, w := range e.wallets.wallets {
+            e.checkGasBalance(ctx, w)
+        }
+        
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+        }
+    }
+}
+
+// checkGasBalance reads w's native balance, reports it and updates whether
+// w is low on gas, logging each transition.
+func (e *Executor) checkGasBalance(ctx context.Context, w *wallet) {
+    log := e.logger.WithField("wallet", w.address.Hex())
+    
+    balance, err := e.client.BalanceAt(ctx, w.address, nil)
+    if err != nil {
+        log.WithError(err).Warn("Gas balance lookup failed")
+        return
+    }
+    
+    low := belowGasFloor(balance, e.minGasBalance)
+    e.monitor.RecordGasBalance(w.address.Hex(), balance, low)
+    
+    if low == w.lowGas.Swap(low) {
+        return
+    }
+    fields := logrus.Fields{"balance_wei": balance, "floor_wei": e.minGasBalance}
+    if low {
+        log.WithFields(fields).Error("Gas balance below floor, wallet paused until topped up")
+    } else {
+        log.WithFields(fields).Info("Gas balance topped up, wallet resumed")
+    }
+}
\ No newline at end of file
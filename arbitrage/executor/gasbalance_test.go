@@ -0,0 +1,70 @@
+package executor
+
+import (
+    "context"
+    "encoding/json"
+    "math/big"
+    "testing"
+
+    "github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestBelowGasFloor(t *testing.T) {
+    tests := []struct {
+        name    string
+        balance int64
+        floor   *big.Int
+        want    bool
+    }{
+        {"above the floor", 300, big.NewInt(250), false},
+        {"at the floor", 250, big.NewInt(250), false},
+        {"below the floor", 249, big.NewInt(250), true},
+        {"empty wallet", 0, big.NewInt(250), true},
+        {"zero floor", 0, new(big.Int), false},
+        {"no floor", 0, nil, false},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := belowGasFloor(big.NewInt(tt.balance), tt.floor); got != tt.want {
+                t.Fatalf("belowGasFloor(%d, %v) = %v, want %v", tt.balance, tt.floor, got, tt.want)
+            }
+        })
+    }
+}
+
+func TestCheckGasBalanceFlagsLowWallet(t *testing.T) {
+    // The default floor is 0.25 of the native token.
+    node := newStubNode(t)
+    balance := new(big.Int)
+    node.handle("eth_getBalance", func([]json.RawMessage) (interface{}, error) {
+        return (*hexutil.Big)(balance), nil
+    })
+    e, _ := newTestExecutor(t, node, nil)
+    w := e.wallets.wallets[0]
+    
+    steps := []struct {
+        balance int64
+        wantLow bool
+    }{
+        {100000000000000000, true},
+        {250000000000000000, false},
+        {249999999999999999, true},
+        {1000000000000000000, false},
+    }
+    for _, step := range steps {
+        balance.SetInt64(step.balance)
+        e.checkGasBalance(context.Background(), w)
+        if got := w.lowGas.Load(); got != step.wantLow {
+            t.Fatalf("balance %d: low = %v, want %v", step.balance, got, step.wantLow)
+        }
+    }
+    
+    // A failed lookup leaves the flag as it was.
+    node.handle("eth_getBalance", nil)
+    w.lowGas.Store(true)
+    e.checkGasBalance(context.Background(), w)
+    if !w.lowGas.Load() {
+        t.Fatal("failed lookup cleared the low gas flag")
+    }
+}
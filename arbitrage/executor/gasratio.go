@@ -0,0 +1,49 @@
+package executor
+
+import (
+    "math"
+    "math/big"
+
+    "github.com/hypercore-suite/arbitrage/pricing"
+    "github.com/hypercore-suite/arbitrage/trade"
+    "github.com/sirupsen/logrus"
+)
+
+// gasProfitRatioBps returns gasCost as basis points of gross, rounded up. A
+// gross of zero or less is reported as the maximum ratio so it never passes
+// the guard.
+func gasProfitRatioBps(gasCost, gross *big.Int) int64 {
+    if gross.Sign() <= 0 {
+        return math.MaxInt64
+    }
+    
+    ratio := pricing.MulDiv(gasCost, big.NewInt(10000), gross, pricing.RoundUp)
+    if !ratio.IsInt64() {
+        return math.MaxInt64
+    }
+    return ratio.Int64()
+}
+
+// checkGasRatio checks the projected gas cost of opp at gasPrice against its
+// expected gross profit, before any simulation. It rejects opp when gas
+// would eat more than the current maximum gas/profit ratio of the profit; a
+// zero threshold disables the check.
+func (e *Executor) checkGasRatio(log *logrus.Entry, opp *trade.Opportunity, gasPrice *big.Int) Rejection {
+    maxRatio := e.Thresholds.Load().MaxGasProfitRatioBps
+    if maxRatio == 0 {
+        return ""
+    }
+    
+    cost := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(e.defaultGasLimit))
+    ratio := gasProfitRatioBps(e.gasCostUSD(cost), grossProfit(opp, nil))
+    if ratio <= maxRatio {
+        return ""
+    }
+    
+    log.WithFields(logrus.Fields{
+        "gas_price":     gasPrice,
+        "gas_ratio_bps": ratio,
+        "max_ratio_bps": maxRatio,
+    }).Info("Gas cost too high relative to profit, skipping opportunity")
+    return RejectGasTooHigh
+}
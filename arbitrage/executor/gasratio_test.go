@@ -0,0 +1,90 @@
+package executor
+
+import (
+    "math"
+    "math/big"
+    "testing"
+
+    "github.com/hypercore-suite/arbitrage/config"
+    "github.com/hypercore-suite/arbitrage/thresholds"
+)
+
+func TestGasProfitRatioBps(t *testing.T) {
+    tests := []struct {
+        name           string
+        gasCost, gross int64
+        want           int64
+    }{
+        {"fifth of the profit", 10000000, 50000000, 2000},
+        {"rounded up", 1, 30000, 1},
+        {"equal to the profit", 50000000, 50000000, 10000},
+        {"zero gas", 0, 50000000, 0},
+        {"no profit", 10000000, 0, math.MaxInt64},
+        {"a loss", 10000000, -5, math.MaxInt64},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := gasProfitRatioBps(big.NewInt(tt.gasCost), big.NewInt(tt.gross)); got != tt.want {
+                t.Fatalf("gasProfitRatioBps = %d, want %d", got, tt.want)
+            }
+        })
+    }
+}
+
+func TestCheckGasRatio(t *testing.T) {
+    // Gross profit is $0.50; 500k gas at $20 per native token costs $0.01
+    // per gwei.
+    tests := []struct {
+        name     string
+        gasGwei  int64
+        maxRatio int64
+        want     Rejection
+    }{
+        {"well under the limit", 10, 5000, ""},
+        {"at the limit", 25, 5000, ""},
+        {"over the limit", 30, 5000, RejectGasTooHigh},
+        {"guard disabled", 300, 0, ""},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            e := &Executor{
+                defaultGasLimit: 500000,
+                nativePrice:     big.NewInt(2000000000),
+                Thresholds:      thresholds.New(thresholds.Set{MaxGasProfitRatioBps: tt.maxRatio}),
+            }
+            gasPrice := new(big.Int).Mul(big.NewInt(tt.gasGwei), big.NewInt(1000000000))
+            
+            if got := e.checkGasRatio(quietLogger().WithField("test", t.Name()), testOpportunity(), gasPrice); got != tt.want {
+                t.Fatalf("checkGasRatio = %q, want %q", got, tt.want)
+            }
+        })
+    }
+}
+
+func TestCheckGasRatioDefaultConfig(t *testing.T) {
+    // The default configuration guards at half the profit; with the native
+    // token priced at $20, 500k gas costs $0.01 per gwei.
+    tests := []struct {
+        name    string
+        gasGwei int64
+        want    Rejection
+    }{
+        {"cheap gas", 10, ""},
+        {"gas over half the profit", 30, RejectGasTooHigh},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            e, _ := newTestExecutor(t, newStubNode(t), func(cfg *config.Config) {
+                cfg.Executor.NativeTokenPrice = 2000000000
+            })
+            gasPrice := new(big.Int).Mul(big.NewInt(tt.gasGwei), big.NewInt(1000000000))
+            
+            if got := e.checkGasRatio(quietLogger().WithField("test", t.Name()), testOpportunity(), gasPrice); got != tt.want {
+                t.Fatalf("checkGasRatio = %q, want %q", got, tt.want)
+            }
+        })
+    }
+}
\ No newline at end of file
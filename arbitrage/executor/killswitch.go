@@ -0,0 +1,48 @@
+package executor
+
+import (
+    "context"
+    "errors"
+    "io/fs"
+    "os"
+    "time"
+)
+
+// killSwitchPoll is how often the kill switch file is checked for.
+const killSwitchPoll = time.Second
+
+// watchKillSwitch pauses trading while a file exists at path and lifts the
+// pause once it is removed, until ctx is cancelled. It needs nothing but the
+// filesystem, so it works when the control endpoints cannot be reached. An
+// empty path disables it.
+func (e *Executor) watchKillSwitch(ctx context.Context, path string) {
+    if path == "" {
+        return
+    }
+    
+    ticker := time.NewTicker(killSwitchPoll)
+    defer ticker.Stop()
+    
+    halted := false
+    for {
+        _, err := os.Stat(path)
+        switch {
+        case err == nil && !halted:
+            halted = true
+            e.logger.WithField("path", path).Error("Kill switch file present, halting trading")
+            e.setPaused(PauseSourceFile, true)
+        case errors.Is(err, fs.ErrNotExist) && halted:
+            halted = false
+            e.logger.WithField("path", path).Warn("Kill switch file removed")
+            e.setPaused(PauseSourceFile, false)
+        case err != nil && !errors.Is(err, fs.ErrNotExist):
+            e.logger.WithError(err).WithField("path", path).Warn("Kill switch check failed")
+        }
+        
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+        }
+    }
+}
@@ -0,0 +1,89 @@
+package executor
+
+import (
+    "math/big"
+
+    "github.com/hypercore-suite/arbitrage/pricing"
+    "github.com/hypercore-suite/arbitrage/trade"
+)
+
+// tradeLeg is one side of an arbitrage. IsBuy opportunities buy spot and sell
+// the perp; the reverse direction sells spot and buys the perp.
+type tradeLeg struct {
+    market        trade.Market
+    asset         uint32
+    buy           bool
+    price         *big.Int
+    fees          trade.FeeModel
+    halfSpreadBps uint64
+}
+
+// legs returns the spot and perp legs of opp traded through r. The spot leg
+// trades at the expected fill price, the perp leg at the oracle price.
+// Oracle prices are mids, so a leg priced from one also crosses half the
+// bid/ask spread; a fill price walked from the book already includes it. A
+// nil r leaves fees at zero, for callers that only need the prices.
+func (e *Executor) legs(opp *trade.Opportunity, r *route) (tradeLeg, tradeLeg) {
+    spotPrice, spotHalfSpread := opp.FillPrice, uint64(0)
+    if spotPrice == nil {
+        spotPrice, spotHalfSpread = opp.EVMPrice, e.spotHalfSpread
+    }
+    
+    spot := tradeLeg{market: trade.MarketSpot, asset: opp.Asset, buy: opp.IsBuy, price: spotPrice, halfSpreadBps: spotHalfSpread}
+    perp := tradeLeg{market: trade.MarketPerp, asset: opp.Asset, buy: !opp.IsBuy, price: opp.CorePrice, halfSpreadBps: e.perpHalfSpread}
+    if r != nil {
+        spot.fees, perp.fees = r.fees, r.fees
+    }
+    return spot, perp
+}
+
+// fee returns the leg's taker fee for amount under its fee model, in USD at
+// trade.PriceDecimals; a leg without one pays none.
+func (l tradeLeg) fee(amount *big.Int) *big.Int {
+    if l.fees == nil {
+        return new(big.Int)
+    }
+    return l.fees.TakerFee(l.asset, l.market, l.notional(amount))
+}
+
+// notional returns the USD value of amount at the leg's price, rounded up.
+func (l tradeLeg) notional(amount *big.Int) *big.Int {
+    return pricing.MulDiv(amount, l.price, pricing.Pow10(trade.PriceDecimals), pricing.RoundUp)
+}
+
+// crossing returns the cost of crossing half the leg's bid/ask spread for
+// amount, in USD at trade.PriceDecimals, rounded up.
+func (l tradeLeg) crossing(amount *big.Int) *big.Int {
+    cost := new(big.Int).Mul(amount, l.price)
+    cost.Mul(cost, new(big.Int).SetUint64(l.halfSpreadBps))
+    return pricing.Div(cost, new(big.Int).Mul(pricing.Pow10(trade.PriceDecimals), big.NewInt(10000)), pricing.RoundUp)
+}
+
+func (l tradeLeg) side() string {
+    if l.buy {
+        return "buy"
+    }
+    return "sell"
+}
+
+// legFees returns the combined trading fees of both legs of opp through r.
+func (e *Executor) legFees(opp *trade.Opportunity, r *route) *big.Int {
+    spot, perp := e.legs(opp, r)
+    return new(big.Int).Add(spot.fee(opp.Amount), perp.fee(opp.Amount))
+}
+
+// tradingCosts returns what a round trip of opp through r is expected to
+// cost beyond gas: both legs' taker fees plus the half-spread each leg
+// crosses.
+func (e *Executor) tradingCosts(opp *trade.Opportunity, r *route) *big.Int {
+    spot, perp := e.legs(opp, r)
+    costs := new(big.Int).Add(spot.fee(opp.Amount), perp.fee(opp.Amount))
+    costs.Add(costs, spot.crossing(opp.Amount))
+    return costs.Add(costs, perp.crossing(opp.Amount))
+}
+
+// direction describes which way opp trades, for logs.
+func (e *Executor) direction(opp *trade.Opportunity) string {
+    spot, perp := e.legs(opp, nil)
+    return spot.side() + "_spot_" + perp.side() + "_perp"
+}
@@ -0,0 +1,120 @@
+package executor
+
+import (
+    "math/big"
+    "testing"
+    "time"
+
+    "github.com/hypercore-suite/arbitrage/config"
+    "github.com/hypercore-suite/arbitrage/trade"
+)
+
+func TestLegsFollowDirection(t *testing.T) {
+    tests := []struct {
+        name          string
+        isBuy         bool
+        wantDirection string
+    }{
+        {"buy spot, sell perp", true, "buy_spot_sell_perp"},
+        {"sell spot, buy perp", false, "sell_spot_buy_perp"},
+    }
+    
+    e := &Executor{}
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            opp := &trade.Opportunity{
+                CorePrice: big.NewInt(10050000000),
+                EVMPrice:  big.NewInt(10000000000),
+                IsBuy:     tt.isBuy,
+                Amount:    big.NewInt(100000000),
+            }
+            
+            spot, perp := e.legs(opp, nil)
+            if spot.buy != tt.isBuy || perp.buy == tt.isBuy {
+                t.Fatalf("legs buy spot = %v, buy perp = %v for IsBuy %v", spot.buy, perp.buy, tt.isBuy)
+            }
+            if spot.price != opp.EVMPrice || perp.price != opp.CorePrice {
+                t.Fatalf("legs priced at spot %v, perp %v", spot.price, perp.price)
+            }
+            if got := e.direction(opp); got != tt.wantDirection {
+                t.Fatalf("direction = %s, want %s", got, tt.wantDirection)
+            }
+        })
+    }
+}
+
+func TestSimulateExecutionCharges(t *testing.T) {
+    // The test opportunity grosses $0.50 on a $100 spot and $100.50 perp leg,
+    // and 200000 gas at 2 gwei costs $0.008 at $20.
+    tests := []struct {
+        name             string
+        spotFee, perpFee int64
+        perpHalfSpread   uint64
+        wantProfit       int64
+        wantSuccess      bool
+    }{
+        {"gas only", 0, 0, 0, 49200000, true},
+        {"taker fees on both legs", 20, 20, 0, 9100000, true},
+        {"fees and the perp half-spread", 20, 20, 10, -950000, false},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            e, _ := newTestExecutor(t, newStubNode(t), func(cfg *config.Config) {
+                cfg.Executor.NativeTokenPrice = 2000000000
+                cfg.Executor.SpotFeeBps = tt.spotFee
+                cfg.Executor.PerpFeeBps = tt.perpFee
+                cfg.Executor.PerpHalfSpreadBps = tt.perpHalfSpread
+            })
+            
+            profit, success := e.simulateExecution(testOpportunity(), e.routes[0], 200000, big.NewInt(2000000000), nil)
+            if profit.Int64() != tt.wantProfit || success != tt.wantSuccess {
+                t.Fatalf("simulateExecution = (%v, %v), want (%d, %v)", profit, success, tt.wantProfit, tt.wantSuccess)
+            }
+        })
+    }
+}
+
+// flatFees charges the same taker fee on every leg whatever its size.
+type flatFees struct {
+    fee int64
+}
+
+func (f flatFees) TakerFee(uint32, trade.Market, *big.Int) *big.Int {
+    return big.NewInt(f.fee)
+}
+
+func (f flatFees) Funding(uint32, *big.Int, *big.Int, time.Duration, bool) *big.Int {
+    return new(big.Int)
+}
+
+func TestFeeModelsDecideVerdict(t *testing.T) {
+    // The same $0.50 opportunity nets $0.492 after gas; whether it still
+    // pays depends only on the route's fee model.
+    tests := []struct {
+        name        string
+        fees        trade.FeeModel
+        wantProfit  int64
+        wantSuccess bool
+    }{
+        {"flat bps", trade.BpsFees{SpotBps: 20, PerpBps: 20}, 9100000, true},
+        {"asset tier overrides the flat bps", trade.BpsFees{SpotBps: 20, PerpBps: 20, Assets: map[uint32]trade.BpsFees{0: {SpotBps: 40, PerpBps: 40}}}, -31000000, false},
+        {"spot rebate tier", trade.BpsFees{SpotBps: 40, PerpBps: 40, Assets: map[uint32]trade.BpsFees{0: {SpotBps: -5, PerpBps: 20}}}, 34100000, true},
+        {"other asset's tier ignored", trade.BpsFees{SpotBps: 20, PerpBps: 20, Assets: map[uint32]trade.BpsFees{1: {SpotBps: 40, PerpBps: 40}}}, 9100000, true},
+        {"flat fee per leg", flatFees{fee: 30000000}, -10800000, false},
+    }
+    
+    e, _ := newTestExecutor(t, newStubNode(t), func(cfg *config.Config) {
+        cfg.Executor.NativeTokenPrice = 2000000000
+    })
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            r := *e.routes[0]
+            r.fees = tt.fees
+            profit, success := e.simulateExecution(testOpportunity(), &r, 200000, big.NewInt(2000000000), nil)
+            if profit.Int64() != tt.wantProfit || success != tt.wantSuccess {
+                t.Fatalf("simulateExecution = (%v, %v), want (%d, %v)", profit, success, tt.wantProfit, tt.wantSuccess)
+            }
+        })
+    }
+}
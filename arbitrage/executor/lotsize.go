@@ -0,0 +1,94 @@
+package executor
+
+import (
+    "errors"
+    "fmt"
+    "math/big"
+
+    "github.com/hypercore-suite/arbitrage/config"
+    "github.com/hypercore-suite/arbitrage/pricing"
+    "github.com/hypercore-suite/arbitrage/registry"
+    "github.com/hypercore-suite/arbitrage/trade"
+    "github.com/sirupsen/logrus"
+)
+
+// errBelowMinSize is returned by normalizeAmount when the rounded size is
+// smaller than the venue minimum.
+var errBelowMinSize = errors.New("size below venue minimum")
+
+// lotSize is one asset's venue order size limits in trade.PriceDecimals
+// fixed point. A nil max or step disables that limit.
+type lotSize struct {
+    min  *big.Int
+    max  *big.Int
+    step *big.Int
+}
+
+// newLotSizes builds the per-asset limits. Every registered asset trades in
+// steps of its size decimals unless cfg sets an explicit step.
+func newLotSizes(cfg map[uint32]config.LotSizeConfig, assets *registry.Registry) map[uint32]lotSize {
+    lots := make(map[uint32]lotSize, len(cfg))
+    for _, asset := range assets.Assets() {
+        lots[asset.ID] = lotSize{min: new(big.Int), step: decimalStep(asset.Decimals)}
+    }
+    
+    for asset, c := range cfg {
+        lot := lotSize{min: new(big.Int).SetUint64(c.Min), step: lots[asset].step}
+        if c.Max > 0 {
+            lot.max = new(big.Int).SetUint64(c.Max)
+        }
+        if c.Step > 0 {
+            lot.step = new(big.Int).SetUint64(c.Step)
+        }
+        lots[asset] = lot
+    }
+    return lots
+}
+
+// decimalStep returns the smallest size, in trade.PriceDecimals fixed
+// point, of an asset traded to the given number of decimals. It is nil when
+// sizes are at least as fine as the fixed-point scale.
+func decimalStep(decimals int) *big.Int {
+    if decimals >= trade.PriceDecimals {
+        return nil
+    }
+    return pricing.Pow10(trade.PriceDecimals - decimals)
+}
+
+// normalizeAmount caps amount at the venue maximum and rounds it down to a
+// whole number of steps. It fails when the result is below the venue minimum
+// or not positive.
+func normalizeAmount(amount *big.Int, lot lotSize) (*big.Int, error) {
+    size := new(big.Int).Set(amount)
+    if lot.max != nil && size.Cmp(lot.max) > 0 {
+        size.Set(lot.max)
+    }
+    if lot.step != nil {
+        size.Sub(size, new(big.Int).Mod(size, lot.step))
+    }
+    
+    if size.Sign() <= 0 || size.Cmp(lot.min) < 0 {
+        return nil, fmt.Errorf("%w: %v rounds to %v, minimum %v", errBelowMinSize, amount, size, lot.min)
+    }
+    return size, nil
+}
+
+// applyLotSize returns a copy of opp sized to a valid lot for its asset.
+// Assets without configured limits are returned unchanged. It is rejected
+// when the size cannot be made valid.
+func (e *Executor) applyLotSize(log *logrus.Entry, opp *trade.Opportunity) (*trade.Opportunity, Rejection) {
+    lot, found := e.lotSizes[opp.Asset]
+    if !found {
+        return opp, ""
+    }
+    
+    amount, err := normalizeAmount(opp.Amount, lot)
+    if err != nil {
+        log.WithError(err).Info("Trade size violates venue limits, skipping opportunity")
+        return nil, RejectLotSize
+    }
+    
+    sized := *opp
+    sized.Amount = amount
+    return &sized, ""
+}
\ No newline at end of file
@@ -0,0 +1,39 @@
+package executor
+
+import (
+    "errors"
+    "math/big"
+    "testing"
+)
+
+func TestNormalizeAmount(t *testing.T) {
+    // Sizes trade in steps of 0.001 between 0.01 and 5.
+    lot := lotSize{min: big.NewInt(1000000), max: big.NewInt(500000000), step: big.NewInt(100000)}
+    tests := []struct {
+        name    string
+        lot     lotSize
+        amount  int64
+        want    int64
+        wantErr error
+    }{
+        {"whole steps unchanged", lot, 150000000, 150000000, nil},
+        {"rounded down to the step", lot, 150099999, 150000000, nil},
+        {"capped at the maximum", lot, 900000000, 500000000, nil},
+        {"at the minimum", lot, 1000000, 1000000, nil},
+        {"below the minimum", lot, 999999, 0, errBelowMinSize},
+        {"rounds to zero", lotSize{min: new(big.Int), step: big.NewInt(100000)}, 99999, 0, errBelowMinSize},
+        {"no step", lotSize{min: new(big.Int)}, 123456789, 123456789, nil},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            got, err := normalizeAmount(big.NewInt(tt.amount), tt.lot)
+            if !errors.Is(err, tt.wantErr) {
+                t.Fatalf("normalizeAmount error = %v, want %v", err, tt.wantErr)
+            }
+            if err == nil && got.Int64() != tt.want {
+                t.Fatalf("normalizeAmount = %v, want %d", got, tt.want)
+            }
+        })
+    }
+}
@@ -0,0 +1,87 @@
+package executor
+
+import (
+    "context"
+    "math/big"
+    "sync"
+    "time"
+
+    "github.com/ethereum/go-ethereum/core/types"
+    "github.com/hypercore-suite/arbitrage/pricing"
+    "github.com/sirupsen/logrus"
+)
+
+// nativePriceRefresh is how long an oracle read of the native token price is
+// reused, since every simulation converts gas to USD.
+const nativePriceRefresh = 5 * time.Second
+
+// SpotPriceSource supplies spot oracle prices at 1e8 fixed point, or nil when
+// unavailable; the detector's price source satisfies it.
+type SpotPriceSource interface {
+    SpotPrice(ctx context.Context, asset uint32) (price *big.Int, updated time.Time)
+}
+
+// nativeOracle caches the native token's spot oracle price.
+type nativeOracle struct {
+    asset uint32
+    
+    mu      sync.Mutex
+    price   *big.Int
+    fetched time.Time
+}
+
+// nativeTokenPrice returns the USD price of the gas token in 1e8 fixed
+// point: the native asset's oracle price when one is configured and Prices
+// is set, else the configured NativeTokenPrice. While the oracle returns no
+// price the last one read is kept, falling back to the configured price.
+// The read is cancelled with the executor's executions on shutdown.
+func (e *Executor) nativeTokenPrice() *big.Int {
+    if e.native == nil || e.Prices == nil {
+        return e.nativePrice
+    }
+    o := e.native
+    o.mu.Lock()
+    defer o.mu.Unlock()
+    
+    if o.price == nil || time.Since(o.fetched) >= nativePriceRefresh {
+        price, _ := e.Prices.SpotPrice(e.execCtx, o.asset)
+        if price != nil && price.Sign() > 0 {
+            o.price = price
+        } else {
+            e.logger.WithField("asset", o.asset).Debug("Native token oracle price unavailable")
+        }
+        o.fetched = time.Now()
+    }
+    if o.price == nil {
+        return e.nativePrice
+    }
+    return o.price
+}
+
+// weiToUSD converts a fee in wei to USD at trade.PriceDecimals at
+// nativePrice, rounded by mode.
+func weiToUSD(wei, nativePrice *big.Int, mode pricing.Rounding) *big.Int {
+    return pricing.MulDiv(wei, nativePrice, pricing.Pow10(pricing.NativeDecimals), mode)
+}
+
+// costBreakdown returns the per-trade P&L fields of a settled arbitrage: its
+// gas as used, priced and totalled in native token and USD at nativePrice,
+// and its gross and net profit with their ratio to gas.
+func costBreakdown(receipt *types.Receipt, nativePrice, gross, net *big.Int) logrus.Fields {
+    spent := gasCost(receipt)
+    gasUSD := pricing.ToUSD(weiToUSD(spent, nativePrice, pricing.RoundHalfUp), pricing.USDDecimals)
+    fields := logrus.Fields{
+        "gas_used":            receipt.GasUsed,
+        "effective_gas_price": receipt.EffectiveGasPrice,
+        "gas_cost_wei":        spent,
+        "gas_cost_native":     pricing.ToUSD(spent, pricing.NativeDecimals),
+        "gas_cost_usd":        gasUSD,
+        "native_price_usd":    pricing.ToUSD(nativePrice, pricing.USDDecimals),
+        "gross_profit_usd":    pricing.ToUSD(gross, pricing.USDDecimals),
+        "net_profit_usd":      pricing.ToUSD(net, pricing.USDDecimals),
+    }
+    if gasUSD > 0 {
+        fields["profit_gas_ratio"] = pricing.ToUSD(gross, pricing.USDDecimals) / gasUSD
+    }
+    return fields
+}
\ No newline at end of file
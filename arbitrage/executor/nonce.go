@@ -0,0 +1,69 @@
+package executor
+
+import (
+    "context"
+    "sync"
+
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/ethereum/go-ethereum/core/types"
+)
+
+// NonceSource is the subset of ethclient.Client the nonce manager needs.
+type NonceSource interface {
+    PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+}
+
+// nonceManager hands out sequential nonces for a single account. The pending
+// nonce is fetched from the chain once and then tracked locally; resync
+// re-reads it after a broadcast failure. With a store the counter and the
+// account's unmined transactions are saved as they change.
+type nonceManager struct {
+    mu      sync.Mutex
+    source  NonceSource
+    store   *nonceStore
+    account common.Address
+    nonce   uint64
+}
+
+func newNonceManager(ctx context.Context, source NonceSource, store *nonceStore, account common.Address) (*nonceManager, error) {
+    n := &nonceManager{source: source, store: store, account: account}
+    if err := n.resync(ctx); err != nil {
+        return nil, err
+    }
+    return n, nil
+}
+
+// reserve returns the next nonce and advances the local counter.
+func (n *nonceManager) reserve() uint64 {
+    n.mu.Lock()
+    defer n.mu.Unlock()
+    
+    nonce := n.nonce
+    n.nonce++
+    n.store.setNonce(n.account, n.nonce)
+    return nonce
+}
+
+// resync replaces the local counter with the chain's pending nonce.
+func (n *nonceManager) resync(ctx context.Context) error {
+    n.mu.Lock()
+    defer n.mu.Unlock()
+    
+    nonce, err := n.source.PendingNonceAt(ctx, n.account)
+    if err != nil {
+        return err
+    }
+    n.nonce = nonce
+    n.store.setNonce(n.account, n.nonce)
+    return nil
+}
+
+// track records tx as sent and awaiting a receipt.
+func (n *nonceManager) track(tx *types.Transaction) {
+    n.store.track(n.account, tx)
+}
+
+// settle records that a transaction with nonce was mined.
+func (n *nonceManager) settle(nonce uint64) {
+    n.store.settle(n.account, nonce)
+}
\ No newline at end of file
@@ -0,0 +1,58 @@
+package executor
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "sync/atomic"
+    "testing"
+
+    "github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestBroadcastResyncsNonceAfterConflict(t *testing.T) {
+    tests := []struct {
+        name      string
+        rejection string
+    }{
+        {"nonce too low", "nonce too low: next nonce 9, tx nonce 5"},
+        {"nonce too high", "nonce too high"},
+        {"replacement underpriced", "replacement transaction underpriced"},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            node := newStubNode(t)
+            var chainNonce atomic.Uint64
+            chainNonce.Store(5)
+            node.handle("eth_getTransactionCount", func([]json.RawMessage) (interface{}, error) {
+                return hexutil.Uint64(chainNonce.Load()), nil
+            })
+            e, _ := newTestExecutor(t, node, nil)
+            w := e.wallets.wallets[0]
+            
+            // Another sender used nonces 5 to 8 behind the executor's back.
+            chainNonce.Store(9)
+            accept := node.handler("eth_sendRawTransaction")
+            var rejected atomic.Bool
+            node.handle("eth_sendRawTransaction", func(params []json.RawMessage) (interface{}, error) {
+                if rejected.CompareAndSwap(false, true) {
+                    return nil, errors.New(tt.rejection)
+                }
+                return accept(params)
+            })
+            
+            ctx := context.Background()
+            if _, err := e.broadcast(ctx, w, testContract, 100000, nil, nil, false); !errors.Is(err, ErrNonceConflict) {
+                t.Fatalf("first broadcast error = %v, want ErrNonceConflict", err)
+            }
+            tx, err := e.broadcast(ctx, w, testContract, 100000, nil, nil, false)
+            if err != nil {
+                t.Fatalf("second broadcast: %v", err)
+            }
+            if tx.Nonce() != 9 {
+                t.Fatalf("nonce after resync = %d, want the chain's 9", tx.Nonce())
+            }
+        })
+    }
+}
\ No newline at end of file
@@ -0,0 +1,241 @@
+package executor
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "os"
+    "path/filepath"
+    "sort"
+    "sync"
+
+    "github.com/ethereum/go-ethereum"
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/ethereum/go-ethereum/core/types"
+    "github.com/sirupsen/logrus"
+)
+
+// txLookup is the part of the RPC client restoring saved nonce state needs.
+type txLookup interface {
+    TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error)
+    TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error)
+}
+
+// nonceStore saves each wallet's next nonce and the transactions it sent
+// that have not been seen mined, so a restart resumes the nonce sequence
+// behind the transactions still in flight instead of colliding with them.
+// Each change is written and synced to a temporary file before it replaces
+// the old state, so a crash never leaves it half written; a failed write is
+// logged and the state is kept in memory.
+type nonceStore struct {
+    path   string
+    logger *logrus.Logger
+    
+    mu      sync.Mutex
+    wallets map[common.Address]*walletNonces
+}
+
+// walletNonces is one wallet's saved state.
+type walletNonces struct {
+    Nonce   uint64      `json:"nonce"`
+    Pending []pendingTx `json:"pending"`
+}
+
+// pendingTx is a sent transaction not yet seen mined.
+type pendingTx struct {
+    Hash  common.Hash `json:"hash"`
+    Nonce uint64      `json:"nonce"`
+}
+
+// openNonceStore loads the nonce state saved at path. A missing file starts
+// empty; an empty path returns a nil store, which saves nothing.
+func openNonceStore(path string, logger *logrus.Logger) (*nonceStore, error) {
+    if path == "" {
+        return nil, nil
+    }
+    
+    s := &nonceStore{
+        path:    path,
+        logger:  logger,
+        wallets: make(map[common.Address]*walletNonces),
+    }
+    raw, err := os.ReadFile(path)
+    if errors.Is(err, os.ErrNotExist) {
+        return s, nil
+    }
+    if err != nil {
+        return nil, fmt.Errorf("read nonce state: %w", err)
+    }
+    if err := json.Unmarshal(raw, &s.wallets); err != nil {
+        return nil, fmt.Errorf("decode nonce state %s: %w", path, err)
+    }
+    return s, nil
+}
+
+// saved returns a copy of account's saved state.
+func (s *nonceStore) saved(account common.Address) walletNonces {
+    if s == nil {
+        return walletNonces{}
+    }
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    
+    state, ok := s.wallets[account]
+    if !ok {
+        return walletNonces{}
+    }
+    return walletNonces{Nonce: state.Nonce, Pending: append([]pendingTx(nil), state.Pending...)}
+}
+
+// update applies change to account's state and saves the result.
+func (s *nonceStore) update(account common.Address, change func(state *walletNonces)) {
+    if s == nil {
+        return
+    }
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    
+    state, ok := s.wallets[account]
+    if !ok {
+        state = &walletNonces{}
+        s.wallets[account] = state
+    }
+    change(state)
+    
+    if err := s.save(); err != nil {
+        s.logger.WithError(err).WithField("path", s.path).Warn("Failed to save nonce state")
+    }
+}
+
+// setNonce records account's next nonce.
+func (s *nonceStore) setNonce(account common.Address, nonce uint64) {
+    s.update(account, func(state *walletNonces) {
+        state.Nonce = nonce
+    })
+}
+
+// track records tx as sent from account and not yet mined.
+func (s *nonceStore) track(account common.Address, tx *types.Transaction) {
+    s.update(account, func(state *walletNonces) {
+        state.Pending = append(state.Pending, pendingTx{Hash: tx.Hash(), Nonce: tx.Nonce()})
+    })
+}
+
+// settle forgets every transaction from account at or below nonce, once a
+// transaction with that nonce is mined; replacements sharing it can no
+// longer be.
+func (s *nonceStore) settle(account common.Address, nonce uint64) {
+    s.update(account, func(state *walletNonces) {
+        kept := state.Pending[:0]
+        for _, p := range state.Pending {
+            if p.Nonce > nonce {
+                kept = append(kept, p)
+            }
+        }
+        state.Pending = kept
+    })
+}
+
+// save writes every wallet's state. Callers hold s.mu.
+func (s *nonceStore) save() error {
+    raw, err := json.Marshal(s.wallets)
+    if err != nil {
+        return err
+    }
+    
+    tmp, err := os.CreateTemp(filepath.Dir(s.path), ".nonce-state-*")
+    if err != nil {
+        return fmt.Errorf("save nonce state: %w", err)
+    }
+    defer os.Remove(tmp.Name())
+    
+    if _, err := tmp.Write(raw); err != nil {
+        tmp.Close()
+        return fmt.Errorf("save nonce state: %w", err)
+    }
+    if err := tmp.Sync(); err != nil {
+        tmp.Close()
+        return fmt.Errorf("save nonce state: %w", err)
+    }
+    if err := tmp.Close(); err != nil {
+        return fmt.Errorf("save nonce state: %w", err)
+    }
+    if err := os.Rename(tmp.Name(), s.path); err != nil {
+        return fmt.Errorf("save nonce state: %w", err)
+    }
+    return nil
+}
+
+// restore reconciles the nonce manager, already synced to the chain's
+// pending nonce, with the state saved before a restart. Those mined
+// since, and their same-nonce replacements, are forgotten; those the node no
+// longer knows were dropped and their nonces are reused. Those still pending
+// stay tracked, and the next nonce moves past any run of them starting at the
+// chain's pending nonce, so the first new transaction neither collides with
+// them nor leaves a gap.
+func (n *nonceManager) restore(ctx context.Context, chain txLookup, saved walletNonces, logger *logrus.Logger) error {
+    n.mu.Lock()
+    defer n.mu.Unlock()
+    
+    var inFlight, dropped []pendingTx
+    mined := -1
+    for _, p := range saved.Pending {
+        _, err := chain.TransactionReceipt(ctx, p.Hash)
+        if err == nil {
+            if int(p.Nonce) > mined {
+                mined = int(p.Nonce)
+            }
+            continue
+        }
+        if !errors.Is(err, ethereum.NotFound) {
+            return fmt.Errorf("look up receipt of %s: %w", p.Hash.Hex(), classifyCallError(err))
+        }
+        
+        _, _, err = chain.TransactionByHash(ctx, p.Hash)
+        if errors.Is(err, ethereum.NotFound) {
+            dropped = append(dropped, p)
+            continue
+        }
+        if err != nil {
+            return fmt.Errorf("look up transaction %s: %w", p.Hash.Hex(), classifyCallError(err))
+        }
+        inFlight = append(inFlight, p)
+    }
+    
+    kept := unsettled(inFlight, mined)
+    sort.Slice(kept, func(i, j int) bool { return kept[i].Nonce < kept[j].Nonce })
+    for _, p := range kept {
+        if p.Nonce == n.nonce {
+            n.nonce++
+        }
+    }
+    
+    n.store.update(n.account, func(state *walletNonces) {
+        state.Nonce = n.nonce
+        state.Pending = kept
+    })
+    
+    if len(saved.Pending) > 0 || saved.Nonce > n.nonce {
+        logger.WithFields(logrus.Fields{
+            "address":     n.account.Hex(),
+            "nonce":       n.nonce,
+            "saved_nonce": saved.Nonce,
+            "in_flight":   len(kept),
+            "dropped":     len(unsettled(dropped, mined)),
+        }).Info("Nonce state restored")
+    }
+    return nil
+}
+
+// unsettled returns the transactions in pending above the highest mined
+// nonce, -1 when none was.
+func unsettled(pending []pendingTx, mined int) []pendingTx {
+    var kept []pendingTx
+    for _, p := range pending {
+        if int(p.Nonce) > mined {
+            kept = append(kept, p)
+        }
+    }
+    return kept
+}
\ No newline at end of file
@@ -0,0 +1,131 @@
+package executor
+
+import (
+    "context"
+    "errors"
+    "math/big"
+    "path/filepath"
+    "reflect"
+    "testing"
+
+    "github.com/ethereum/go-ethereum"
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/ethereum/go-ethereum/core/types"
+)
+
+// fixedNonce is a chain whose pending nonce never moves.
+type fixedNonce uint64
+
+func (n fixedNonce) PendingNonceAt(context.Context, common.Address) (uint64, error) {
+    return uint64(n), nil
+}
+
+// stubLookup knows the mined and the still pending transactions; every
+// other hash is unknown to the node.
+type stubLookup struct {
+    mined, pending map[common.Hash]bool
+    err            error
+}
+
+func (l stubLookup) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+    if l.err != nil {
+        return nil, l.err
+    }
+    if l.mined[hash] {
+        return &types.Receipt{TxHash: hash, Status: types.ReceiptStatusSuccessful}, nil
+    }
+    return nil, ethereum.NotFound
+}
+
+func (l stubLookup) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+    if l.pending[hash] {
+        return nil, true, nil
+    }
+    return nil, false, ethereum.NotFound
+}
+
+func TestNonceRestoreAfterRestart(t *testing.T) {
+    ctx := context.Background()
+    path := filepath.Join(t.TempDir(), "nonces.json")
+    account := common.HexToAddress("0x000000000000000000000000000000000000a11c")
+    
+    // Before the restart the wallet sent nonces 8 to 13, 9 twice as a
+    // replacement, and only 8 was seen mined.
+    store, err := openNonceStore(path, quietLogger())
+    if err != nil {
+        t.Fatalf("open store: %v", err)
+    }
+    before, err := newNonceManager(ctx, fixedNonce(8), store, account)
+    if err != nil {
+        t.Fatalf("nonce manager: %v", err)
+    }
+    sent := make(map[uint64]*types.Transaction)
+    for i := 0; i < 6; i++ {
+        nonce := before.reserve()
+        sent[nonce] = types.NewTx(&types.LegacyTx{Nonce: nonce, GasPrice: big.NewInt(1)})
+        before.track(sent[nonce])
+    }
+    replaced := types.NewTx(&types.LegacyTx{Nonce: 9, GasPrice: big.NewInt(2)})
+    before.track(replaced)
+    
+    // Since then 8 and the replacement at 9 were mined and 12 dropped; 10,
+    // 11 and 13 are still pending, and the node reports 10 as the next nonce.
+    chain := stubLookup{
+        mined:   map[common.Hash]bool{sent[8].Hash(): true, replaced.Hash(): true},
+        pending: map[common.Hash]bool{sent[10].Hash(): true, sent[11].Hash(): true, sent[13].Hash(): true},
+    }
+    
+    store, err = openNonceStore(path, quietLogger())
+    if err != nil {
+        t.Fatalf("reopen store: %v", err)
+    }
+    saved := store.saved(account)
+    if saved.Nonce != 14 || len(saved.Pending) != 7 {
+        t.Fatalf("saved nonce %d with %d pending, want 14 with 7", saved.Nonce, len(saved.Pending))
+    }
+    after, err := newNonceManager(ctx, fixedNonce(10), store, account)
+    if err != nil {
+        t.Fatalf("nonce manager: %v", err)
+    }
+    if err := after.restore(ctx, chain, saved, quietLogger()); err != nil {
+        t.Fatalf("restore: %v", err)
+    }
+    
+    // 10 and 11 are skipped, the dropped 12 is reused and 13 kept in flight.
+    if got := after.reserve(); got != 12 {
+        t.Fatalf("first nonce after restart = %d, want 12", got)
+    }
+    store, err = openNonceStore(path, quietLogger())
+    if err != nil {
+        t.Fatalf("reopen store: %v", err)
+    }
+    want := walletNonces{
+        Nonce: 13,
+        Pending: []pendingTx{
+            {Hash: sent[10].Hash(), Nonce: 10},
+            {Hash: sent[11].Hash(), Nonce: 11},
+            {Hash: sent[13].Hash(), Nonce: 13},
+        },
+    }
+    if got := store.saved(account); !reflect.DeepEqual(got, want) {
+        t.Fatalf("saved state = %+v, want %+v", got, want)
+    }
+}
+
+func TestNonceRestoreLookupFailure(t *testing.T) {
+    ctx := context.Background()
+    account := common.HexToAddress("0x000000000000000000000000000000000000a11c")
+    n, err := newNonceManager(ctx, fixedNonce(5), nil, account)
+    if err != nil {
+        t.Fatalf("nonce manager: %v", err)
+    }
+    
+    saved := walletNonces{Nonce: 6, Pending: []pendingTx{{Hash: common.HexToHash("0x05"), Nonce: 5}}}
+    chain := stubLookup{err: errors.New("connection refused")}
+    if err := n.restore(ctx, chain, saved, quietLogger()); err == nil {
+        t.Fatal("restore succeeded without reaching the node")
+    }
+    if got := n.reserve(); got != 5 {
+        t.Fatalf("nonce after a failed restore = %d, want the chain's 5", got)
+    }
+}
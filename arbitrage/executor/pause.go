@@ -0,0 +1,60 @@
+package executor
+
+import (
+    "sort"
+)
+
+// Sources that can pause trading. Trading stays paused while any of them
+// holds a pause.
+const (
+    PauseSourceAPI  = "api"
+    PauseSourceFile = "file"
+)
+
+// Pause stops executing new opportunities. Opportunities keep being drained
+// from the detector and are counted as rejected until Resume; executions
+// already in flight run to completion.
+func (e *Executor) Pause() {
+    e.setPaused(PauseSourceAPI, true)
+}
+
+// Resume lifts a pause set by Pause. Trading stays paused while the kill
+// switch file is present.
+func (e *Executor) Resume() {
+    e.setPaused(PauseSourceAPI, false)
+}
+
+// setPaused sets or clears the pause held by source and reports the sources
+// still holding one.
+func (e *Executor) setPaused(source string, paused bool) {
+    e.pauseMu.Lock()
+    defer e.pauseMu.Unlock()
+    
+    if e.pausedBy == nil {
+        e.pausedBy = make(map[string]bool)
+    }
+    if paused {
+        e.pausedBy[source] = true
+    } else {
+        delete(e.pausedBy, source)
+    }
+    
+    sources := make([]string, 0, len(e.pausedBy))
+    for s := range e.pausedBy {
+        sources = append(sources, s)
+    }
+    sort.Strings(sources)
+    
+    e.paused.Store(len(sources) > 0)
+    e.monitor.RecordPaused(sources)
+    
+    log := e.logger.WithField("source", source)
+    switch {
+    case paused:
+        log.Warn("Trading paused")
+    case len(sources) > 0:
+        log.WithField("paused_by", sources).Warn("Pause lifted, trading still paused")
+    default:
+        log.Warn("Trading resumed")
+    }
+}
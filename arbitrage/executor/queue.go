@@ -0,0 +1,138 @@
+package executor
+
+import (
+    "container/heap"
+    "context"
+    "errors"
+    "math/big"
+    "time"
+
+    "github.com/hypercore-suite/arbitrage/trade"
+)
+
+// errQueueClosed is returned by nextOpportunity once the detector has closed
+// the channel and nothing is left queued.
+var errQueueClosed = errors.New("opportunity queue closed")
+
+// queuedOpportunity is an opportunity waiting for a wallet, scored by its
+// estimated net profit.
+type queuedOpportunity struct {
+    opp   *trade.Opportunity
+    score *big.Int
+}
+
+// opportunityQueue orders waiting opportunities by score, highest first. Of
+// two equal scores the older goes first, as it is closer to going stale. It
+// is only used from Start's goroutine and is not safe for concurrent use.
+type opportunityQueue []queuedOpportunity
+
+func (q opportunityQueue) Len() int { return len(q) }
+
+func (q opportunityQueue) Less(i, j int) bool {
+    if c := q[i].score.Cmp(q[j].score); c != 0 {
+        return c > 0
+    }
+    return q[i].opp.Timestamp.Before(q[j].opp.Timestamp)
+}
+
+func (q opportunityQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *opportunityQueue) Push(x any) { *q = append(*q, x.(queuedOpportunity)) }
+
+func (q *opportunityQueue) Pop() any {
+    old := *q
+    last := old[len(old)-1]
+    *q = old[:len(old)-1]
+    return last
+}
+
+// evictStale removes and returns every queued opportunity older than maxAge
+// at now.
+func (q *opportunityQueue) evictStale(now time.Time, maxAge time.Duration) []*trade.Opportunity {
+    var stale []*trade.Opportunity
+    kept := (*q)[:0]
+    for _, item := range *q {
+        if now.Sub(item.opp.Timestamp) > maxAge {
+            stale = append(stale, item.opp)
+            continue
+        }
+        kept = append(kept, item)
+    }
+    *q = kept
+    if stale != nil {
+        heap.Init(q)
+    }
+    return stale
+}
+
+// score estimates opp's net profit on its most profitable route at the
+// default gas limit and the last gas price, before any chain calls. Kinds
+// the executor cannot trade score zero; they are rejected once dequeued.
+func (e *Executor) score(opp *trade.Opportunity) *big.Int {
+    if opp.Kind != trade.KindPerpSpot {
+        return new(big.Int)
+    }
+    var best *big.Int
+    gasPrice := e.lastGasPrice()
+    for _, r := range e.routes {
+        profit, _ := e.simulateExecution(opp, r, e.defaultGasLimit, gasPrice, nil)
+        if best == nil || profit.Cmp(best) > 0 {
+            best = profit
+        }
+    }
+    return best
+}
+
+// nextOpportunity moves everything waiting on opportunities into the queue
+// and returns the highest-scoring opportunity that is not yet stale,
+// blocking until one arrives when the queue is empty. Stale opportunities
+// are rejected as they are evicted. It returns ctx's error when ctx is done
+// and errQueueClosed once the channel is closed and the queue is empty.
+func (e *Executor) nextOpportunity(ctx context.Context, opportunities <-chan *trade.Opportunity) (*trade.Opportunity, error) {
+    for {
+        closed := e.enqueueWaiting(opportunities)
+        for _, opp := range e.queue.evictStale(time.Now(), e.MaxOpportunityAge) {
+            e.logger.WithFields(opp.LogFields()).Debug("Queued opportunity went stale, evicting")
+            e.reject(opp, RejectStale)
+        }
+        if e.queue.Len() > 0 {
+            return heap.Pop(&e.queue).(queuedOpportunity).opp, nil
+        }
+        if closed {
+            return nil, errQueueClosed
+        }
+        
+        select {
+        case <-ctx.Done():
+            return nil, ctx.Err()
+        case opp, ok := <-opportunities:
+            if !ok {
+                return nil, errQueueClosed
+            }
+            e.enqueue(opp)
+        }
+    }
+}
+
+// enqueueWaiting queues every opportunity already on the channel without
+// blocking and reports whether the channel is closed.
+func (e *Executor) enqueueWaiting(opportunities <-chan *trade.Opportunity) bool {
+    for {
+        select {
+        case opp, ok := <-opportunities:
+            if !ok {
+                return true
+            }
+            e.enqueue(opp)
+        default:
+            return false
+        }
+    }
+}
+
+func (e *Executor) enqueue(opp *trade.Opportunity) {
+    if opp == nil {
+        return
+    }
+    heap.Push(&e.queue, queuedOpportunity{opp: opp, score: e.score(opp)})
+}
\ No newline at end of file
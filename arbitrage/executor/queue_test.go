@@ -0,0 +1,85 @@
+package executor
+
+import (
+    "container/heap"
+    "context"
+    "errors"
+    "math/big"
+    "reflect"
+    "sort"
+    "testing"
+    "time"
+
+    "github.com/hypercore-suite/arbitrage/trade"
+)
+
+func TestNextOpportunityOrder(t *testing.T) {
+    e, monitor := newTestExecutor(t, newStubNode(t), nil)
+    now := time.Now()
+    
+    // Each trades one unit, so its net spread is its gross profit.
+    opportunity := func(id string, netSpread int64, age time.Duration) *trade.Opportunity {
+        opp := testOpportunity()
+        opp.ID = id
+        opp.NetSpread = big.NewInt(netSpread)
+        opp.Timestamp = now.Add(-age)
+        return opp
+    }
+    cycle := opportunity("cycle", 90000000, 0)
+    cycle.Kind = trade.KindCycle
+    
+    opportunities := make(chan *trade.Opportunity, 6)
+    opportunities <- opportunity("low", 20000000, 0)
+    opportunities <- opportunity("equal-newer", 50000000, 0)
+    opportunities <- cycle
+    opportunities <- opportunity("stale", 99000000, time.Second)
+    opportunities <- opportunity("equal-older", 50000000, 100*time.Millisecond)
+    opportunities <- opportunity("high", 60000000, 0)
+    close(opportunities)
+    
+    var got []string
+    for {
+        opp, err := e.nextOpportunity(context.Background(), opportunities)
+        if errors.Is(err, errQueueClosed) {
+            break
+        }
+        if err != nil {
+            t.Fatalf("nextOpportunity: %v", err)
+        }
+        got = append(got, opp.ID)
+    }
+    
+    want := []string{"high", "equal-older", "equal-newer", "low", "cycle"}
+    if !reflect.DeepEqual(got, want) {
+        t.Fatalf("dequeued %v, want %v", got, want)
+    }
+    if n := monitor.rejected(RejectStale); n != 1 {
+        t.Fatalf("rejected %d stale opportunities, want the one evicted", n)
+    }
+}
+
+func TestEvictStale(t *testing.T) {
+    now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+    var q opportunityQueue
+    for i, age := range []time.Duration{0, 600 * time.Millisecond, 500 * time.Millisecond, 2 * time.Second} {
+        opp := testOpportunity()
+        opp.ID = string(rune('a' + i))
+        opp.Timestamp = now.Add(-age)
+        heap.Push(&q, queuedOpportunity{opp: opp, score: big.NewInt(int64(i))})
+    }
+    
+    var evicted []string
+    for _, opp := range q.evictStale(now, 500*time.Millisecond) {
+        evicted = append(evicted, opp.ID)
+    }
+    sort.Strings(evicted)
+    if want := []string{"b", "d"}; !reflect.DeepEqual(evicted, want) {
+        t.Fatalf("evicted %v, want %v", evicted, want)
+    }
+    if q.Len() != 2 {
+        t.Fatalf("%d left queued, want 2", q.Len())
+    }
+    if stale := q.evictStale(now, 500*time.Millisecond); stale != nil {
+        t.Fatalf("evicted %d more on a second pass", len(stale))
+    }
+}
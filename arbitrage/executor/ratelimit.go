@@ -0,0 +1,72 @@
+package executor
+
+import (
+    "sync"
+    "time"
+)
+
+// tradeRateWindow is how far back the reported trade rate looks.
+const tradeRateWindow = time.Minute
+
+// executionLimiter spaces executions at least interval apart across every
+// asset and wallet, as a blunt guard against overtrading in volatile markets
+// and against venue rate limits. An opportunity arriving too soon is skipped
+// rather than held back, so only the best of a burst trades. It also counts
+// executions over the last tradeRateWindow for the trade rate metric; zero
+// interval disables the limit but not the count.
+type executionLimiter struct {
+    interval time.Duration
+    
+    mu     sync.Mutex
+    last   time.Time
+    recent []time.Time
+}
+
+func newExecutionLimiter(interval time.Duration) *executionLimiter {
+    return &executionLimiter{interval: interval}
+}
+
+// remaining returns how long until the next execution may start at now, or
+// zero.
+func (l *executionLimiter) remaining(now time.Time) time.Duration {
+    l.mu.Lock()
+    defer l.mu.Unlock()
+    
+    if l.interval <= 0 || l.last.IsZero() {
+        return 0
+    }
+    if wait := l.interval - now.Sub(l.last); wait > 0 {
+        return wait
+    }
+    return 0
+}
+
+// claim starts an execution at now unless one started less than interval
+// before, reporting whether it did.
+func (l *executionLimiter) claim(now time.Time) bool {
+    l.mu.Lock()
+    defer l.mu.Unlock()
+    
+    if l.interval > 0 && !l.last.IsZero() && now.Sub(l.last) < l.interval {
+        return false
+    }
+    l.last = now
+    l.recent = append(l.recent, now)
+    return true
+}
+
+// rate returns the executions started per minute over the tradeRateWindow
+// before now.
+func (l *executionLimiter) rate(now time.Time) float64 {
+    l.mu.Lock()
+    defer l.mu.Unlock()
+    
+    kept := l.recent[:0]
+    for _, t := range l.recent {
+        if now.Sub(t) < tradeRateWindow {
+            kept = append(kept, t)
+        }
+    }
+    l.recent = kept
+    return float64(len(kept)) / tradeRateWindow.Minutes()
+}
\ No newline at end of file
@@ -0,0 +1,62 @@
+package executor
+
+import (
+    "testing"
+    "time"
+)
+
+func TestExecutionLimiterSpacing(t *testing.T) {
+    now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+    l := newExecutionLimiter(10 * time.Second)
+    
+    if got := l.remaining(now); got != 0 {
+        t.Fatalf("remaining before any execution = %v, want 0", got)
+    }
+    if !l.claim(now) {
+        t.Fatal("first execution refused")
+    }
+    
+    steps := []struct {
+        name          string
+        after         time.Duration
+        wantRemaining time.Duration
+        wantClaim     bool
+    }{
+        {"right after", 0, 10 * time.Second, false},
+        {"inside the interval", 4 * time.Second, 6 * time.Second, false},
+        {"just inside the interval", 10*time.Second - time.Millisecond, time.Millisecond, false},
+        {"interval elapsed", 10 * time.Second, 0, true},
+        // Refused claims do not push the next slot back; the interval runs
+        // from the last execution that started, now at 10s.
+        {"after the new execution", 15 * time.Second, 5 * time.Second, false},
+        {"next interval elapsed", 25 * time.Second, 0, true},
+    }
+    for _, step := range steps {
+        at := now.Add(step.after)
+        if got := l.remaining(at); got != step.wantRemaining {
+            t.Fatalf("%s: remaining = %v, want %v", step.name, got, step.wantRemaining)
+        }
+        if got := l.claim(at); got != step.wantClaim {
+            t.Fatalf("%s: claim = %v, want %v", step.name, got, step.wantClaim)
+        }
+    }
+    
+    // Three executions started within the last minute at 25s; by 65s the
+    // first has left the window.
+    if got := l.rate(now.Add(25 * time.Second)); got != 3 {
+        t.Fatalf("rate = %v, want 3 per minute", got)
+    }
+    if got := l.rate(now.Add(65 * time.Second)); got != 2 {
+        t.Fatalf("rate = %v, want 2 per minute", got)
+    }
+    
+    unlimited := newExecutionLimiter(0)
+    for i := 0; i < 3; i++ {
+        if !unlimited.claim(now) || unlimited.remaining(now) != 0 {
+            t.Fatal("zero interval spaced executions")
+        }
+    }
+    if got := unlimited.rate(now); got != 3 {
+        t.Fatalf("rate without a limit = %v, want 3 per minute", got)
+    }
+}
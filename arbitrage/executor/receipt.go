@@ -0,0 +1,42 @@
+package executor
+
+import (
+    "context"
+    "errors"
+    "time"
+
+    "github.com/ethereum/go-ethereum"
+    "github.com/ethereum/go-ethereum/core/types"
+)
+
+const receiptPollInterval = 500 * time.Millisecond
+
+// waitForReceipt polls for the receipt of tx, sent from w, until it is
+// mined, the receipt timeout elapses, or ctx is cancelled.
+func (e *Executor) waitForReceipt(ctx context.Context, w *wallet, tx *types.Transaction) (*types.Receipt, error) {
+    hash := tx.Hash()
+    ctx, cancel := context.WithTimeout(ctx, e.receiptTimeout)
+    defer cancel()
+    
+    ticker := time.NewTicker(receiptPollInterval)
+    defer ticker.Stop()
+    
+    for {
+        start := time.Now()
+        receipt, err := e.client.TransactionReceipt(ctx, hash)
+        e.monitor.RecordRPCLatency("getTransactionReceipt", time.Since(start))
+        if err == nil {
+            w.nonces.settle(tx.Nonce())
+            return receipt, nil
+        }
+        if !errors.Is(err, ethereum.NotFound) {
+            e.logger.WithError(err).WithField("tx_hash", hash.Hex()).Debug("Receipt lookup failed")
+        }
+        
+        select {
+        case <-ctx.Done():
+            return nil, ctx.Err()
+        case <-ticker.C:
+        }
+    }
+}
\ No newline at end of file
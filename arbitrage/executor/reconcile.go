@@ -0,0 +1,138 @@
+package executor
+
+import (
+    "context"
+    "fmt"
+    "math/big"
+    "sync"
+    "time"
+
+    "github.com/hypercore-suite/arbitrage/pricing"
+    "github.com/hypercore-suite/arbitrage/trade"
+    "github.com/sirupsen/logrus"
+)
+
+// profitReconciler sums the net profit recorded for settled trades between
+// balance snapshots, so it can be compared with how much the wallets'
+// balances actually moved. Deposits, withdrawals and profit sweeps into the
+// settlement token show up in the gap too.
+type profitReconciler struct {
+    interval  time.Duration
+    tolerance *big.Int
+    
+    mu       sync.Mutex
+    recorded *big.Int
+}
+
+func newProfitReconciler(interval time.Duration, tolerance int64) *profitReconciler {
+    return &profitReconciler{
+        interval:  interval,
+        tolerance: big.NewInt(tolerance),
+        recorded:  new(big.Int),
+    }
+}
+
+// add records the realized net profit of a settled trade, in 1e8 USD.
+func (r *profitReconciler) add(pnl *big.Int) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    r.recorded.Add(r.recorded, pnl)
+}
+
+// take returns the profit recorded since the last call and starts a new
+// window.
+func (r *profitReconciler) take() *big.Int {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    
+    recorded := r.recorded
+    r.recorded = new(big.Int)
+    return recorded
+}
+
+// watchReconciliation snapshots the wallets' value every interval until ctx
+// is cancelled and compares each window's balance delta with the profit
+// recorded over it. Trades settling while a snapshot is taken may fall into
+// the neighbouring window.
+func (e *Executor) watchReconciliation(ctx context.Context) {
+    if e.reconcile.interval <= 0 {
+        return
+    }
+    
+    ticker := time.NewTicker(e.reconcile.interval)
+    defer ticker.Stop()
+    
+    baseline, err := e.walletValue(ctx)
+    e.reconcile.take()
+    if err != nil {
+        e.logger.WithError(err).Warn("Reconciliation snapshot failed")
+    }
+    
+    for {
+        select {
+        case <-ctx.Done():
+            return
+        case <-ticker.C:
+        }
+        
+        value, err := e.walletValue(ctx)
+        recorded := e.reconcile.take()
+        if err != nil {
+            e.logger.WithError(err).Warn("Reconciliation snapshot failed")
+            baseline = nil
+            continue
+        }
+        if baseline != nil {
+            e.reconcileWindow(recorded, new(big.Int).Sub(value, baseline))
+        }
+        baseline = value
+    }
+}
+
+// reconcileWindow reports one window's recorded profit against the actual
+// balance delta, warning when they diverge by more than the tolerance.
+func (e *Executor) reconcileWindow(recorded, actual *big.Int) {
+    gap := new(big.Int).Sub(actual, recorded)
+    e.monitor.RecordReconciliation(recorded, actual)
+    
+    log := e.logger.WithFields(logrus.Fields{
+        "window":          e.reconcile.interval,
+        "recorded_profit": recorded,
+        "balance_delta":   actual,
+        "gap":             gap,
+    })
+    if e.reconcile.tolerance.Sign() > 0 && new(big.Int).Abs(gap).Cmp(e.reconcile.tolerance) > 0 {
+        log.Warn("Recorded profit diverges from wallet balances")
+        return
+    }
+    log.Info("Profit reconciled against wallet balances")
+}
+
+// walletValue returns the combined value of every wallet in 1e8 USD: its
+// balance of the capital token, when capital is held in one, plus its native
+// balance at the native token price. Without a native price it fails with
+// errNoNativePrice.
+func (e *Executor) walletValue(ctx context.Context) (*big.Int, error) {
+    price := e.nativeTokenPrice()
+    if price == nil || price.Sign() <= 0 {
+        return nil, errNoNativePrice
+    }
+    
+    total := new(big.Int)
+    for _, w := range e.wallets.wallets {
+        if e.capital.token != nil {
+            token, err := e.tokenBalance(ctx, *e.capital.token, w.address)
+            if err != nil {
+                return nil, fmt.Errorf("wallet %s: %w", w.address.Hex(), err)
+            }
+            total.Add(total, pricing.Rescale(token, e.capital.decimals, trade.PriceDecimals))
+        }
+        
+        native, err := e.client.BalanceAt(ctx, w.address, nil)
+        if err != nil {
+            return nil, fmt.Errorf("wallet %s: fetch native balance: %w", w.address.Hex(), classifyCallError(err))
+        }
+        total.Add(total, weiToUSD(native, price, pricing.RoundHalfUp))
+    }
+    return total, nil
+}
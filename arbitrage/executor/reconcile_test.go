@@ -0,0 +1,51 @@
+package executor
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "math/big"
+    "testing"
+
+    "github.com/ethereum/go-ethereum/common/hexutil"
+    "github.com/hypercore-suite/arbitrage/config"
+)
+
+func TestWalletValue(t *testing.T) {
+    // The wallet holds 1000 native tokens and, where capital is in a token,
+    // 5000 of a 6 decimal stablecoin.
+    tests := []struct {
+        name    string
+        token   string
+        price   int64
+        want    *big.Int
+        wantErr error
+    }{
+        {"native capital", "", 2000000000, big.NewInt(2000000000000), nil},
+        {"token capital", "0x00000000000000000000000000000000000070c3", 2000000000, big.NewInt(2500000000000), nil},
+        {"native capital without a price", "", 0, nil, errNoNativePrice},
+        {"token capital without a price", "0x00000000000000000000000000000000000070c3", 0, nil, errNoNativePrice},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            node := newStubNode(t)
+            node.handle("eth_call", func([]json.RawMessage) (interface{}, error) {
+                return hexutil.Bytes(big.NewInt(5000000000).FillBytes(make([]byte, 32))), nil
+            })
+            e, _ := newTestExecutor(t, node, func(cfg *config.Config) {
+                cfg.Executor.Capital.Token = tt.token
+                cfg.Executor.NativeTokenPrice = tt.price
+                cfg.Executor.DryRun = tt.price == 0
+            })
+            
+            got, err := e.walletValue(context.Background())
+            if !errors.Is(err, tt.wantErr) {
+                t.Fatalf("walletValue error = %v, want %v", err, tt.wantErr)
+            }
+            if tt.want != nil && got.Cmp(tt.want) != 0 {
+                t.Errorf("walletValue = %s, want %s", got, tt.want)
+            }
+        })
+    }
+}
@@ -0,0 +1,38 @@
+package executor
+
+import (
+    "github.com/hypercore-suite/arbitrage/trade"
+)
+
+// Rejection is why an opportunity was turned down before a transaction was
+// sent. The zero value means the opportunity passed the check.
+type Rejection string
+
+const (
+    RejectPaused          Rejection = "paused"
+    RejectAssetDisabled   Rejection = "asset_disabled"
+    RejectCooldown        Rejection = "cooldown"
+    RejectRateLimited     Rejection = "rate_limited"
+    RejectBreakerOpen     Rejection = "breaker_open"
+    RejectGasBalanceLow   Rejection = "gas_balance_low"
+    RejectStale           Rejection = "stale"
+    RejectSpreadTooSmall  Rejection = "spread_too_small"
+    RejectBalanceUnknown  Rejection = "balance_unavailable"
+    RejectBalanceTooLow   Rejection = "insufficient_balance"
+    RejectLotSize         Rejection = "lot_size"
+    RejectExposureUnknown Rejection = "exposure_unavailable"
+    RejectExposureLimit   Rejection = "exposure_limit"
+    RejectGasTooHigh      Rejection = "gas_too_high"
+    RejectDailyCap        Rejection = "daily_cap"
+    RejectDryRunReverted  Rejection = "dry_run_reverted"
+    RejectRPCUnavailable  Rejection = "rpc_unavailable"
+    RejectUnprofitable    Rejection = "insufficient_profit"
+    RejectApprovalFailed  Rejection = "approval_failed"
+    RejectDuplicate       Rejection = "duplicate"
+    RejectUnsupported     Rejection = "unsupported_kind"
+)
+
+// reject records that opp was turned down for reason.
+func (e *Executor) reject(opp *trade.Opportunity, reason Rejection) {
+    e.monitor.RecordRejection(opp.Asset, string(reason))
+}
\ No newline at end of file
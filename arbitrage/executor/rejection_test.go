@@ -0,0 +1,235 @@
+package executor
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "math/big"
+    "testing"
+    "time"
+
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/hypercore-suite/arbitrage/config"
+    "github.com/hypercore-suite/arbitrage/trade"
+)
+
+// disabledAssets is an AssetFilter turning away the assets it holds.
+type disabledAssets map[uint32]bool
+
+func (d disabledAssets) Enabled(asset uint32) bool {
+    return !d[asset]
+}
+
+func TestExecuteRejections(t *testing.T) {
+    token := common.HexToAddress("0x00000000000000000000000000000000000070c3")
+    
+    // Every row trades the test opportunity on paper with a profitable dry
+    // run unless it says otherwise, so only the check it sets up fails.
+    tests := []struct {
+        name      string
+        configure func(*config.Config)
+        setup     func(e *Executor, node *stubNode, opp *trade.Opportunity)
+        want      Rejection
+    }{
+        {
+            name:  "paused",
+            setup: func(e *Executor, _ *stubNode, _ *trade.Opportunity) { e.Pause() },
+            want:  RejectPaused,
+        },
+        {
+            name:  "asset disabled",
+            setup: func(e *Executor, _ *stubNode, _ *trade.Opportunity) { e.Filter = disabledAssets{0: true} },
+            want:  RejectAssetDisabled,
+        },
+        {
+            name:      "within the minimum interval",
+            configure: func(cfg *config.Config) { cfg.Executor.MinExecutionInterval = time.Hour },
+            setup:     func(e *Executor, _ *stubNode, _ *trade.Opportunity) { e.limiter.claim(time.Now()) },
+            want:      RejectRateLimited,
+        },
+        {
+            name:      "asset cooling down",
+            configure: func(cfg *config.Config) { cfg.Executor.FailureCooldown = time.Hour },
+            setup:     func(e *Executor, _ *stubNode, _ *trade.Opportunity) { e.cooldowns.record(0, false, time.Now()) },
+            want:      RejectCooldown,
+        },
+        {
+            name: "breaker open",
+            setup: func(e *Executor, _ *stubNode, _ *trade.Opportunity) {
+                for !e.breaker.record(false, new(big.Int), time.Now()) {
+                }
+            },
+            want: RejectBreakerOpen,
+        },
+        {
+            name:      "wallet low on gas",
+            configure: func(cfg *config.Config) { cfg.Executor.DryRun = false },
+            setup:     func(e *Executor, _ *stubNode, _ *trade.Opportunity) { e.wallets.wallets[0].lowGas.Store(true) },
+            want:      RejectGasBalanceLow,
+        },
+        {
+            name:  "unsupported kind",
+            setup: func(_ *Executor, _ *stubNode, opp *trade.Opportunity) { opp.Kind = trade.KindCycle },
+            want:  RejectUnsupported,
+        },
+        {
+            name:  "stale",
+            setup: func(_ *Executor, _ *stubNode, opp *trade.Opportunity) { opp.Timestamp = time.Now().Add(-time.Minute) },
+            want:  RejectStale,
+        },
+        {
+            name:  "spread too small",
+            setup: func(_ *Executor, _ *stubNode, opp *trade.Opportunity) { opp.SpreadBps = 1 },
+            want:  RejectSpreadTooSmall,
+        },
+        {
+            name: "balance unknown",
+            setup: func(_ *Executor, node *stubNode, _ *trade.Opportunity) {
+                node.handle("eth_getBalance", func([]json.RawMessage) (interface{}, error) {
+                    return nil, errors.New("header not found")
+                })
+            },
+            want: RejectBalanceUnknown,
+        },
+        {
+            name: "balance too low",
+            setup: func(_ *Executor, node *stubNode, _ *trade.Opportunity) {
+                node.handle("eth_getBalance", func([]json.RawMessage) (interface{}, error) {
+                    return "0x0", nil
+                })
+            },
+            want: RejectBalanceTooLow,
+        },
+        {
+            name: "below the lot size",
+            configure: func(cfg *config.Config) {
+                cfg.Executor.LotSizes = map[uint32]config.LotSizeConfig{0: {Min: 1000000000}}
+            },
+            want: RejectLotSize,
+        },
+        {
+            name:      "exposure unknown",
+            configure: func(cfg *config.Config) { cfg.Executor.MaxExposure = map[uint32]int64{0: 500000000} },
+            setup: func(_ *Executor, node *stubNode, _ *trade.Opportunity) {
+                node.stubExposure(0, 0, errors.New("header not found"))
+            },
+            want: RejectExposureUnknown,
+        },
+        {
+            name:      "exposure limit",
+            configure: func(cfg *config.Config) { cfg.Executor.MaxExposure = map[uint32]int64{0: 500000000} },
+            setup:     func(_ *Executor, node *stubNode, _ *trade.Opportunity) { node.stubExposure(0, 500000000, nil) },
+            want:      RejectExposureLimit,
+        },
+        {
+            name:      "daily volume cap",
+            configure: func(cfg *config.Config) { cfg.Breaker.DailyMaxVolume = 1 },
+            want:      RejectDailyCap,
+        },
+        {
+            name: "gas too high",
+            setup: func(_ *Executor, node *stubNode, _ *trade.Opportunity) {
+                node.handle("eth_gasPrice", func([]json.RawMessage) (interface{}, error) {
+                    return "0x6fc23ac00", nil // 30 gwei
+                })
+            },
+            want: RejectGasTooHigh,
+        },
+        {
+            name: "dry run reverted",
+            setup: func(_ *Executor, node *stubNode, _ *trade.Opportunity) {
+                node.handle("eth_call", func([]json.RawMessage) (interface{}, error) {
+                    return nil, errors.New("execution reverted")
+                })
+            },
+            want: RejectDryRunReverted,
+        },
+        {
+            name: "dry run unavailable",
+            setup: func(_ *Executor, node *stubNode, _ *trade.Opportunity) {
+                node.handle("eth_call", func([]json.RawMessage) (interface{}, error) {
+                    return nil, errors.New("header not found")
+                })
+            },
+            want: RejectRPCUnavailable,
+        },
+        {
+            name:      "unprofitable",
+            configure: func(cfg *config.Config) { cfg.Executor.MinNetProfit = 100000000 },
+            want:      RejectUnprofitable,
+        },
+        {
+            name:  "duplicate",
+            setup: func(e *Executor, _ *stubNode, opp *trade.Opportunity) { e.replay.claim(opp, time.Now()) },
+            want:  RejectDuplicate,
+        },
+        {
+            name: "approval failed",
+            configure: func(cfg *config.Config) {
+                cfg.Executor.DryRun = false
+                cfg.Executor.Approvals.Tokens = []string{token.Hex()}
+            },
+            setup: func(_ *Executor, node *stubNode, _ *trade.Opportunity) {
+                node.handle("eth_call", func(params []json.RawMessage) (interface{}, error) {
+                    var call struct {
+                        To common.Address `json:"to"`
+                    }
+                    if err := json.Unmarshal(params[0], &call); err != nil {
+                        return nil, err
+                    }
+                    if call.To == token {
+                        return nil, errors.New("header not found")
+                    }
+                    return dryRunResult(40000000)
+                })
+            },
+            want: RejectApprovalFailed,
+        },
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            node := newStubNode(t)
+            node.reportProfit(40000000)
+            e, monitor := newTestExecutor(t, node, func(cfg *config.Config) {
+                cfg.Executor.DryRun = true
+                cfg.Executor.WarmUp = 0
+                cfg.Executor.NativeTokenPrice = 2000000000
+                if tt.configure != nil {
+                    tt.configure(cfg)
+                }
+            })
+            opp := testOpportunity()
+            if tt.setup != nil {
+                tt.setup(e, node, opp)
+            }
+            
+            e.execute(context.Background(), e.wallets.wallets[0], opp)
+            
+            if got := monitor.rejected(tt.want); got != 1 {
+                t.Errorf("rejected for %q %d times, want once (rejections %v)", tt.want, got, monitor.rejections)
+            }
+            if len(monitor.rejections) != 1 {
+                t.Errorf("rejections = %v, want only %q", monitor.rejections, tt.want)
+            }
+            if monitor.dryRuns != 0 || len(node.transactions()) != 0 {
+                t.Errorf("rejected opportunity was traded: %d dry runs, %d transactions", monitor.dryRuns, len(node.transactions()))
+            }
+        })
+    }
+    
+    t.Run("passes every check", func(t *testing.T) {
+        node := newStubNode(t)
+        node.reportProfit(40000000)
+        e, monitor := newTestExecutor(t, node, func(cfg *config.Config) {
+            cfg.Executor.DryRun = true
+            cfg.Executor.NativeTokenPrice = 2000000000
+        })
+        
+        e.execute(context.Background(), e.wallets.wallets[0], testOpportunity())
+        
+        if len(monitor.rejections) != 0 || monitor.dryRuns != 1 {
+            t.Errorf("rejections = %v, dry runs = %d, want none and one", monitor.rejections, monitor.dryRuns)
+        }
+    })
+}
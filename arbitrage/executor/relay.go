@@ -0,0 +1,69 @@
+package executor
+
+import (
+    "context"
+    "fmt"
+    "time"
+
+    "github.com/ethereum/go-ethereum/common/hexutil"
+    "github.com/ethereum/go-ethereum/core/types"
+    "github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+    routePublic  = "public"
+    routePrivate = "private"
+)
+
+// privateRelay submits signed transactions to an order-flow endpoint that
+// keeps them out of the public mempool.
+type privateRelay struct {
+    url     string
+    client  *rpc.Client
+    timeout time.Duration
+}
+
+func dialRelay(url string, timeout time.Duration) (*privateRelay, error) {
+    client, err := rpc.DialContext(context.Background(), url)
+    if err != nil {
+        return nil, fmt.Errorf("dial private relay %s: %w", url, err)
+    }
+    return &privateRelay{url: url, client: client, timeout: timeout}, nil
+}
+
+// send calls eth_sendPrivateTransaction with the raw signed transaction.
+func (r *privateRelay) send(ctx context.Context, tx *types.Transaction) error {
+    raw, err := tx.MarshalBinary()
+    if err != nil {
+        return err
+    }
+    
+    ctx, cancel := context.WithTimeout(ctx, r.timeout)
+    defer cancel()
+    
+    var result interface{}
+    params := map[string]interface{}{"tx": hexutil.Encode(raw)}
+    return r.client.CallContext(ctx, &result, "eth_sendPrivateTransaction", params)
+}
+
+// submit sends tx through the private relay when private is set and a relay
+// is configured, retrying a transient failure once before falling back to
+// public broadcast. It returns the route the transaction went out on, and
+// errors are classified with classifySendError.
+func (e *Executor) submit(ctx context.Context, tx *types.Transaction, private bool) (string, error) {
+    start := time.Now()
+    defer func() { e.monitor.RecordRPCLatency("sendTransaction", time.Since(start)) }()
+    
+    if private && e.relay != nil {
+        err := classifySendError(e.relay.send(ctx, tx))
+        if err != nil && transient(err) {
+            err = classifySendError(e.relay.send(ctx, tx))
+        }
+        if err == nil {
+            return routePrivate, nil
+        }
+        e.logger.WithError(err).WithField("tx_hash", tx.Hash().Hex()).Warn("Private relay failed, falling back to public broadcast")
+    }
+    
+    return routePublic, classifySendError(e.client.SendTransaction(ctx, tx))
+}
@@ -0,0 +1,89 @@
+package executor
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "testing"
+
+    "github.com/ethereum/go-ethereum/common/hexutil"
+    "github.com/ethereum/go-ethereum/core/types"
+    "github.com/hypercore-suite/arbitrage/config"
+)
+
+// signedTx builds and signs a transaction from e's first wallet.
+func signedTx(t *testing.T, e *Executor) *types.Transaction {
+    t.Helper()
+    
+    tx, err := e.buildTx(context.Background(), e.wallets.wallets[0], testContract, 100000, []byte{0x01}, nil)
+    if err != nil {
+        t.Fatalf("buildTx: %v", err)
+    }
+    signed, err := types.SignTx(tx, types.LatestSignerForChainID(e.chainID), e.wallets.wallets[0].key)
+    if err != nil {
+        t.Fatalf("sign: %v", err)
+    }
+    return signed
+}
+
+func TestSubmitPrivateRelay(t *testing.T) {
+    tests := []struct {
+        name        string
+        private     bool
+        relayErr    error
+        wantRoute   string
+        wantRelayed int
+        wantPublic  int
+    }{
+        {"private through the relay", true, nil, routePrivate, 1, 0},
+        {"public when not private", false, nil, routePublic, 0, 1},
+        {"public after a relay rejection", true, errors.New("bundle rejected"), routePublic, 1, 1},
+        {"public after the relay fails twice", true, errors.New("mempool full"), routePublic, 2, 1},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            node, relay := newStubNode(t), newStubNode(t)
+            var got []map[string]string
+            relay.handle("eth_sendPrivateTransaction", func(params []json.RawMessage) (interface{}, error) {
+                var req map[string]string
+                if len(params) != 1 || json.Unmarshal(params[0], &req) != nil {
+                    t.Errorf("params = %s, want one object", params)
+                }
+                got = append(got, req)
+                if tt.relayErr != nil {
+                    return nil, tt.relayErr
+                }
+                return true, nil
+            })
+            e, _ := newTestExecutor(t, node, func(cfg *config.Config) {
+                cfg.Executor.RelayURL = relay.url
+            })
+            tx := signedTx(t, e)
+            
+            route, err := e.submit(context.Background(), tx, tt.private)
+            if err != nil {
+                t.Fatalf("submit: %v", err)
+            }
+            if route != tt.wantRoute {
+                t.Errorf("route = %s, want %s", route, tt.wantRoute)
+            }
+            if len(got) != tt.wantRelayed {
+                t.Fatalf("relay received %d requests, want %d", len(got), tt.wantRelayed)
+            }
+            if n := node.count("eth_sendRawTransaction"); n != tt.wantPublic {
+                t.Errorf("public broadcasts = %d, want %d", n, tt.wantPublic)
+            }
+            
+            raw, err := tx.MarshalBinary()
+            if err != nil {
+                t.Fatalf("marshal: %v", err)
+            }
+            for _, req := range got {
+                if len(req) != 1 || req["tx"] != hexutil.Encode(raw) {
+                    t.Errorf("relay request = %v, want {tx: %s}", req, hexutil.Encode(raw))
+                }
+            }
+        })
+    }
+}
\ No newline at end of file
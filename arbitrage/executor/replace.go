@@ -0,0 +1,169 @@
+package executor
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "math/big"
+    "time"
+
+    "github.com/ethereum/go-ethereum"
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/ethereum/go-ethereum/core/types"
+    "github.com/hypercore-suite/arbitrage/trade"
+    "github.com/sirupsen/logrus"
+)
+
+const (
+    ReplaceSpeedUp = "speedup"
+    ReplaceCancel  = "cancel"
+    
+    // minReplaceBumpPercent is the smallest fee increase nodes accept for a
+    // same-nonce replacement.
+    minReplaceBumpPercent = 10
+    cancelGasLimit        = 21000
+)
+
+// awaitSettlement waits for tx, or a replacement of it, to be mined. When
+// replaceAfter is set and tx sits unmined that long, it is resent with the
+// same nonce and fees raised by replaceBumpPercent: either as the same call
+// (speed-up) or as a zero-value self-send that cancels it. Replacements
+// repeat every replaceAfter until the fee reaches maxGasPrice. cancelled is
+// true when the confirmed transaction is a cancellation.
+func (e *Executor) awaitSettlement(ctx context.Context, w *wallet, log *logrus.Entry, opp *trade.Opportunity, tx *types.Transaction) (receipt *types.Receipt, cancelled bool, err error) {
+    ctx, cancel := context.WithTimeout(ctx, e.receiptTimeout)
+    defer cancel()
+    
+    sent := []*types.Transaction{tx}
+    cancels := make(map[*types.Transaction]bool)
+    e.addPending(tx.Hash(), opp)
+    defer func() {
+        for _, s := range sent {
+            e.removePending(s.Hash())
+        }
+    }()
+    
+    ticker := time.NewTicker(receiptPollInterval)
+    defer ticker.Stop()
+    
+    lastSent := time.Now()
+    for {
+        for _, s := range sent {
+            start := time.Now()
+            receipt, err := e.client.TransactionReceipt(ctx, s.Hash())
+            e.monitor.RecordRPCLatency("getTransactionReceipt", time.Since(start))
+            if err == nil {
+                w.nonces.settle(s.Nonce())
+                return receipt, cancels[s], nil
+            }
+            if !errors.Is(err, ethereum.NotFound) {
+                log.WithError(err).WithField("tx_hash", s.Hash().Hex()).Debug("Receipt lookup failed")
+            }
+        }
+        
+        latest := sent[len(sent)-1]
+        if e.replaceAfter > 0 && time.Since(lastSent) >= e.replaceAfter && latest.GasFeeCap().Cmp(e.maxGasPrice()) < 0 {
+            replacement, err := e.replace(ctx, w, latest)
+            if err != nil {
+                log.WithError(err).WithField("tx_hash", latest.Hash().Hex()).Warn("Failed to replace stuck transaction")
+            } else {
+                log.WithFields(logrus.Fields{
+                    "tx_hash":       latest.Hash().Hex(),
+                    "replacement":   replacement.Hash().Hex(),
+                    "mode":          e.replaceMode,
+                    "gas_fee_cap":   replacement.GasFeeCap(),
+                    "replace_count": len(sent),
+                }).Warn("Replaced stuck transaction")
+                
+                sent = append(sent, replacement)
+                cancels[replacement] = e.replaceMode == ReplaceCancel
+                e.addPending(replacement.Hash(), opp)
+            }
+            lastSent = time.Now()
+        }
+        
+        select {
+        case <-ctx.Done():
+            return nil, false, ctx.Err()
+        case <-ticker.C:
+        }
+    }
+}
+
+// replace signs and sends a same-nonce replacement for tx.
+func (e *Executor) replace(ctx context.Context, w *wallet, tx *types.Transaction) (*types.Transaction, error) {
+    next, err := replacementTx(tx, e.replaceMode, w.address, e.replaceBumpPercent, e.maxGasPrice())
+    if err != nil {
+        return nil, err
+    }
+    
+    signed, err := types.SignTx(next, types.LatestSignerForChainID(e.chainID), w.key)
+    if err != nil {
+        return nil, fmt.Errorf("sign replacement: %w", err)
+    }
+    if _, err := e.submit(ctx, signed, e.relay != nil); err != nil {
+        return nil, fmt.Errorf("broadcast replacement: %w", err)
+    }
+    w.nonces.track(signed)
+    return signed, nil
+}
+
+// replacementTx builds the unsigned replacement for tx, with every fee field
+// raised by bumpPercent and capped at ceiling. Cancellations send zero value
+// to from with no data. It fails when the cap leaves less than the minimum
+// bump nodes accept.
+func replacementTx(tx *types.Transaction, mode string, from common.Address, bumpPercent uint64, ceiling *big.Int) (*types.Transaction, error) {
+    to, value, gas, data := tx.To(), tx.Value(), tx.Gas(), tx.Data()
+    if mode == ReplaceCancel {
+        to, value, gas, data = &from, new(big.Int), cancelGasLimit, nil
+    }
+    
+    if tx.Type() == types.LegacyTxType {
+        gasPrice, ok := bumpFee(tx.GasPrice(), bumpPercent, ceiling)
+        if !ok {
+            return nil, errors.New("gas price already at the ceiling")
+        }
+        return types.NewTx(&types.LegacyTx{
+            Nonce:    tx.Nonce(),
+            GasPrice: gasPrice,
+            Gas:      gas,
+            To:       to,
+            Value:    value,
+            Data:     data,
+        }), nil
+    }
+    
+    feeCap, ok := bumpFee(tx.GasFeeCap(), bumpPercent, ceiling)
+    if !ok {
+        return nil, errors.New("fee cap already at the ceiling")
+    }
+    tipCap, ok := bumpFee(tx.GasTipCap(), bumpPercent, feeCap)
+    if !ok {
+        return nil, errors.New("tip cannot be raised within the fee cap")
+    }
+    
+    return types.NewTx(&types.DynamicFeeTx{
+        ChainID:   tx.ChainId(),
+        Nonce:     tx.Nonce(),
+        GasTipCap: tipCap,
+        GasFeeCap: feeCap,
+        Gas:       gas,
+        To:        to,
+        Value:     value,
+        Data:      data,
+    }), nil
+}
+
+// bumpFee raises fee by percent, capped at ceiling. ok is false when the
+// capped value is below the minimum replacement bump.
+func bumpFee(fee *big.Int, percent uint64, ceiling *big.Int) (*big.Int, bool) {
+    bumped := new(big.Int).Mul(fee, new(big.Int).SetUint64(100+percent))
+    bumped.Quo(bumped, big.NewInt(100))
+    if bumped.Cmp(ceiling) > 0 {
+        bumped = new(big.Int).Set(ceiling)
+    }
+    
+    required := new(big.Int).Mul(fee, big.NewInt(100+minReplaceBumpPercent))
+    required.Quo(required, big.NewInt(100))
+    return bumped, bumped.Cmp(required) >= 0
+}
\ No newline at end of file
@@ -0,0 +1,83 @@
+package executor
+
+import (
+    "bytes"
+    "context"
+    "encoding/json"
+    "math/big"
+    "testing"
+    "time"
+
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/hypercore-suite/arbitrage/config"
+)
+
+func TestAwaitSettlementReplacesStuckTransaction(t *testing.T) {
+    tests := []struct {
+        name          string
+        mode          string
+        wantCancelled bool
+    }{
+        {"speed-up", ReplaceSpeedUp, false},
+        {"cancel", ReplaceCancel, true},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            node := newStubNode(t)
+            e, _ := newTestExecutor(t, node, func(cfg *config.Config) {
+                cfg.Executor.ReplaceAfter = time.Millisecond
+                cfg.Executor.ReplaceMode = tt.mode
+                cfg.Executor.ReplaceBumpPercent = 20
+                cfg.Executor.ReceiptTimeout = 5 * time.Second
+            })
+            w := e.wallets.wallets[0]
+            stuck := signedTx(t, e)
+            
+            // The original never mines; anything else does at once.
+            node.handle("eth_getTransactionReceipt", func(params []json.RawMessage) (interface{}, error) {
+                var hash common.Hash
+                if err := json.Unmarshal(params[0], &hash); err != nil {
+                    return nil, err
+                }
+                if hash == stuck.Hash() {
+                    return nil, nil
+                }
+                return minedReceipt(hash), nil
+            })
+            
+            log := e.logger.WithField("test", t.Name())
+            receipt, cancelled, err := e.awaitSettlement(context.Background(), w, log, testOpportunity(), stuck)
+            if err != nil {
+                t.Fatalf("awaitSettlement: %v", err)
+            }
+            if cancelled != tt.wantCancelled {
+                t.Errorf("cancelled = %v, want %v", cancelled, tt.wantCancelled)
+            }
+            
+            sent := node.transactions()
+            if len(sent) != 1 {
+                t.Fatalf("node received %d replacements, want 1", len(sent))
+            }
+            replacement := sent[0]
+            if receipt.TxHash != replacement.Hash() {
+                t.Errorf("settled %s, want the replacement %s", receipt.TxHash.Hex(), replacement.Hash().Hex())
+            }
+            if replacement.Nonce() != stuck.Nonce() {
+                t.Errorf("replacement nonce = %d, want %d", replacement.Nonce(), stuck.Nonce())
+            }
+            minFeeCap := new(big.Int).Div(new(big.Int).Mul(stuck.GasFeeCap(), big.NewInt(120)), big.NewInt(100))
+            if replacement.GasFeeCap().Cmp(minFeeCap) < 0 {
+                t.Errorf("replacement fee cap = %v, want at least %v", replacement.GasFeeCap(), minFeeCap)
+            }
+            
+            if tt.mode == ReplaceCancel {
+                if *replacement.To() != w.address || len(replacement.Data()) != 0 || replacement.Gas() != cancelGasLimit {
+                    t.Errorf("cancellation sends to %s with %d bytes and %d gas, want an empty self-send", replacement.To().Hex(), len(replacement.Data()), replacement.Gas())
+                }
+            } else if *replacement.To() != *stuck.To() || !bytes.Equal(replacement.Data(), stuck.Data()) {
+                t.Errorf("speed-up changed the call")
+            }
+        })
+    }
+}
\ No newline at end of file
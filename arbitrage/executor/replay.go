@@ -0,0 +1,91 @@
+package executor
+
+import (
+    "container/list"
+    "fmt"
+    "sync"
+    "time"
+
+    "github.com/hypercore-suite/arbitrage/trade"
+)
+
+// maxSentIDs bounds how many sent opportunities the replay guard remembers.
+const maxSentIDs = 4096
+
+// replayGuard remembers the opportunities sent within window, oldest first,
+// so a redelivered opportunity is not traded twice. It is the executor-side
+// counterpart of the detector's deduplication; a zero window disables it.
+type replayGuard struct {
+    window time.Duration
+    
+    mu    sync.Mutex
+    order *list.List
+    sent  map[string]*list.Element
+}
+
+type sentEntry struct {
+    key string
+    at  time.Time
+}
+
+func newReplayGuard(window time.Duration) *replayGuard {
+    return &replayGuard{
+        window: window,
+        order:  list.New(),
+        sent:   make(map[string]*list.Element),
+    }
+}
+
+// claim marks opp as sent at now and reports whether it had not already been
+// sent within the window. Checking and marking together keeps two workers
+// from both claiming the same opportunity.
+func (g *replayGuard) claim(opp *trade.Opportunity, now time.Time) bool {
+    if g.window == 0 {
+        return true
+    }
+    
+    g.mu.Lock()
+    defer g.mu.Unlock()
+    
+    for front := g.order.Front(); front != nil; front = g.order.Front() {
+        entry := front.Value.(sentEntry)
+        if now.Sub(entry.at) < g.window && g.order.Len() < maxSentIDs {
+            break
+        }
+        g.order.Remove(front)
+        delete(g.sent, entry.key)
+    }
+    
+    key := replayKey(opp)
+    if _, ok := g.sent[key]; ok {
+        return false
+    }
+    g.sent[key] = g.order.PushBack(sentEntry{key: key, at: now})
+    return true
+}
+
+// release forgets a claim on opp whose execution did not go ahead, so a
+// redelivery may still be traded.
+func (g *replayGuard) release(opp *trade.Opportunity) {
+    if g.window == 0 {
+        return
+    }
+    
+    g.mu.Lock()
+    defer g.mu.Unlock()
+    
+    key := replayKey(opp)
+    if elem, ok := g.sent[key]; ok {
+        g.order.Remove(elem)
+        delete(g.sent, key)
+    }
+}
+
+// replayKey identifies opp by its id, or by what it trades and when for an
+// opportunity built without one.
+func replayKey(opp *trade.Opportunity) string {
+    if opp.ID != "" {
+        return opp.ID
+    }
+    return fmt.Sprintf("%d/%t/%d/%s/%s", opp.Asset, opp.IsBuy, opp.Timestamp.UnixNano(), opp.Amount, opp.EVMPrice)
+}
\ No newline at end of file
@@ -0,0 +1,63 @@
+package executor
+
+import (
+    "context"
+    "testing"
+    "time"
+
+    "github.com/hypercore-suite/arbitrage/config"
+    "github.com/hypercore-suite/arbitrage/trade"
+)
+
+func TestReplayGuardClaim(t *testing.T) {
+    now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+    g := newReplayGuard(time.Minute)
+    opp := testOpportunity()
+    
+    if !g.claim(opp, now) {
+        t.Fatal("first claim refused")
+    }
+    if g.claim(testOpportunity(), now.Add(time.Second)) {
+        t.Fatal("same id claimed twice within the window")
+    }
+    g.release(opp)
+    if !g.claim(opp, now.Add(2*time.Second)) {
+        t.Fatal("released claim refused")
+    }
+    if !g.claim(opp, now.Add(2*time.Minute)) {
+        t.Fatal("claim refused after the window")
+    }
+    
+    disabled := newReplayGuard(0)
+    if !disabled.claim(opp, now) || !disabled.claim(opp, now) {
+        t.Fatal("zero window refused a claim")
+    }
+}
+
+func TestStartExecutesRedeliveredOpportunityOnce(t *testing.T) {
+    node := newStubNode(t)
+    node.reportProfit(40000000)
+    e, monitor := newTestExecutor(t, node, func(cfg *config.Config) {
+        cfg.Executor.WarmUp = 0
+        cfg.Executor.NativeTokenPrice = 2000000000
+        cfg.Executor.MinExecutionInterval = 0
+    })
+    
+    // The same opportunity id enqueued twice, as a redelivery would be.
+    opportunities := make(chan *trade.Opportunity, 2)
+    opportunities <- testOpportunity()
+    opportunities <- testOpportunity()
+    close(opportunities)
+    
+    ctx, cancel := context.WithCancel(context.Background())
+    defer cancel()
+    e.Start(ctx, opportunities)
+    e.Shutdown(5 * time.Second)
+    
+    if got := len(node.transactions()); got != 1 {
+        t.Fatalf("sent %d transactions, want 1", got)
+    }
+    if got := monitor.rejected(RejectDuplicate); got != 1 {
+        t.Fatalf("rejected %d duplicates, want 1", got)
+    }
+}
@@ -0,0 +1,75 @@
+package executor
+
+import (
+    "context"
+    "errors"
+
+    "github.com/ethereum/go-ethereum"
+    "github.com/ethereum/go-ethereum/accounts/abi"
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/ethereum/go-ethereum/common/hexutil"
+    "github.com/ethereum/go-ethereum/core/types"
+    "github.com/ethereum/go-ethereum/rpc"
+    "github.com/sirupsen/logrus"
+)
+
+// logRevertReason replays a reverted transaction with eth_call at its mined
+// block and logs the decoded revert reason. Error(string) reverts are logged as
+// messages; custom errors are logged by their 4-byte selector so they can be
+// matched against the contract ABI.
+func (e *Executor) logRevertReason(ctx context.Context, log *logrus.Entry, receipt *types.Receipt) {
+    fields := logrus.Fields{"tx_hash": receipt.TxHash.Hex()}
+    
+    tx, _, err := e.client.TransactionByHash(ctx, receipt.TxHash)
+    if err != nil {
+        log.WithError(err).WithFields(fields).Error("Failed to fetch reverted transaction")
+        return
+    }
+    
+    from, err := types.Sender(types.LatestSignerForChainID(e.chainID), tx)
+    if err != nil {
+        log.WithError(err).WithFields(fields).Error("Failed to recover reverted transaction sender")
+        return
+    }
+    
+    _, err = e.client.CallContract(ctx, ethereum.CallMsg{
+        From:  from,
+        To:    tx.To(),
+        Gas:   tx.Gas(),
+        Value: tx.Value(),
+        Data:  tx.Data(),
+    }, receipt.BlockNumber)
+    if err == nil {
+        log.WithFields(fields).Error("Arbitrage reverted but replay succeeded; state changed within the block")
+        return
+    }
+    
+    data := revertData(err)
+    if reason, unpackErr := abi.UnpackRevert(data); unpackErr == nil {
+        fields["reason"] = reason
+    } else if len(data) >= 4 {
+        fields["selector"] = hexutil.Encode(data[:4])
+        fields["revert_data"] = hexutil.Encode(data)
+    } else {
+        fields["error"] = err.Error()
+    }
+    
+    log.WithFields(fields).Error("Arbitrage revert reason")
+}
+
+// revertData extracts the raw revert payload from a JSON-RPC call error.
+func revertData(err error) []byte {
+    var dataErr rpc.DataError
+    if !errors.As(err, &dataErr) {
+        return nil
+    }
+    
+    switch data := dataErr.ErrorData().(type) {
+    case string:
+        return common.FromHex(data)
+    case []byte:
+        return data
+    default:
+        return nil
+    }
+}
@@ -0,0 +1,133 @@
+package executor
+
+import (
+    "context"
+    "fmt"
+    "math/big"
+
+    "github.com/ethereum/go-ethereum/accounts/abi"
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/hypercore-suite/arbitrage/config"
+    "github.com/hypercore-suite/arbitrage/trade"
+    "github.com/sirupsen/logrus"
+)
+
+const (
+    RouteABIPath   = "path"
+    RouteABILegacy = "legacy"
+)
+
+// route is one arbitrage contract opportunities can be executed through,
+// with the fee model of the venues it trades on.
+type route struct {
+    name     string
+    contract common.Address
+    abi      abi.ABI
+    path     []common.Address
+    fees     trade.FeeModel
+}
+
+func newRoutes(cfg config.ExecutorConfig) []*route {
+    var routes []*route
+    for _, rc := range cfg.RouteList() {
+        r := &route{
+            name:     rc.Name,
+            contract: common.HexToAddress(rc.Contract),
+            abi:      arbitrageABI,
+            path:     []common.Address{},
+            fees:     routeFees(rc, cfg.AssetFees),
+        }
+        if rc.ABI == RouteABILegacy {
+            r.abi = legacyArbitrageABI
+        }
+        for _, hop := range rc.Path {
+            r.path = append(r.path, common.HexToAddress(hop))
+        }
+        routes = append(routes, r)
+    }
+    return routes
+}
+
+// routeFees is the default fee model of rc: its spot and perp fees, replaced
+// for each asset with an entry in assetFees.
+func routeFees(rc config.RouteConfig, assetFees map[uint32]config.AssetFeeConfig) trade.BpsFees {
+    fees := trade.BpsFees{SpotBps: rc.SpotFeeBps, PerpBps: rc.PerpFeeBps}
+    if len(assetFees) == 0 {
+        return fees
+    }
+    
+    fees.Assets = make(map[uint32]trade.BpsFees, len(assetFees))
+    for asset, tier := range assetFees {
+        fees.Assets[asset] = trade.BpsFees{SpotBps: tier.SpotFeeBps, PerpBps: tier.PerpFeeBps}
+    }
+    return fees
+}
+
+// calldata encodes the route's executeArbitrage call for opp with the given
+// minimum profit. Dry runs and gas estimates pass zero.
+func (r *route) calldata(opp *trade.Opportunity, minProfit *big.Int) ([]byte, error) {
+    params, err := paramsFromOpportunity(opp, minProfit, r.path)
+    if err != nil {
+        return nil, err
+    }
+    
+    data, err := r.abi.Pack("executeArbitrage", params)
+    if err != nil {
+        return nil, fmt.Errorf("encode calldata: %w", err)
+    }
+    return data, nil
+}
+
+// routeQuote is the simulated result of executing an opportunity through
+// one route.
+type routeQuote struct {
+    route          *route
+    expectedProfit *big.Int
+    gasLimit       uint64
+    profit         *big.Int
+}
+
+// bestRoute dry-runs and simulates opp on every route, costing gas at
+// gasPrice, and returns the most profitable quote that clears the minimum
+// net profit. When there is none it returns
+// why: every dry run failed transiently, a dry run reverted, or no route was
+// profitable enough.
+func (e *Executor) bestRoute(ctx context.Context, log *logrus.Entry, w *wallet, opp *trade.Opportunity, gasPrice *big.Int) (*routeQuote, Rejection) {
+    var best *routeQuote
+    simulated, unavailable := 0, 0
+    
+    for _, r := range e.routes {
+        routeLog := log.WithField("route", r.name)
+        
+        expectedProfit, err := e.dryRunCall(ctx, w, r, opp)
+        if err != nil {
+            e.recordError(routeLog, "dry_run", err)
+            if transient(err) {
+                unavailable++
+            }
+            continue
+        }
+        simulated++
+        
+        gasLimit := e.estimateGas(ctx, w, r, opp)
+        profit, success := e.simulateExecution(opp, r, gasLimit, gasPrice, expectedProfit)
+        routeLog.WithField("profit", profit).Debug("Route simulated")
+        if !success || profit.Cmp(e.minNetProfit()) < 0 {
+            continue
+        }
+        if best == nil || profit.Cmp(best.profit) > 0 {
+            best = &routeQuote{route: r, expectedProfit: expectedProfit, gasLimit: gasLimit, profit: profit}
+        }
+    }
+    
+    switch {
+    case best != nil:
+        return best, ""
+    case simulated > 0:
+        return nil, RejectUnprofitable
+    case unavailable == len(e.routes):
+        return nil, RejectRPCUnavailable
+    default:
+        return nil, RejectDryRunReverted
+    }
+}
\ No newline at end of file
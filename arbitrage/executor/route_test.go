@@ -0,0 +1,98 @@
+package executor
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "math/big"
+    "testing"
+
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/hypercore-suite/arbitrage/config"
+)
+
+func TestBestRouteMinNetProfit(t *testing.T) {
+    const profit = 40000000 // $0.40 after costs
+    tests := []struct {
+        name         string
+        minNetProfit int64
+        wantReason   Rejection
+    }{
+        {"above the minimum", profit - 1, ""},
+        {"at the minimum", profit, ""},
+        {"just under the minimum", profit + 1, RejectUnprofitable},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            node := newStubNode(t)
+            node.reportProfit(profit)
+            e, _ := newTestExecutor(t, node, func(cfg *config.Config) {
+                cfg.Executor.MinNetProfit = tt.minNetProfit
+            })
+            
+            log := e.logger.WithField("test", t.Name())
+            quote, reason := e.bestRoute(context.Background(), log, e.wallets.wallets[0], testOpportunity(), big.NewInt(2000000000))
+            if reason != tt.wantReason {
+                t.Fatalf("reason = %q, want %q", reason, tt.wantReason)
+            }
+            if reason == "" && quote.profit.Int64() != profit {
+                t.Fatalf("quoted profit = %v, want %d", quote.profit, profit)
+            }
+        })
+    }
+}
+func TestBestRoutePicksMostProfitable(t *testing.T) {
+    first := common.HexToAddress("0x00000000000000000000000000000000000a4b01")
+    second := common.HexToAddress("0x00000000000000000000000000000000000a4b02")
+    
+    // A dry-run profit of zero stands for a reverting route.
+    tests := []struct {
+        name                      string
+        firstProfit, secondProfit uint64
+        firstFeeBps, secondFeeBps int64
+        wantRoute                 string
+    }{
+        {"higher dry-run profit", 30000000, 45000000, 0, 0, "second"},
+        {"first route ahead", 45000000, 30000000, 0, 0, "first"},
+        {"fees outweigh a higher dry run", 30000000, 45000000, 0, 20, "first"},
+        {"other route reverts", 0, 20000000, 0, 0, "second"},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            node := newStubNode(t)
+            node.handle("eth_call", func(params []json.RawMessage) (interface{}, error) {
+                var call struct {
+                    To common.Address `json:"to"`
+                }
+                if err := json.Unmarshal(params[0], &call); err != nil {
+                    return nil, err
+                }
+                profit := tt.firstProfit
+                if call.To == second {
+                    profit = tt.secondProfit
+                }
+                if profit == 0 {
+                    return nil, errors.New("execution reverted")
+                }
+                return dryRunResult(profit)
+            })
+            e, _ := newTestExecutor(t, node, func(cfg *config.Config) {
+                cfg.Executor.Routes = []config.RouteConfig{
+                    {Name: "first", Contract: first.Hex(), ABI: RouteABIPath, SpotFeeBps: tt.firstFeeBps},
+                    {Name: "second", Contract: second.Hex(), ABI: RouteABIPath, SpotFeeBps: tt.secondFeeBps},
+                }
+            })
+            
+            log := e.logger.WithField("test", t.Name())
+            quote, reason := e.bestRoute(context.Background(), log, e.wallets.wallets[0], testOpportunity(), big.NewInt(2000000000))
+            if reason != "" {
+                t.Fatalf("rejected: %s", reason)
+            }
+            if quote.route.name != tt.wantRoute {
+                t.Fatalf("picked route %s (profit %v), want %s", quote.route.name, quote.profit, tt.wantRoute)
+            }
+        })
+    }
+}
@@ -0,0 +1,96 @@
+package executor
+
+import (
+    "context"
+    "math/big"
+    "time"
+
+    "github.com/ethereum/go-ethereum"
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/ethereum/go-ethereum/core/types"
+    "github.com/ethereum/go-ethereum/ethclient"
+)
+
+// timeoutClient bounds every RPC the executor makes to timeout, on top of
+// whatever deadline the caller's context already carries, so a hung node
+// fails the call instead of stalling the execution.
+type timeoutClient struct {
+    *ethclient.Client
+    timeout time.Duration
+}
+
+func (c *timeoutClient) bound(ctx context.Context) (context.Context, context.CancelFunc) {
+    return context.WithTimeout(ctx, c.timeout)
+}
+
+func (c *timeoutClient) ChainID(ctx context.Context) (*big.Int, error) {
+    ctx, cancel := c.bound(ctx)
+    defer cancel()
+    return c.Client.ChainID(ctx)
+}
+
+func (c *timeoutClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+    ctx, cancel := c.bound(ctx)
+    defer cancel()
+    return c.Client.PendingNonceAt(ctx, account)
+}
+
+func (c *timeoutClient) BalanceAt(ctx context.Context, account common.Address, block *big.Int) (*big.Int, error) {
+    ctx, cancel := c.bound(ctx)
+    defer cancel()
+    return c.Client.BalanceAt(ctx, account, block)
+}
+
+func (c *timeoutClient) CallContract(ctx context.Context, msg ethereum.CallMsg, block *big.Int) ([]byte, error) {
+    ctx, cancel := c.bound(ctx)
+    defer cancel()
+    return c.Client.CallContract(ctx, msg, block)
+}
+
+func (c *timeoutClient) PendingCallContract(ctx context.Context, msg ethereum.CallMsg) ([]byte, error) {
+    ctx, cancel := c.bound(ctx)
+    defer cancel()
+    return c.Client.PendingCallContract(ctx, msg)
+}
+
+func (c *timeoutClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+    ctx, cancel := c.bound(ctx)
+    defer cancel()
+    return c.Client.EstimateGas(ctx, msg)
+}
+
+func (c *timeoutClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+    ctx, cancel := c.bound(ctx)
+    defer cancel()
+    return c.Client.HeaderByNumber(ctx, number)
+}
+
+func (c *timeoutClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+    ctx, cancel := c.bound(ctx)
+    defer cancel()
+    return c.Client.SuggestGasPrice(ctx)
+}
+
+func (c *timeoutClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+    ctx, cancel := c.bound(ctx)
+    defer cancel()
+    return c.Client.SuggestGasTipCap(ctx)
+}
+
+func (c *timeoutClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+    ctx, cancel := c.bound(ctx)
+    defer cancel()
+    return c.Client.SendTransaction(ctx, tx)
+}
+
+func (c *timeoutClient) TransactionReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
+    ctx, cancel := c.bound(ctx)
+    defer cancel()
+    return c.Client.TransactionReceipt(ctx, hash)
+}
+
+func (c *timeoutClient) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+    ctx, cancel := c.bound(ctx)
+    defer cancel()
+    return c.Client.TransactionByHash(ctx, hash)
+}
\ No newline at end of file
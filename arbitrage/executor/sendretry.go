@@ -0,0 +1,58 @@
+package executor
+
+import (
+    "context"
+    "errors"
+    "strings"
+    "time"
+
+    "github.com/ethereum/go-ethereum/core/types"
+    "github.com/ethereum/go-ethereum/rpc"
+    "github.com/sirupsen/logrus"
+)
+
+// retryableSend reports whether a failed broadcast may go through when the
+// same signed transaction is sent again: the node was unreachable or could
+// not take it right now. Nonce, balance and revert rejections are terminal.
+func retryableSend(err error) bool {
+    return errors.Is(err, ErrRPCUnavailable)
+}
+
+// alreadyKnown reports whether the node turned the transaction down because
+// it already holds it, which means an earlier attempt got through.
+func alreadyKnown(err error) bool {
+    var rpcErr rpc.Error
+    return errors.As(err, &rpcErr) && strings.Contains(strings.ToLower(rpcErr.Error()), "already known")
+}
+
+// submitWithRetry submits tx, resending it up to sendRetries times while the
+// failure is retryable and backing off exponentially from sendRetryBackoff
+// between attempts. Terminal failures are returned at once.
+func (e *Executor) submitWithRetry(ctx context.Context, tx *types.Transaction, private bool) (string, error) {
+    backoff := e.sendRetryBackoff
+    for attempt := 0; ; attempt++ {
+        route, err := e.submit(ctx, tx, private)
+        if err == nil || alreadyKnown(err) {
+            return route, nil
+        }
+        if attempt >= e.sendRetries || !retryableSend(err) {
+            return route, err
+        }
+        
+        class := errorClass(err)
+        e.monitor.RecordSendRetry(class)
+        e.logger.WithError(err).WithFields(logrus.Fields{
+            "tx_hash":     tx.Hash().Hex(),
+            "attempt":     attempt + 1,
+            "error_class": class,
+            "backoff":     backoff,
+        }).Warn("Broadcast failed, retrying")
+        
+        select {
+        case <-ctx.Done():
+            return route, ctx.Err()
+        case <-time.After(backoff):
+        }
+        backoff *= 2
+    }
+}
\ No newline at end of file
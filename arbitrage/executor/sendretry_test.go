@@ -0,0 +1,54 @@
+package executor
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "testing"
+    "time"
+
+    "github.com/hypercore-suite/arbitrage/config"
+)
+
+func TestSubmitWithRetry(t *testing.T) {
+    tests := []struct {
+        name      string
+        failures  []string
+        wantErr   error
+        wantCalls int
+    }{
+        {"accepted at once", nil, nil, 1},
+        {"transient failure, then accepted", []string{"txpool is full"}, nil, 2},
+        {"accepted on the last retry", []string{"txpool is full", "txpool is full"}, nil, 3},
+        {"retries exhausted", []string{"txpool is full", "txpool is full", "txpool is full"}, ErrRPCUnavailable, 3},
+        {"earlier attempt got through", []string{"already known"}, nil, 1},
+        {"terminal failure", []string{"nonce too low"}, ErrNonceConflict, 1},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            node := newStubNode(t)
+            accept := node.handler("eth_sendRawTransaction")
+            calls := 0
+            node.handle("eth_sendRawTransaction", func(params []json.RawMessage) (interface{}, error) {
+                calls++
+                if calls <= len(tt.failures) {
+                    return nil, errors.New(tt.failures[calls-1])
+                }
+                return accept(params)
+            })
+            e, _ := newTestExecutor(t, node, func(cfg *config.Config) {
+                cfg.Executor.SendRetries = 2
+                cfg.Executor.SendRetryBackoff = time.Millisecond
+            })
+            
+            _, err := e.submitWithRetry(context.Background(), signedTx(t, e), false)
+            if !errors.Is(err, tt.wantErr) {
+                t.Fatalf("submitWithRetry error = %v, want %v", err, tt.wantErr)
+            }
+            if calls != tt.wantCalls {
+                t.Fatalf("broadcast %d times, want %d", calls, tt.wantCalls)
+            }
+        })
+    }
+}
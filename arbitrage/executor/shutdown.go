@@ -0,0 +1,91 @@
+package executor
+
+import (
+    "context"
+    "time"
+
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/hypercore-suite/arbitrage/trade"
+)
+
+// track registers an execution as in flight. It returns false once shutdown
+// has begun so no new executions start.
+func (e *Executor) track() bool {
+    e.flightMu.Lock()
+    defer e.flightMu.Unlock()
+    
+    if e.closing {
+        return false
+    }
+    e.inFlight.Add(1)
+    return true
+}
+
+func (e *Executor) addPending(hash common.Hash, opp *trade.Opportunity) {
+    e.flightMu.Lock()
+    defer e.flightMu.Unlock()
+    e.pending[hash] = opp
+}
+
+func (e *Executor) removePending(hash common.Hash) {
+    e.flightMu.Lock()
+    defer e.flightMu.Unlock()
+    delete(e.pending, hash)
+}
+
+// drain executes the opportunities still queued when shutdown began, until
+// the detector closes the channel or drainTimeout passes. Whatever is queued
+// after that is abandoned.
+func (e *Executor) drain(opportunities <-chan *trade.Opportunity) {
+    if e.drainTimeout <= 0 {
+        if queued := len(opportunities) + e.queue.Len(); queued > 0 {
+            e.logger.WithField("abandoned", queued).Warn("Queued opportunities abandoned on shutdown")
+        }
+        return
+    }
+    
+    e.logger.WithField("queued", len(opportunities)+e.queue.Len()).Info("Draining queued opportunities")
+    ctx, cancel := context.WithTimeout(context.Background(), e.drainTimeout)
+    defer cancel()
+    
+    if e.consume(ctx, opportunities) {
+        e.logger.WithField("abandoned", len(opportunities)+e.queue.Len()).Warn("Drain timeout with opportunities still queued")
+        return
+    }
+    e.logger.Info("Queued opportunities drained")
+}
+
+// Shutdown stops new executions and waits up to timeout for in-flight ones to
+// settle. When the timeout fires, any transactions still awaiting a receipt
+// are logged so operators can check them on-chain, and outstanding waits are
+// cancelled.
+func (e *Executor) Shutdown(timeout time.Duration) {
+    e.flightMu.Lock()
+    e.closing = true
+    e.flightMu.Unlock()
+    
+    done := make(chan struct{})
+    go func() {
+        e.inFlight.Wait()
+        close(done)
+    }()
+    
+    select {
+    case <-done:
+        e.logger.Info("All executions settled")
+    case <-time.After(timeout):
+        e.flightMu.Lock()
+        for hash, opp := range e.pending {
+            e.logger.WithFields(opp.LogFields()).WithField("tx_hash", hash.Hex()).Warn("Shutdown timeout with transaction unconfirmed")
+        }
+        e.flightMu.Unlock()
+    }
+    
+    e.cancelExec()
+    
+    if e.ledger != nil {
+        if err := e.ledger.Close(); err != nil {
+            e.logger.WithError(err).Warn("Failed to close trade ledger")
+        }
+    }
+}
@@ -0,0 +1,24 @@
+package executor
+
+import (
+    "math/big"
+
+    "github.com/hypercore-suite/arbitrage/pricing"
+    "github.com/hypercore-suite/arbitrage/trade"
+)
+
+// minProfit returns the minProfit argument for opp. The contract compares it
+// against the perp/spot oracle price gap, so it is a per-unit spread at
+// trade.PriceDecimals, not a total: opp's net spread less slippageBps of the
+// spot price, floored at zero. The contract only trades a gap strictly above
+// the argument, so one is taken off to let the floor itself through.
+func (e *Executor) minProfit(opp *trade.Opportunity) *big.Int {
+    spot, _ := e.legs(opp, nil)
+    
+    allowance := pricing.MulDiv(spot.price, new(big.Int).SetUint64(e.slippageBps), big.NewInt(10000), pricing.RoundDown)
+    floor := new(big.Int).Sub(opp.NetSpread, allowance)
+    if floor.Sign() <= 0 {
+        return new(big.Int)
+    }
+    return floor.Sub(floor, big.NewInt(1))
+}
@@ -0,0 +1,82 @@
+package executor
+
+import (
+    "math/big"
+    "testing"
+
+    "github.com/ethereum/go-ethereum/accounts/abi"
+    "github.com/hypercore-suite/arbitrage/trade"
+)
+
+// contractAccepts mirrors the spread check in CoreEVMArbitrage.executeArbitrage.
+func contractAccepts(perpPrice, spotPrice *big.Int, minProfit uint64) bool {
+    floor := new(big.Int).SetUint64(minProfit)
+    return perpPrice.Cmp(new(big.Int).Add(spotPrice, floor)) > 0 ||
+        spotPrice.Cmp(new(big.Int).Add(perpPrice, floor)) > 0
+}
+
+func TestMinProfitCalldata(t *testing.T) {
+    tests := []struct {
+        name        string
+        slippageBps uint64
+        netSpread   int64
+        want        uint64
+        // minGap is the narrowest perp/spot gap the contract still trades.
+        minGap int64
+    }{
+        {"no tolerance keeps the net spread", 0, 50000000, 49999999, 50000000},
+        {"10 bps of a $100 spot price", 10, 50000000, 39999999, 40000000},
+        {"funding narrows the net spread", 10, 30000000, 19999999, 20000000},
+        {"tolerance above the spread floors at zero", 100, 50000000, 0, 1},
+    }
+    // The floor is per unit, so it does not move with the trade size.
+    amounts := []int64{20000000, 100000000, 500000000}
+    
+    for _, tt := range tests {
+        for _, amount := range amounts {
+            t.Run(tt.name, func(t *testing.T) {
+                e := &Executor{slippageBps: tt.slippageBps}
+                opp := &trade.Opportunity{
+                    CorePrice: big.NewInt(10050000000),
+                    EVMPrice:  big.NewInt(10000000000),
+                    NetSpread: big.NewInt(tt.netSpread),
+                    Amount:    big.NewInt(amount),
+                    IsBuy:     true,
+                }
+                
+                minProfit := e.minProfit(opp)
+                if minProfit.Cmp(new(big.Int).SetUint64(tt.want)) != 0 {
+                    t.Fatalf("amount %d: minProfit = %v, want %d", amount, minProfit, tt.want)
+                }
+                
+                r := &route{abi: arbitrageABI}
+                data, err := r.calldata(opp, minProfit)
+                if err != nil {
+                    t.Fatalf("calldata: %v", err)
+                }
+                out, err := arbitrageABI.Methods["executeArbitrage"].Inputs.Unpack(data[4:])
+                if err != nil {
+                    t.Fatalf("unpack: %v", err)
+                }
+                params := abi.ConvertType(out[0], new(ArbitrageParams)).(*ArbitrageParams)
+                if params.MinProfit != tt.want {
+                    t.Fatalf("amount %d: encoded minProfit = %d, want %d", amount, params.MinProfit, tt.want)
+                }
+                
+                if !contractAccepts(opp.CorePrice, opp.EVMPrice, params.MinProfit) {
+                    t.Fatalf("amount %d: contract rejects the quoted prices", amount)
+                }
+                // Spot rising against the buy narrows the gap; the contract
+                // trades down to minGap and no further.
+                narrowest := new(big.Int).Sub(opp.CorePrice, big.NewInt(tt.minGap))
+                if !contractAccepts(opp.CorePrice, narrowest, params.MinProfit) {
+                    t.Fatalf("amount %d: contract rejects a gap of %d", amount, tt.minGap)
+                }
+                tooNarrow := new(big.Int).Add(narrowest, big.NewInt(1))
+                if contractAccepts(opp.CorePrice, tooNarrow, params.MinProfit) {
+                    t.Fatalf("amount %d: contract accepts a gap of %d", amount, tt.minGap-1)
+                }
+            })
+        }
+    }
+}
\ No newline at end of file
@@ -0,0 +1,66 @@
+package executor
+
+import (
+    "context"
+    "testing"
+    "time"
+
+    "github.com/hypercore-suite/arbitrage/config"
+    "github.com/hypercore-suite/arbitrage/trade"
+)
+
+// fakeSource stands in for the detector: any producer of trade
+// opportunities can feed the executor.
+type fakeSource struct {
+    opportunities []*trade.Opportunity
+}
+
+// run sends the source's opportunities in order and closes the channel once
+// they are all sent or ctx is cancelled.
+func (s fakeSource) run(ctx context.Context) <-chan *trade.Opportunity {
+    out := make(chan *trade.Opportunity)
+    go func() {
+        defer close(out)
+        for _, opp := range s.opportunities {
+            select {
+            case out <- opp:
+            case <-ctx.Done():
+                return
+            }
+        }
+    }()
+    return out
+}
+
+func TestStartFromFakeSource(t *testing.T) {
+    node := newStubNode(t)
+    node.reportProfit(40000000)
+    e, monitor := newTestExecutor(t, node, func(cfg *config.Config) {
+        cfg.Executor.WarmUp = 0
+        cfg.Executor.NativeTokenPrice = 2000000000
+        cfg.Executor.MinExecutionInterval = 0
+    })
+    
+    first, second, cycle := testOpportunity(), testOpportunity(), testOpportunity()
+    first.ID, second.ID, cycle.ID = "opp-a", "opp-b", "cycle-a"
+    second.IsBuy = false
+    cycle.Kind = trade.KindCycle
+    source := fakeSource{opportunities: []*trade.Opportunity{first, cycle, second}}
+    
+    ctx, cancel := context.WithCancel(context.Background())
+    defer cancel()
+    e.Start(ctx, source.run(ctx))
+    e.Shutdown(5 * time.Second)
+    
+    if got := len(node.transactions()); got != 2 {
+        t.Fatalf("sent %d transactions, want 2", got)
+    }
+    if got := monitor.rejected(RejectUnsupported); got != 1 {
+        t.Fatalf("rejected %d unsupported opportunities, want 1", got)
+    }
+    monitor.mu.Lock()
+    defer monitor.mu.Unlock()
+    if len(monitor.executions) != 2 || !monitor.executions[0] || !monitor.executions[1] {
+        t.Fatalf("executions = %v, want two successes", monitor.executions)
+    }
+}
@@ -0,0 +1,102 @@
+package executor
+
+import (
+    "context"
+    "crypto/ecdsa"
+    "fmt"
+    "sync/atomic"
+
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/ethereum/go-ethereum/crypto"
+    "github.com/hypercore-suite/arbitrage/config"
+    "github.com/sirupsen/logrus"
+)
+
+// wallet is one signing account. Each wallet has its own nonce sequence and
+// approval cache so several can have arbitrage transactions in flight at once.
+// lowGas is set while its native balance is below the gas floor.
+type wallet struct {
+    key       *ecdsa.PrivateKey
+    address   common.Address
+    nonces    *nonceManager
+    approvals *approvalManager
+    lowGas    atomic.Bool
+}
+
+// walletPool hands out idle wallets in round-robin order. A wallet is held
+// for a whole execution, from the balance check to the receipt, so its
+// nonces are never shared between concurrent executions.
+type walletPool struct {
+    wallets []*wallet
+    idle    chan *wallet
+}
+
+func newWalletPool(wallets []*wallet) *walletPool {
+    p := &walletPool{
+        wallets: wallets,
+        idle:    make(chan *wallet, len(wallets)),
+    }
+    for _, w := range wallets {
+        p.idle <- w
+    }
+    return p
+}
+
+// loadWallets parses every configured key, fetches its pending nonce and
+// reconciles it with the nonce state saved in store.
+func loadWallets(ctx context.Context, client *timeoutClient, store *nonceStore, logger *logrus.Logger, execCfg config.ExecutorConfig) ([]*wallet, error) {
+    var wallets []*wallet
+    for i, raw := range execCfg.Keys() {
+        key, err := loadPrivateKey(raw)
+        if err != nil {
+            return nil, fmt.Errorf("wallet %d: %w", i, err)
+        }
+        
+        address := crypto.PubkeyToAddress(key.PublicKey)
+        saved := store.saved(address)
+        nonces, err := newNonceManager(ctx, client, store, address)
+        if err != nil {
+            return nil, fmt.Errorf("fetch pending nonce for %s: %w", address.Hex(), err)
+        }
+        if err := nonces.restore(ctx, client, saved, logger); err != nil {
+            return nil, fmt.Errorf("restore nonce state for %s: %w", address.Hex(), err)
+        }
+        
+        wallets = append(wallets, &wallet{
+            key:       key,
+            address:   address,
+            nonces:    nonces,
+            approvals: newApprovalManager(execCfg.Approvals),
+        })
+    }
+    return wallets, nil
+}
+
+// WalletAddresses returns the address of every configured executor key,
+// without touching the chain.
+func WalletAddresses(execCfg config.ExecutorConfig) ([]common.Address, error) {
+    var addresses []common.Address
+    for i, raw := range execCfg.Keys() {
+        key, err := loadPrivateKey(raw)
+        if err != nil {
+            return nil, fmt.Errorf("wallet %d: %w", i, err)
+        }
+        addresses = append(addresses, crypto.PubkeyToAddress(key.PublicKey))
+    }
+    return addresses, nil
+}
+
+// acquire blocks until a wallet is idle or ctx is done. Wallets are returned
+// to the back of the queue on release, so idle wallets are used in turn.
+func (p *walletPool) acquire(ctx context.Context) (*wallet, error) {
+    select {
+    case w := <-p.idle:
+        return w, nil
+    case <-ctx.Done():
+        return nil, ctx.Err()
+    }
+}
+
+func (p *walletPool) release(w *wallet) {
+    p.idle <- w
+}
\ No newline at end of file
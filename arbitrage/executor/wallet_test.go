@@ -0,0 +1,82 @@
+package executor
+
+import (
+    "context"
+    "errors"
+    "math/big"
+    "testing"
+    "time"
+
+    "github.com/ethereum/go-ethereum/common"
+)
+
+// testWalletPool returns a pool of n wallets with distinct addresses.
+func testWalletPool(n int) *walletPool {
+    var wallets []*wallet
+    for i := 1; i <= n; i++ {
+        wallets = append(wallets, &wallet{address: common.BigToAddress(big.NewInt(int64(i)))})
+    }
+    return newWalletPool(wallets)
+}
+
+func TestWalletPoolAssignsIdleWallets(t *testing.T) {
+    tests := []struct {
+        name    string
+        wallets int
+        held    int
+    }{
+        {"two wallets, two opportunities", 2, 2},
+        {"three wallets, two opportunities", 3, 2},
+        {"three wallets, three opportunities", 3, 3},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            pool := testWalletPool(tt.wallets)
+            
+            seen := make(map[common.Address]bool)
+            for i := 0; i < tt.held; i++ {
+                w, err := pool.acquire(context.Background())
+                if err != nil {
+                    t.Fatalf("acquire %d: %v", i, err)
+                }
+                if seen[w.address] {
+                    t.Fatalf("wallet %s handed out twice while held", w.address.Hex())
+                }
+                seen[w.address] = true
+            }
+            
+            if tt.held < tt.wallets {
+                return
+            }
+            ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+            defer cancel()
+            if _, err := pool.acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+                t.Fatalf("acquire with every wallet held = %v, want it to wait for a release", err)
+            }
+        })
+    }
+}
+
+func TestWalletPoolRoundRobin(t *testing.T) {
+    pool := testWalletPool(3)
+    
+    var order []common.Address
+    for i := 0; i < 6; i++ {
+        w, err := pool.acquire(context.Background())
+        if err != nil {
+            t.Fatalf("acquire: %v", err)
+        }
+        order = append(order, w.address)
+        pool.release(w)
+    }
+    
+    for i := 3; i < len(order); i++ {
+        if order[i] != order[i-3] {
+            t.Fatalf("wallet order %v does not cycle through every wallet", order)
+        }
+    }
+    if order[0] == order[1] || order[1] == order[2] || order[0] == order[2] {
+        t.Fatalf("wallet order %v reuses a wallet before the others", order)
+    }
+}
\ No newline at end of file
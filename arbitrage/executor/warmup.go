@@ -0,0 +1,52 @@
+package executor
+
+import (
+    "context"
+    "time"
+)
+
+// Execution phases reported to the monitor.
+const (
+    PhaseDryRun = "dry_run"
+    PhaseWarmUp = "warm_up"
+    PhaseLive   = "live"
+)
+
+// beginWarmUp puts the executor into the warm-up phase, during which
+// opportunities are evaluated and paper-traded exactly as in dry-run mode,
+// and switches it to live execution once the warm-up window has passed. A
+// dry-run executor never goes live; a zero window goes live at once.
+func (e *Executor) beginWarmUp(ctx context.Context) {
+    if e.dryRun {
+        e.monitor.RecordPhase(PhaseDryRun)
+        return
+    }
+    if e.warmUp == 0 {
+        e.monitor.RecordPhase(PhaseLive)
+        return
+    }
+    
+    e.warming.Store(true)
+    e.monitor.RecordPhase(PhaseWarmUp)
+    e.logger.WithField("warm_up", e.warmUp).Warn("Warming up: paper-trading before live execution")
+    
+    go func() {
+        timer := time.NewTimer(e.warmUp)
+        defer timer.Stop()
+        
+        select {
+        case <-ctx.Done():
+            return
+        case <-timer.C:
+        }
+        
+        e.warming.Store(false)
+        e.monitor.RecordPhase(PhaseLive)
+        e.logger.Warn("Warm-up complete, switching to live execution")
+    }()
+}
+
+// paperTrading reports whether executions are only simulated right now.
+func (e *Executor) paperTrading() bool {
+    return e.dryRun || e.warming.Load()
+}
\ No newline at end of file
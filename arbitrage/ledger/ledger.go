@@ -0,0 +1,119 @@
+// Package ledger keeps a durable, append-only record of settled executions
+// as JSON lines so trade history survives restarts.
+package ledger
+
+import (
+    "bufio"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "math/big"
+    "os"
+    "sync"
+    "time"
+)
+
+// KindConversion marks an entry recording a profit conversion rather than
+// an arbitrage execution.
+const KindConversion = "conversion"
+
+// Entry is one settled execution. Profits are in USD at
+// trade.PriceDecimals; GasCostWei is the fee paid in the native token.
+// Conversion entries have Kind set, carry the swapped amounts in token units
+// and charge their gas as a negative NetProfit.
+type Entry struct {
+    Time          time.Time `json:"time"`
+    Kind          string    `json:"kind,omitempty"`
+    Asset         uint32    `json:"asset"`
+    OpportunityID string    `json:"opportunity_id"`
+    TxHash        string    `json:"tx_hash"`
+    Success       bool      `json:"success"`
+    GrossProfit   *big.Int  `json:"gross_profit"`
+    GasCostWei    *big.Int  `json:"gas_cost_wei"`
+    NetProfit     *big.Int  `json:"net_profit"`
+    AmountIn      *big.Int  `json:"amount_in,omitempty"`
+    MinAmountOut  *big.Int  `json:"min_amount_out,omitempty"`
+}
+
+// Ledger appends entries to a file. Appends from concurrent executions are
+// serialized and each one is fsynced before Append returns, so a crash
+// loses at most the entry being written.
+type Ledger struct {
+    mu   sync.Mutex
+    file *os.File
+}
+
+// Open opens the ledger at path for appending, creating it if needed.
+func Open(path string) (*Ledger, error) {
+    file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+    if err != nil {
+        return nil, fmt.Errorf("open ledger: %w", err)
+    }
+    return &Ledger{file: file}, nil
+}
+
+// Append writes entry as one line and syncs it to disk.
+func (l *Ledger) Append(entry Entry) error {
+    line, err := json.Marshal(entry)
+    if err != nil {
+        return err
+    }
+    line = append(line, '\n')
+    
+    l.mu.Lock()
+    defer l.mu.Unlock()
+    
+    if l.file == nil {
+        return errors.New("ledger is closed")
+    }
+    if _, err := l.file.Write(line); err != nil {
+        return fmt.Errorf("write ledger: %w", err)
+    }
+    return l.file.Sync()
+}
+
+// Close closes the ledger file. Later appends fail.
+func (l *Ledger) Close() error {
+    l.mu.Lock()
+    defer l.mu.Unlock()
+    
+    if l.file == nil {
+        return nil
+    }
+    err := l.file.Close()
+    l.file = nil
+    return err
+}
+
+// TotalPnL sums the net profit of entries in the ledger at path recorded in
+// [from, to) and returns it with the number of entries counted.
+func TotalPnL(path string, from, to time.Time) (*big.Int, int, error) {
+    file, err := os.Open(path)
+    if err != nil {
+        return nil, 0, fmt.Errorf("open ledger: %w", err)
+    }
+    defer file.Close()
+    
+    total := new(big.Int)
+    count := 0
+    
+    scanner := bufio.NewScanner(file)
+    for line := 1; scanner.Scan(); line++ {
+        if len(scanner.Bytes()) == 0 {
+            continue
+        }
+        
+        var entry Entry
+        if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+            return nil, 0, fmt.Errorf("ledger line %d: %w", line, err)
+        }
+        if entry.Time.Before(from) || !entry.Time.Before(to) || entry.NetProfit == nil {
+            continue
+        }
+        
+        total.Add(total, entry.NetProfit)
+        count++
+    }
+    
+    return total, count, scanner.Err()
+}
@@ -2,44 +2,100 @@ package main
 
 import (
     "context"
+    "flag"
+    "io"
     "os"
     "os/signal"
     "syscall"
     "time"
 
+    "github.com/hypercore-suite/arbitrage/alert"
+    "github.com/hypercore-suite/arbitrage/backtest"
+    "github.com/hypercore-suite/arbitrage/config"
     "github.com/hypercore-suite/arbitrage/detector"
     "github.com/hypercore-suite/arbitrage/executor"
+    "github.com/hypercore-suite/arbitrage/ledger"
     "github.com/hypercore-suite/arbitrage/monitoring"
-    "github.com/joho/godotenv"
+    "github.com/hypercore-suite/arbitrage/toggle"
+    "github.com/hypercore-suite/arbitrage/trade"
     "github.com/sirupsen/logrus"
+    "gopkg.in/natefinch/lumberjack.v2"
 )
 
-func main() {
-    if err := godotenv.Load(); err != nil {
-        logrus.Warn("No .env file found")
+// runLive is the run command: it trades until interrupted, once the
+// preflight checks pass.
+func runLive(args []string) {
+    fs := flag.NewFlagSet("run", flag.ExitOnError)
+    skipPreflight := fs.Bool("skip-preflight", false, "start trading without running the preflight checks")
+    cfg, logger := loadConfig(fs, args)
+    
+    if err := cfg.ValidateCredentials(); err != nil {
+        logger.Fatal("Invalid configuration: ", err)
     }
-
-    logger := setupLogger()
+    
     ctx, cancel := context.WithCancel(context.Background())
     defer cancel()
 
-    monitor := monitoring.NewMonitor()
-    go monitor.Start(":8080")
+    monitor := monitoring.NewMonitor(cfg.PnLWindows, cfg.Registry())
+    monitor.SetLogger(logger)
+    monitor.SetStatsToken(cfg.StatsToken, cfg.ProtectMetrics)
+    monitor.SetOpportunityHistory(cfg.OpportunityHistory)
+    monitor.SetOpportunityDrought(cfg.Alerts.NoOpportunityAfter, cfg.Detector.Assets)
+    if !cfg.TLSEnabled() {
+        logger.WithField("addr", cfg.MetricsAddr).Warn("TLS certificate or key not configured; monitoring server is serving plain HTTP")
+    }
+    go func() {
+        if err := monitor.Start(cfg.MetricsAddr, cfg.TLSCertFile, cfg.TLSKeyFile); err != nil {
+            logger.WithError(err).WithField("addr", cfg.MetricsAddr).Fatal("Monitoring server failed")
+        }
+    }()
 
-    det, err := detector.NewDetector(logger, monitor)
+    det, err := detector.NewDetector(logger, monitor, cfg)
     if err != nil {
         logger.Fatal("Failed to create detector:", err)
     }
+    if !*skipPreflight {
+        if err := runPreflight(logger, cfg, det.Prices); err != nil {
+            logger.Fatal(err)
+        }
+    }
 
-    exec, err := executor.NewExecutor(logger, monitor)
+    exec, err := executor.NewExecutor(logger, monitor, cfg)
     if err != nil {
         logger.Fatal("Failed to create executor:", err)
     }
 
-    opportunities := make(chan *detector.Opportunity, 100)
+    toggles, err := toggle.Open(cfg.AssetStatePath, cfg.Detector.Assets)
+    if err != nil {
+        logger.Fatal("Failed to load asset toggles: ", err)
+    }
+    det.Filter = toggles
+    exec.Filter = toggles
+    exec.Prices = det.Prices
+    exec.Thresholds = det.Thresholds
+    det.Feed = monitor
+    det.OwnAddresses = exec.Addresses()
+    
+    monitor.SetBreaker(exec)
+    monitor.SetController(exec, cfg.ControlToken)
+    monitor.SetAssetToggles(toggles)
+    monitor.SetSimulator(backtest.NewSimulator(logger, cfg))
+    monitor.SetThresholds(det.Thresholds)
+    
+    if cfg.Alerts.Enabled() {
+        alerter := alert.New(logger, cfg.Alerts)
+        monitor.SetAlerts(alerter)
+        go alerter.Start(ctx)
+    }
+    
+    opportunities := make(chan *trade.Opportunity, 100)
 
     go det.Start(ctx, opportunities)
-    go exec.Start(ctx, opportunities)
+    drained := make(chan struct{})
+    go func() {
+        exec.Start(ctx, opportunities)
+        close(drained)
+    }()
 
     sigChan := make(chan os.Signal, 1)
     signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
@@ -47,14 +103,87 @@ func main() {
 
     logger.Info("Shutting down...")
     cancel()
-    time.Sleep(2 * time.Second)
+    // The detector stops producing and closes the channel; the executor
+    // works through what is left before in-flight executions are awaited.
+    <-drained
+    exec.Shutdown(cfg.ShutdownTimeout)
+}
+
+// runBacktest is the backtest command: it replays a CSV or JSONL price
+// series and logs the theoretical PnL.
+func runBacktest(args []string) {
+    fs := flag.NewFlagSet("backtest", flag.ExitOnError)
+    cfg, logger := loadConfig(fs, args)
+    
+    path := fs.Arg(0)
+    if path == "" {
+        logger.Fatal("No price series given; usage: arbitrage backtest [-config file] FILE")
+    }
+    
+    quotes, err := backtest.LoadQuotes(path)
+    if err != nil {
+        logger.Fatal("Failed to load price series: ", err)
+    }
+    
+    summary := backtest.Run(logger, cfg, quotes)
+    logger.WithFields(logrus.Fields{
+        "quotes":        summary.Quotes,
+        "stale_prices":  summary.StalePrices,
+        "opportunities": summary.Opportunities,
+        "executions":    summary.Executions,
+        "gross_profit":  summary.GrossProfit,
+        "net_profit":    summary.NetProfit,
+    }).Info("Backtest complete")
 }
 
-func setupLogger() *logrus.Logger {
+// reportPnL is the pnl command: it logs the net PnL recorded in the trade
+// ledger over a trailing window.
+func reportPnL(args []string) {
+    fs := flag.NewFlagSet("pnl", flag.ExitOnError)
+    window := fs.Duration("since", 24*time.Hour, "trailing window to total")
+    cfg, logger := loadConfig(fs, args)
+    
+    if cfg.Executor.LedgerPath == "" {
+        logger.Fatal("No trade ledger configured")
+    }
+    
+    to := time.Now()
+    total, trades, err := ledger.TotalPnL(cfg.Executor.LedgerPath, to.Add(-*window), to)
+    if err != nil {
+        logger.Fatal("Failed to read trade ledger: ", err)
+    }
+    
+    logger.WithFields(logrus.Fields{
+        "window":     *window,
+        "trades":     trades,
+        "net_profit": total,
+    }).Info("Ledger PnL")
+}
+
+// setupLogger builds the logger described by cfg. Output always goes to
+// stdout and, when a log file is configured, also to that file, rotated by
+// size.
+func setupLogger(cfg *config.Config) *logrus.Logger {
     logger := logrus.New()
-    logger.SetFormatter(&logrus.JSONFormatter{})
+    if cfg.LogFormat == "text" {
+        logger.SetFormatter(&logrus.TextFormatter{FullTimestamp: true})
+    } else {
+        logger.SetFormatter(&logrus.JSONFormatter{})
+    }
+    
+    if cfg.LogFile.Path != "" {
+        logger.SetOutput(io.MultiWriter(os.Stdout, &lumberjack.Logger{
+            Filename:   cfg.LogFile.Path,
+            MaxSize:    cfg.LogFile.MaxSizeMB,
+            MaxAge:     cfg.LogFile.MaxAgeDays,
+            MaxBackups: cfg.LogFile.MaxBackups,
+            Compress:   cfg.LogFile.Compress,
+        }))
+    } else {
+        logger.SetOutput(os.Stdout)
+    }
     
-    level, err := logrus.ParseLevel(os.Getenv("LOG_LEVEL"))
+    level, err := logrus.ParseLevel(cfg.LogLevel)
     if err != nil {
         level = logrus.InfoLevel
     }
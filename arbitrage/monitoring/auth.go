@@ -0,0 +1,42 @@
+package monitoring
+
+import (
+    "crypto/subtle"
+    "net/http"
+    "strings"
+)
+
+// SetStatsToken requires token as a bearer token on the read endpoints.
+// /metrics stays open to scrapers unless protectMetrics is set; an empty
+// token leaves everything open.
+func (m *Monitor) SetStatsToken(token string, protectMetrics bool) {
+    m.mutex.Lock()
+    defer m.mutex.Unlock()
+    m.statsToken = token
+    m.openMetrics = !protectMetrics
+}
+
+// requireStatsToken wraps next so it is only served to requests carrying the
+// stats token. metrics marks the /metrics handler, which can be left open.
+func (m *Monitor) requireStatsToken(next http.Handler, metrics bool) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        m.mutex.RLock()
+        token := m.statsToken
+        open := metrics && m.openMetrics
+        m.mutex.RUnlock()
+        
+        if token != "" && !open && !bearerMatches(r, token) {
+            w.Header().Set("WWW-Authenticate", "Bearer")
+            writeError(w, http.StatusUnauthorized, "unauthorized")
+            return
+        }
+        next.ServeHTTP(w, r)
+    })
+}
+
+// bearerMatches reports whether r carries token as its bearer token, using a
+// constant-time comparison.
+func bearerMatches(r *http.Request, token string) bool {
+    given, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+    return ok && subtle.ConstantTimeCompare([]byte(given), []byte(token)) == 1
+}
\ No newline at end of file
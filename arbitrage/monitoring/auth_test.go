@@ -0,0 +1,50 @@
+package monitoring
+
+import (
+    "net/http"
+    "net/http/httptest"
+    "testing"
+)
+
+func TestRequireStatsToken(t *testing.T) {
+    tests := []struct {
+        name           string
+        token          string
+        protectMetrics bool
+        metrics        bool
+        authorization  string
+        want           int
+    }{
+        {"no token configured", "", false, false, "", http.StatusOK},
+        {"matching bearer token", "s3cret", false, false, "Bearer s3cret", http.StatusOK},
+        {"missing token", "s3cret", false, false, "", http.StatusUnauthorized},
+        {"wrong token", "s3cret", false, false, "Bearer guess", http.StatusUnauthorized},
+        {"token without the bearer scheme", "s3cret", false, false, "s3cret", http.StatusUnauthorized},
+        {"token prefix only", "s3cret", false, false, "Bearer s3", http.StatusUnauthorized},
+        {"metrics open to scrapers", "s3cret", false, true, "", http.StatusOK},
+        {"protected metrics", "s3cret", true, true, "", http.StatusUnauthorized},
+        {"protected metrics with the token", "s3cret", true, true, "Bearer s3cret", http.StatusOK},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            m := NewMonitor(nil, nil)
+            m.SetStatsToken(tt.token, tt.protectMetrics)
+            ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+            
+            req := httptest.NewRequest(http.MethodGet, "/stats", nil)
+            if tt.authorization != "" {
+                req.Header.Set("Authorization", tt.authorization)
+            }
+            rec := httptest.NewRecorder()
+            m.requireStatsToken(ok, tt.metrics).ServeHTTP(rec, req)
+            
+            if rec.Code != tt.want {
+                t.Fatalf("status = %d, want %d", rec.Code, tt.want)
+            }
+            if rec.Code == http.StatusUnauthorized && rec.Header().Get("WWW-Authenticate") != "Bearer" {
+                t.Errorf("WWW-Authenticate = %q, want Bearer", rec.Header().Get("WWW-Authenticate"))
+            }
+        })
+    }
+}
@@ -0,0 +1,146 @@
+package monitoring
+
+import (
+    "net/http"
+    "strconv"
+    "strings"
+)
+
+// TradingController is implemented by components that can stop and restart
+// trading through the /pause and /resume endpoints.
+type TradingController interface {
+    Pause()
+    Resume()
+}
+
+// AssetToggler is implemented by the per-asset switch behind the
+// /asset/{id}/enable and /asset/{id}/disable endpoints.
+type AssetToggler interface {
+    SetEnabled(asset uint32, enabled bool) error
+    States() map[uint32]bool
+}
+
+// SetController registers the component paused and resumed by the control
+// endpoints. Requests to them, /breaker/reset and POST /config must carry
+// token as a bearer token; the endpoints stay disabled while token is empty.
+func (m *Monitor) SetController(c TradingController, token string) {
+    m.mutex.Lock()
+    defer m.mutex.Unlock()
+    m.controller = c
+    m.controlToken = token
+}
+
+// SetAssetToggles registers the per-asset switch changed by the asset
+// endpoints and reported in /stats. The endpoints use the control token.
+func (m *Monitor) SetAssetToggles(t AssetToggler) {
+    m.mutex.Lock()
+    defer m.mutex.Unlock()
+    m.toggles = t
+}
+
+// RecordPaused records the sources currently pausing trading, "api" or
+// "file"; trading is paused while there is any.
+func (m *Monitor) RecordPaused(sources []string) {
+    value := 0.0
+    if len(sources) > 0 {
+        value = 1
+    }
+    m.pausedGauge.Set(value)
+    
+    m.mutex.Lock()
+    m.paused = len(sources) > 0
+    m.pausedBy = sources
+    m.mutex.Unlock()
+}
+
+// RecordPhase records the executor's phase: "dry_run", "warm_up" or "live".
+func (m *Monitor) RecordPhase(phase string) {
+    m.mutex.Lock()
+    m.phase = phase
+    m.mutex.Unlock()
+}
+
+func (m *Monitor) pauseHandler(w http.ResponseWriter, r *http.Request) {
+    if c := m.tradingController(w, r); c != nil {
+        c.Pause()
+        w.WriteHeader(http.StatusNoContent)
+    }
+}
+
+func (m *Monitor) resumeHandler(w http.ResponseWriter, r *http.Request) {
+    if c := m.tradingController(w, r); c != nil {
+        c.Resume()
+        w.WriteHeader(http.StatusNoContent)
+    }
+}
+
+// tradingController authorizes a pause or resume request and returns the
+// registered controller, or writes the error response and returns nil.
+func (m *Monitor) tradingController(w http.ResponseWriter, r *http.Request) TradingController {
+    if !m.authorizeControl(w, r) {
+        return nil
+    }
+    
+    m.mutex.RLock()
+    controller := m.controller
+    m.mutex.RUnlock()
+    
+    if controller == nil {
+        writeError(w, http.StatusServiceUnavailable, "no trading controller registered")
+    }
+    return controller
+}
+
+// assetHandler serves POST /asset/{id}/enable and /asset/{id}/disable.
+func (m *Monitor) assetHandler(w http.ResponseWriter, r *http.Request) {
+    rest := strings.TrimPrefix(r.URL.Path, "/asset/")
+    idPart, action, ok := strings.Cut(rest, "/")
+    if !ok || (action != "enable" && action != "disable") {
+        writeError(w, http.StatusNotFound, "not found")
+        return
+    }
+    
+    id, err := strconv.ParseUint(idPart, 10, 32)
+    if err != nil {
+        writeError(w, http.StatusBadRequest, "invalid asset id")
+        return
+    }
+    
+    if !m.authorizeControl(w, r) {
+        return
+    }
+    
+    m.mutex.RLock()
+    toggles := m.toggles
+    m.mutex.RUnlock()
+    
+    if toggles == nil {
+        writeError(w, http.StatusServiceUnavailable, "no asset toggles registered")
+        return
+    }
+    
+    if err := toggles.SetEnabled(uint32(id), action == "enable"); err != nil {
+        writeError(w, http.StatusInternalServerError, err.Error())
+        return
+    }
+    w.WriteHeader(http.StatusNoContent)
+}
+
+// authorizeControl checks a control request's bearer token, writing the
+// error response when it is refused.
+func (m *Monitor) authorizeControl(w http.ResponseWriter, r *http.Request) bool {
+    m.mutex.RLock()
+    token := m.controlToken
+    m.mutex.RUnlock()
+    
+    if token == "" {
+        writeError(w, http.StatusServiceUnavailable, "trading control is not configured")
+        return false
+    }
+    
+    if !bearerMatches(r, token) {
+        writeError(w, http.StatusUnauthorized, "unauthorized")
+        return false
+    }
+    return true
+}
\ No newline at end of file
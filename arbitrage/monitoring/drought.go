@@ -0,0 +1,94 @@
+package monitoring
+
+import (
+    "time"
+)
+
+// allAssetsLabel labels the drought gauges tracking opportunities on any
+// asset.
+const allAssetsLabel = "all"
+
+// opportunityDrought tracks how long each watched asset, and the detector as
+// a whole, has gone without an opportunity. A long silence more often means a
+// broken feed or misconfigured thresholds than an efficient market, so one
+// past the threshold is alerted on once and reset by the next opportunity.
+// It is guarded by the monitor's mutex.
+type opportunityDrought struct {
+    threshold time.Duration
+    lastAny   time.Time
+    last      map[uint32]time.Time
+    alerted   map[uint32]bool
+    
+    alertedAny bool
+}
+
+func newOpportunityDrought(threshold time.Duration, assets []uint32, now time.Time) *opportunityDrought {
+    d := &opportunityDrought{
+        threshold: threshold,
+        lastAny:   now,
+        last:      make(map[uint32]time.Time, len(assets)),
+        alerted:   make(map[uint32]bool, len(assets)),
+    }
+    for _, asset := range assets {
+        d.last[asset] = now
+    }
+    return d
+}
+
+// observe resets the silence of asset, and of the detector as a whole.
+func (d *opportunityDrought) observe(asset uint32, now time.Time) {
+    d.lastAny, d.alertedAny = now, false
+    if _, ok := d.last[asset]; ok {
+        d.last[asset], d.alerted[asset] = now, false
+    }
+}
+
+// SetOpportunityDrought watches assets, and the detector as a whole, for
+// going longer than threshold without an opportunity, starting now. Each
+// silence is reported by the arbitrage_seconds_since_opportunity gauges and,
+// past threshold, by arbitrage_opportunity_drought and one alert until the
+// next opportunity. A zero threshold keeps the gauges but never alerts.
+func (m *Monitor) SetOpportunityDrought(threshold time.Duration, assets []uint32) {
+    m.mutex.Lock()
+    defer m.mutex.Unlock()
+    m.drought = newOpportunityDrought(threshold, assets, m.now())
+}
+
+// checkDrought updates the drought gauges as of now and returns the
+// silences that just crossed the threshold, keyed by asset label. Callers
+// hold m.mutex.
+func (m *Monitor) checkDrought(now time.Time) map[string]time.Duration {
+    d := m.drought
+    if d == nil {
+        return nil
+    }
+    
+    var crossed map[string]time.Duration
+    check := func(label string, last time.Time, alerted *bool) {
+        idle := now.Sub(last)
+        over := d.threshold > 0 && idle > d.threshold
+        
+        m.sinceOpp.WithLabelValues(label).Set(idle.Seconds())
+        value := 0.0
+        if over {
+            value = 1
+        }
+        m.droughtActive.WithLabelValues(label).Set(value)
+        
+        if over && !*alerted {
+            if crossed == nil {
+                crossed = make(map[string]time.Duration)
+            }
+            crossed[label] = idle
+        }
+        *alerted = over
+    }
+    
+    check(allAssetsLabel, d.lastAny, &d.alertedAny)
+    for asset, last := range d.last {
+        alerted := d.alerted[asset]
+        check(m.assets.Label(asset), last, &alerted)
+        d.alerted[asset] = alerted
+    }
+    return crossed
+}
\ No newline at end of file
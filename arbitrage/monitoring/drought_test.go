@@ -0,0 +1,123 @@
+package monitoring
+
+import (
+    "math/big"
+    "reflect"
+    "testing"
+    "time"
+
+    "github.com/hypercore-suite/arbitrage/registry"
+    "github.com/prometheus/client_golang/prometheus"
+    dto "github.com/prometheus/client_model/go"
+)
+
+// droughtAlerts records the drought alerts raised since it was last read.
+type droughtAlerts struct {
+    raised map[string]time.Duration
+}
+
+func (a *droughtAlerts) NoOpportunities(label string, idle time.Duration) {
+    a.raised[label] = idle
+}
+
+func (a *droughtAlerts) Execution(uint32, *big.Int, bool) {}
+func (a *droughtAlerts) BreakerTripped()                  {}
+func (a *droughtAlerts) LowGasBalance(string, *big.Int)   {}
+
+// take returns the alerts raised and forgets them.
+func (a *droughtAlerts) take() map[string]time.Duration {
+    raised := a.raised
+    a.raised = make(map[string]time.Duration)
+    return raised
+}
+
+// gaugeValue reads the current value of g.
+func gaugeValue(t *testing.T, g prometheus.Gauge) float64 {
+    t.Helper()
+    
+    var metric dto.Metric
+    if err := g.Write(&metric); err != nil {
+        t.Fatalf("read gauge: %v", err)
+    }
+    return metric.GetGauge().GetValue()
+}
+
+func TestOpportunityDroughtAlerts(t *testing.T) {
+    assets, err := registry.New([]registry.Asset{{ID: 0, Symbol: "BTC"}, {ID: 1, Symbol: "ETH"}})
+    if err != nil {
+        t.Fatalf("registry: %v", err)
+    }
+    start := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+    clock := start
+    m := NewMonitor(nil, assets)
+    m.now = func() time.Time { return clock }
+    alerts := &droughtAlerts{raised: make(map[string]time.Duration)}
+    m.SetAlerts(alerts)
+    m.SetOpportunityDrought(time.Minute, []uint32{0, 1})
+    
+    steps := []struct {
+        name        string
+        at          time.Duration
+        opportunity []uint32
+        want        map[string]time.Duration
+    }{
+        {"quiet within the threshold", 30 * time.Second, nil, map[string]time.Duration{}},
+        {"btc trades", 45 * time.Second, []uint32{0}, map[string]time.Duration{}},
+        {"eth goes quiet", 61 * time.Second, nil, map[string]time.Duration{"ETH": 61 * time.Second}},
+        {"eth alerted once", 90 * time.Second, nil, map[string]time.Duration{}},
+        {"btc and the detector go quiet", 106 * time.Second, nil, map[string]time.Duration{"BTC": 61 * time.Second, "all": 61 * time.Second}},
+        {"eth trades again", 120 * time.Second, []uint32{1}, map[string]time.Duration{}},
+        {"eth quiet again alerts again", 181 * time.Second, nil, map[string]time.Duration{"ETH": 61 * time.Second, "all": 61 * time.Second}},
+    }
+    for _, step := range steps {
+        clock = start.Add(step.at)
+        for _, asset := range step.opportunity {
+            m.RecordOpportunity(asset, 50)
+        }
+        m.RecordTick(time.Minute)
+        if got := alerts.take(); !reflect.DeepEqual(got, step.want) {
+            t.Fatalf("%s: alerts = %v, want %v", step.name, got, step.want)
+        }
+    }
+    
+    // At 181s BTC is still in its drought from 106s; ETH's began at 181s.
+    gauges := []struct {
+        label      string
+        wantIdle   float64
+        wantActive float64
+    }{
+        {"BTC", 136, 1},
+        {"ETH", 61, 1},
+        {"all", 61, 1},
+    }
+    for _, g := range gauges {
+        if got := gaugeValue(t, m.sinceOpp.WithLabelValues(g.label)); got != g.wantIdle {
+            t.Errorf("%s seconds since opportunity = %v, want %v", g.label, got, g.wantIdle)
+        }
+        if got := gaugeValue(t, m.droughtActive.WithLabelValues(g.label)); got != g.wantActive {
+            t.Errorf("%s drought = %v, want %v", g.label, got, g.wantActive)
+        }
+    }
+}
+
+func TestOpportunityDroughtWithoutThreshold(t *testing.T) {
+    start := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+    clock := start
+    m := NewMonitor(nil, nil)
+    m.now = func() time.Time { return clock }
+    alerts := &droughtAlerts{raised: make(map[string]time.Duration)}
+    m.SetAlerts(alerts)
+    m.SetOpportunityDrought(0, []uint32{0})
+    
+    clock = start.Add(time.Hour)
+    m.RecordTick(time.Minute)
+    if got := alerts.take(); len(got) != 0 {
+        t.Fatalf("zero threshold alerted: %v", got)
+    }
+    if got := gaugeValue(t, m.sinceOpp.WithLabelValues(allAssetsLabel)); got != 3600 {
+        t.Fatalf("seconds since opportunity = %v, want 3600", got)
+    }
+    if got := gaugeValue(t, m.droughtActive.WithLabelValues(allAssetsLabel)); got != 0 {
+        t.Fatalf("drought = %v, want 0", got)
+    }
+}
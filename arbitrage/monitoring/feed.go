@@ -0,0 +1,168 @@
+package monitoring
+
+import (
+    "encoding/json"
+    "fmt"
+    "net/http"
+    "strconv"
+    "sync"
+    "time"
+
+    "github.com/hypercore-suite/arbitrage/trade"
+)
+
+const (
+    // feedClientBuffer is how many events a slow client may fall behind by
+    // before further events are dropped for it.
+    feedClientBuffer = 64
+    // feedReplay is how many recent events are kept for clients resuming
+    // with Last-Event-ID.
+    feedReplay = 256
+    
+    feedHeartbeat = 15 * time.Second
+)
+
+// FeedOpportunity is the JSON form of an opportunity on the live feed.
+// Prices and amounts are 1e8 fixed-point decimal strings.
+type FeedOpportunity struct {
+    ID        string    `json:"id"`
+    Asset     string    `json:"asset"`
+    AssetID   uint32    `json:"asset_id"`
+    Spread    string    `json:"spread"`
+    SpreadBps int64     `json:"spread_bps"`
+    Direction string    `json:"direction"`
+    Amount    string    `json:"amount"`
+    Timestamp time.Time `json:"timestamp"`
+}
+
+type feedEvent struct {
+    id   uint64
+    data []byte
+}
+
+// opportunityFeed fans detected opportunities out to every connected feed
+// client. Publishing never blocks: a client whose buffer is full misses the
+// event and can catch up by reconnecting with its last event id.
+type opportunityFeed struct {
+    mu      sync.Mutex
+    lastID  uint64
+    recent  []feedEvent
+    clients map[chan feedEvent]struct{}
+}
+
+func newOpportunityFeed() *opportunityFeed {
+    return &opportunityFeed{clients: make(map[chan feedEvent]struct{})}
+}
+
+func (f *opportunityFeed) publish(data []byte) {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    
+    f.lastID++
+    event := feedEvent{id: f.lastID, data: data}
+    f.recent = append(f.recent, event)
+    if len(f.recent) > feedReplay {
+        f.recent = f.recent[len(f.recent)-feedReplay:]
+    }
+    
+    for client := range f.clients {
+        select {
+        case client <- event:
+        default:
+        }
+    }
+}
+
+// subscribe registers a client and returns it with the retained events
+// newer than after, which the client should send first.
+func (f *opportunityFeed) subscribe(after uint64) (chan feedEvent, []feedEvent) {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    
+    var backlog []feedEvent
+    for _, event := range f.recent {
+        if event.id > after {
+            backlog = append(backlog, event)
+        }
+    }
+    
+    client := make(chan feedEvent, feedClientBuffer)
+    f.clients[client] = struct{}{}
+    return client, backlog
+}
+
+func (f *opportunityFeed) unsubscribe(client chan feedEvent) {
+    f.mu.Lock()
+    defer f.mu.Unlock()
+    delete(f.clients, client)
+}
+
+// Publish sends an emitted opportunity to the live feed and the history.
+func (m *Monitor) Publish(opp *trade.Opportunity) {
+    direction := "sell_spot_buy_perp"
+    if opp.IsBuy {
+        direction = "buy_spot_sell_perp"
+    }
+    
+    record := FeedOpportunity{
+        ID:        opp.ID,
+        Asset:     m.assets.Label(opp.Asset),
+        AssetID:   opp.Asset,
+        Spread:    opp.Spread.String(),
+        SpreadBps: opp.SpreadBps,
+        Direction: direction,
+        Amount:    opp.Amount.String(),
+        Timestamp: opp.Timestamp,
+    }
+    m.opportunityHistory().add(OpportunityRecord{FeedOpportunity: record})
+    
+    data, err := json.Marshal(record)
+    if err != nil {
+        return
+    }
+    m.feed.publish(data)
+}
+
+// opportunityStreamHandler serves the live feed as Server-Sent Events. Each
+// event carries its sequence number as the event id, so a reconnecting
+// client that sends Last-Event-ID receives the retained events it missed.
+func (m *Monitor) opportunityStreamHandler(w http.ResponseWriter, r *http.Request) {
+    flusher, ok := w.(http.Flusher)
+    if !ok {
+        writeError(w, http.StatusInternalServerError, "streaming unsupported")
+        return
+    }
+    
+    after, _ := strconv.ParseUint(r.Header.Get("Last-Event-ID"), 10, 64)
+    client, backlog := m.feed.subscribe(after)
+    defer m.feed.unsubscribe(client)
+    
+    w.Header().Set("Content-Type", "text/event-stream")
+    w.Header().Set("Cache-Control", "no-cache")
+    w.Header().Set("Connection", "keep-alive")
+    fmt.Fprint(w, "retry: 2000\n\n")
+    for _, event := range backlog {
+        writeFeedEvent(w, event)
+    }
+    flusher.Flush()
+    
+    heartbeat := time.NewTicker(feedHeartbeat)
+    defer heartbeat.Stop()
+    
+    for {
+        select {
+        case <-r.Context().Done():
+            return
+        case event := <-client:
+            writeFeedEvent(w, event)
+            flusher.Flush()
+        case <-heartbeat.C:
+            fmt.Fprint(w, ": keepalive\n\n")
+            flusher.Flush()
+        }
+    }
+}
+
+func writeFeedEvent(w http.ResponseWriter, event feedEvent) {
+    fmt.Fprintf(w, "id: %d\nevent: opportunity\ndata: %s\n\n", event.id, event.data)
+}
\ No newline at end of file
@@ -0,0 +1,177 @@
+package monitoring
+
+import (
+    "net/http"
+    "strconv"
+    "sync"
+    "time"
+)
+
+const (
+    // defaultOpportunityHistory is how many opportunities are kept until
+    // SetOpportunityHistory says otherwise.
+    defaultOpportunityHistory = 1000
+    // defaultHistoryLimit is how many records GET /opportunities returns
+    // without a limit parameter.
+    defaultHistoryLimit = 100
+)
+
+// OpportunityRecord is an opportunity as returned by GET /opportunities.
+// Executed is set once a transaction for it settled successfully.
+type OpportunityRecord struct {
+    FeedOpportunity
+    Executed bool `json:"executed"`
+}
+
+// OpportunityPage is the body of GET /opportunities. More is set when
+// records past the last one matched but were cut off by the limit.
+type OpportunityPage struct {
+    Opportunities []OpportunityRecord `json:"opportunities"`
+    More          bool                `json:"more"`
+}
+
+// opportunityHistory keeps the most recent opportunities, oldest first, up
+// to size.
+type opportunityHistory struct {
+    mu      sync.Mutex
+    size    int
+    records []OpportunityRecord
+}
+
+func newOpportunityHistory(size int) *opportunityHistory {
+    return &opportunityHistory{size: size}
+}
+
+func (h *opportunityHistory) add(record OpportunityRecord) {
+    h.mu.Lock()
+    defer h.mu.Unlock()
+    
+    h.records = append(h.records, record)
+    if len(h.records) > h.size {
+        h.records = append(h.records[:0:0], h.records[len(h.records)-h.size:]...)
+    }
+}
+
+// markExecuted flags the retained opportunity with id as executed.
+func (h *opportunityHistory) markExecuted(id string) {
+    h.mu.Lock()
+    defer h.mu.Unlock()
+    
+    for i := len(h.records) - 1; i >= 0; i-- {
+        if h.records[i].ID == id {
+            h.records[i].Executed = true
+            return
+        }
+    }
+}
+
+// historyQuery selects records for an asset, if set, and detected after
+// since, if set.
+type historyQuery struct {
+    asset    *uint32
+    since    time.Time
+    hasSince bool
+    limit    int
+}
+
+// query returns up to q.limit matching records, oldest first, and whether
+// more matched. Without since the newest matches are returned, so the first
+// page is the most recent activity; with since the oldest matches after it
+// are, so a client pages forward by passing the last timestamp it saw.
+func (h *opportunityHistory) query(q historyQuery) OpportunityPage {
+    h.mu.Lock()
+    defer h.mu.Unlock()
+    
+    matched := make([]OpportunityRecord, 0)
+    for _, record := range h.records {
+        if q.asset != nil && record.AssetID != *q.asset {
+            continue
+        }
+        if q.hasSince && !record.Timestamp.After(q.since) {
+            continue
+        }
+        matched = append(matched, record)
+    }
+    
+    page := OpportunityPage{Opportunities: matched, More: len(matched) > q.limit}
+    if !page.More {
+        return page
+    }
+    if q.hasSince {
+        page.Opportunities = matched[:q.limit]
+    } else {
+        page.Opportunities = matched[len(matched)-q.limit:]
+    }
+    return page
+}
+
+// SetOpportunityHistory sets how many recent opportunities are kept for GET
+// /opportunities. It must be called before opportunities are published.
+func (m *Monitor) SetOpportunityHistory(size int) {
+    m.mutex.Lock()
+    defer m.mutex.Unlock()
+    m.history = newOpportunityHistory(size)
+}
+
+// RecordExecutedOpportunity marks the opportunity with id as executed in
+// the history.
+func (m *Monitor) RecordExecutedOpportunity(id string) {
+    m.opportunityHistory().markExecuted(id)
+}
+
+func (m *Monitor) opportunityHistory() *opportunityHistory {
+    m.mutex.RLock()
+    defer m.mutex.RUnlock()
+    return m.history
+}
+
+// opportunitiesHandler serves GET /opportunities. asset takes an asset id or
+// registry symbol, since an RFC 3339 time and limit a positive count.
+func (m *Monitor) opportunitiesHandler(w http.ResponseWriter, r *http.Request) {
+    history := m.opportunityHistory()
+    params := r.URL.Query()
+    q := historyQuery{limit: defaultHistoryLimit}
+    
+    if raw := params.Get("asset"); raw != "" {
+        asset, err := m.resolveAsset(raw)
+        if err != nil {
+            writeError(w, http.StatusBadRequest, "unknown asset")
+            return
+        }
+        q.asset = &asset
+    }
+    if raw := params.Get("since"); raw != "" {
+        since, err := time.Parse(time.RFC3339Nano, raw)
+        if err != nil {
+            writeError(w, http.StatusBadRequest, "invalid since: want an RFC 3339 time")
+            return
+        }
+        q.since, q.hasSince = since, true
+    }
+    if raw := params.Get("limit"); raw != "" {
+        limit, err := strconv.Atoi(raw)
+        if err != nil || limit <= 0 {
+            writeError(w, http.StatusBadRequest, "invalid limit")
+            return
+        }
+        q.limit = limit
+    }
+    if q.limit > history.size {
+        q.limit = history.size
+    }
+    
+    writeJSON(w, http.StatusOK, history.query(q))
+}
+
+// resolveAsset parses an asset id, or a registry symbol when raw is not a
+// number.
+func (m *Monitor) resolveAsset(raw string) (uint32, error) {
+    if id, err := strconv.ParseUint(raw, 10, 32); err == nil {
+        return uint32(id), nil
+    }
+    asset, err := m.assets.Resolve(raw)
+    if err != nil {
+        return 0, err
+    }
+    return asset.ID, nil
+}
\ No newline at end of file
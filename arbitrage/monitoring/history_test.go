@@ -0,0 +1,93 @@
+package monitoring
+
+import (
+    "encoding/json"
+    "math/big"
+    "net/http"
+    "net/http/httptest"
+    "net/url"
+    "reflect"
+    "strconv"
+    "testing"
+    "time"
+
+    "github.com/hypercore-suite/arbitrage/registry"
+    "github.com/hypercore-suite/arbitrage/trade"
+)
+
+func TestOpportunitiesHandler(t *testing.T) {
+    // Five opportunities a second apart, alternating between BTC (0) and
+    // ETH (1): opp-0 BTC, opp-1 ETH, opp-2 BTC, opp-3 ETH, opp-4 BTC.
+    start := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+    at := func(i int) string { return start.Add(time.Duration(i) * time.Second).Format(time.RFC3339Nano) }
+    
+    tests := []struct {
+        name     string
+        history  int
+        query    url.Values
+        wantIDs  []string
+        wantMore bool
+    }{
+        {"everything, oldest first", 10, nil, []string{"opp-0", "opp-1", "opp-2", "opp-3", "opp-4"}, false},
+        {"by asset id", 10, url.Values{"asset": {"1"}}, []string{"opp-1", "opp-3"}, false},
+        {"by symbol", 10, url.Values{"asset": {"btc"}}, []string{"opp-0", "opp-2", "opp-4"}, false},
+        {"limit keeps the newest", 10, url.Values{"limit": {"2"}}, []string{"opp-3", "opp-4"}, true},
+        {"since pages forward", 10, url.Values{"since": {at(1)}, "limit": {"2"}}, []string{"opp-2", "opp-3"}, true},
+        {"since to the end", 10, url.Values{"since": {at(2)}}, []string{"opp-3", "opp-4"}, false},
+        {"asset and limit", 10, url.Values{"asset": {"0"}, "limit": {"1"}}, []string{"opp-4"}, true},
+        {"limit past the history size", 3, url.Values{"limit": {"50"}}, []string{"opp-2", "opp-3", "opp-4"}, false},
+        {"nothing matches", 10, url.Values{"since": {at(4)}}, []string{}, false},
+    }
+    
+    assets, err := registry.New([]registry.Asset{{ID: 0, Symbol: "BTC"}, {ID: 1, Symbol: "ETH"}})
+    if err != nil {
+        t.Fatalf("registry: %v", err)
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            m := NewMonitor(nil, assets)
+            m.SetOpportunityHistory(tt.history)
+            for i := 0; i < 5; i++ {
+                m.Publish(&trade.Opportunity{
+                    ID:        "opp-" + strconv.Itoa(i),
+                    Asset:     uint32(i % 2),
+                    Spread:    big.NewInt(50000000),
+                    SpreadBps: 50,
+                    Amount:    big.NewInt(100000000),
+                    Timestamp: start.Add(time.Duration(i) * time.Second),
+                })
+            }
+            
+            rec := httptest.NewRecorder()
+            m.opportunitiesHandler(rec, httptest.NewRequest(http.MethodGet, "/opportunities?"+tt.query.Encode(), nil))
+            if rec.Code != http.StatusOK {
+                t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusOK, rec.Body)
+            }
+            
+            var page OpportunityPage
+            if err := json.Unmarshal(rec.Body.Bytes(), &page); err != nil {
+                t.Fatalf("response is not a page: %v", err)
+            }
+            ids := make([]string, 0, len(page.Opportunities))
+            for _, record := range page.Opportunities {
+                ids = append(ids, record.ID)
+            }
+            if !reflect.DeepEqual(ids, tt.wantIDs) || page.More != tt.wantMore {
+                t.Fatalf("got %v (more %v), want %v (more %v)", ids, page.More, tt.wantIDs, tt.wantMore)
+            }
+        })
+    }
+}
+
+func TestOpportunitiesHandlerRejectsBadQueries(t *testing.T) {
+    for _, query := range []string{"asset=DOGE", "since=yesterday", "limit=0", "limit=-1", "limit=ten"} {
+        t.Run(query, func(t *testing.T) {
+            rec := httptest.NewRecorder()
+            NewMonitor(nil, nil).opportunitiesHandler(rec, httptest.NewRequest(http.MethodGet, "/opportunities?"+query, nil))
+            if rec.Code != http.StatusBadRequest {
+                t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+            }
+        })
+    }
+}
@@ -0,0 +1,75 @@
+package monitoring
+
+import (
+    "encoding/json"
+    "net/http"
+    "strings"
+
+    "github.com/sirupsen/logrus"
+)
+
+// errorResponse is the body of every error the monitoring endpoints return.
+type errorResponse struct {
+    Error string `json:"error"`
+}
+
+// SetLogger sets where the monitoring server logs, e.g. recovered handler
+// panics. The standard logrus logger is used until it is called.
+func (m *Monitor) SetLogger(logger *logrus.Logger) {
+    m.mutex.Lock()
+    defer m.mutex.Unlock()
+    m.logger = logger
+}
+
+// endpoint wraps next so it only serves the given methods, answering others
+// with 405, and so a panic in it is logged and answered with 500 instead of
+// taking down the server's connection handling. No methods allows any.
+func (m *Monitor) endpoint(next http.Handler, methods ...string) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        defer func() {
+            if p := recover(); p != nil {
+                if p == http.ErrAbortHandler {
+                    panic(p)
+                }
+                m.mutex.RLock()
+                logger := m.logger
+                m.mutex.RUnlock()
+                logger.WithFields(logrus.Fields{"panic": p, "path": r.URL.Path}).Error("Monitoring handler panicked")
+                writeError(w, http.StatusInternalServerError, "internal error")
+            }
+        }()
+        
+        if len(methods) > 0 && !allowed(r.Method, methods) {
+            w.Header().Set("Allow", strings.Join(methods, ", "))
+            writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+            return
+        }
+        next.ServeHTTP(w, r)
+    })
+}
+
+func allowed(method string, methods []string) bool {
+    for _, m := range methods {
+        if method == m {
+            return true
+        }
+    }
+    return false
+}
+
+// writeJSON writes v as the JSON body of a status response.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+    w.Header().Set("Content-Type", "application/json")
+    w.WriteHeader(status)
+    json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes a JSON error body with status.
+func writeError(w http.ResponseWriter, status int, msg string) {
+    writeJSON(w, status, errorResponse{Error: msg})
+}
+
+// notFoundHandler answers paths no endpoint is registered for.
+func notFoundHandler(w http.ResponseWriter, r *http.Request) {
+    writeError(w, http.StatusNotFound, "not found")
+}
\ No newline at end of file
@@ -0,0 +1,97 @@
+package monitoring
+
+import (
+    "bytes"
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "testing"
+
+    "github.com/sirupsen/logrus"
+)
+
+func TestEndpoint(t *testing.T) {
+    ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        writeJSON(w, http.StatusOK, map[string]bool{"ok": true})
+    })
+    panics := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        panic("boom")
+    })
+    
+    tests := []struct {
+        name      string
+        handler   http.Handler
+        methods   []string
+        method    string
+        want      int
+        wantError string
+        wantAllow string
+    }{
+        {"allowed method", ok, []string{http.MethodGet}, http.MethodGet, http.StatusOK, "", ""},
+        {"second allowed method", ok, []string{http.MethodGet, http.MethodPost}, http.MethodPost, http.StatusOK, "", ""},
+        {"other method", ok, []string{http.MethodGet, http.MethodPost}, http.MethodDelete, http.StatusMethodNotAllowed, "method not allowed", "GET, POST"},
+        {"any method", ok, nil, http.MethodPut, http.StatusOK, "", ""},
+        {"panic", panics, []string{http.MethodGet}, http.MethodGet, http.StatusInternalServerError, "internal error", ""},
+        {"method checked before the handler runs", panics, []string{http.MethodPost}, http.MethodGet, http.StatusMethodNotAllowed, "method not allowed", "POST"},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            var logs bytes.Buffer
+            logger := logrus.New()
+            logger.SetOutput(&logs)
+            m := NewMonitor(nil, nil)
+            m.SetLogger(logger)
+            
+            rec := httptest.NewRecorder()
+            m.endpoint(tt.handler, tt.methods...).ServeHTTP(rec, httptest.NewRequest(tt.method, "/test", nil))
+            if rec.Code != tt.want {
+                t.Fatalf("status = %d, want %d", rec.Code, tt.want)
+            }
+            if got := rec.Header().Get("Content-Type"); got != "application/json" {
+                t.Fatalf("content type = %q, want application/json", got)
+            }
+            if got := rec.Header().Get("Allow"); got != tt.wantAllow {
+                t.Fatalf("Allow = %q, want %q", got, tt.wantAllow)
+            }
+            
+            var body errorResponse
+            if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+                t.Fatalf("body is not JSON: %v", err)
+            }
+            if body.Error != tt.wantError {
+                t.Fatalf("error = %q, want %q", body.Error, tt.wantError)
+            }
+            if logged := strings.Contains(logs.String(), "Monitoring handler panicked"); logged != (tt.want == http.StatusInternalServerError) {
+                t.Fatalf("panic logged = %v: %s", logged, logs.String())
+            }
+        })
+    }
+}
+
+func TestEndpointRepanicsAbort(t *testing.T) {
+    // http.ErrAbortHandler is how a handler asks the server to drop the
+    // connection, so it must reach the server rather than become a 500.
+    abort := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        panic(http.ErrAbortHandler)
+    })
+    rec := httptest.NewRecorder()
+    defer func() {
+        if p := recover(); p != http.ErrAbortHandler {
+            t.Fatalf("recovered %v, want http.ErrAbortHandler", p)
+        }
+        if rec.Body.Len() != 0 {
+            t.Fatalf("aborted request answered with %q", rec.Body)
+        }
+    }()
+    NewMonitor(nil, nil).endpoint(abort).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/test", nil))
+}
+
+func TestNotFoundHandler(t *testing.T) {
+    rec := httptest.NewRecorder()
+    notFoundHandler(rec, httptest.NewRequest(http.MethodGet, "/missing", nil))
+    if rec.Code != http.StatusNotFound || strings.TrimSpace(rec.Body.String()) != `{"error":"not found"}` {
+        t.Fatalf("got %d %s, want a JSON 404", rec.Code, rec.Body)
+    }
+}
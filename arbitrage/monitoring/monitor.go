@@ -2,28 +2,167 @@ package monitoring
 
 import (
     "math/big"
+    "net"
     "net/http"
+    "sort"
+    "strconv"
     "sync"
     "time"
 
+    "github.com/hypercore-suite/arbitrage/pricing"
+    "github.com/hypercore-suite/arbitrage/registry"
     "github.com/prometheus/client_golang/prometheus"
     "github.com/prometheus/client_golang/prometheus/promhttp"
+    "github.com/sirupsen/logrus"
 )
 
+// rpcMethods is the fixed set of RPC operations whose latency is recorded.
+// Anything else is reported as "other" to keep the label cardinality bounded.
+var rpcMethods = map[string]bool{
+    "getPerpPrice":          true,
+    "getSpotPrice":          true,
+    "getFundingRate":        true,
+    "getOrderBook":          true,
+    "dryRun":                true,
+    "estimateGas":           true,
+    "getPendingHeader":      true,
+    "sendTransaction":       true,
+    "getTransactionReceipt": true,
+}
+
+// maxAgeSamples bounds how many recent executed-opportunity ages are kept for
+// the /stats median.
+const maxAgeSamples = 1000
+
+// BreakerResetter is implemented by components whose circuit breaker can be
+// reset through the /breaker/reset endpoint.
+type BreakerResetter interface {
+    ResetBreaker()
+}
+
+// AlertSink receives the execution outcomes and breaker trips operators may
+// be paged on. Implementations must not block.
+type AlertSink interface {
+    Execution(asset uint32, profit *big.Int, success bool)
+    BreakerTripped()
+    LowGasBalance(wallet string, balance *big.Int)
+    NoOpportunities(label string, idle time.Duration)
+}
+
+type Health struct {
+    Status  string   `json:"status"`
+    Failing []string `json:"failing,omitempty"`
+}
+
+type Stats struct {
+    UptimeSeconds    int64  `json:"uptime_seconds"`
+    TotalExecutions  uint64 `json:"total_executions"`
+    TotalProfit      string `json:"total_profit"`
+    AverageProfit    string `json:"average_profit"`
+    DryRunExecutions uint64 `json:"dry_run_executions"`
+    DryRunProfit     string `json:"dry_run_profit"`
+    TotalGasSpentWei string `json:"total_gas_spent_wei"`
+    TotalNetProfit   string `json:"total_net_profit"`
+    Paused           bool   `json:"paused"`
+    Phase            string `json:"phase"`
+    
+    PausedBy []string `json:"paused_by,omitempty"`
+    
+    MedianExecutedAgeMs float64 `json:"median_executed_age_ms"`
+    
+    Rejections      map[string]uint64            `json:"rejections"`
+    ConversionRatio map[string]float64           `json:"conversion_ratio"`
+    RollingPnL      map[string]map[string]string `json:"rolling_pnl"`
+    AssetEnabled    map[string]bool              `json:"asset_enabled,omitempty"`
+    CooldownSeconds map[string]float64           `json:"cooldown_remaining_seconds,omitempty"`
+}
+
 type Monitor struct {
-    mutex           sync.RWMutex
-    opportunities   *prometheus.CounterVec
-    executions      *prometheus.CounterVec
-    profits         *prometheus.HistogramVec
-    spreads         *prometheus.GaugeVec
-    executionTime   *prometheus.HistogramVec
+    mutex          sync.RWMutex
+    registry       *prometheus.Registry
+    assets         *registry.Registry
+    opportunities  *prometheus.CounterVec
+    executions     *prometheus.CounterVec
+    profits        *prometheus.HistogramVec
+    spreads        *prometheus.GaugeVec
+    spreadHist     *prometheus.HistogramVec
+    executionTime  *prometheus.HistogramVec
+    stalePrices    *prometheus.CounterVec
+    rpcDegraded    *prometheus.GaugeVec
+    breakerTripped prometheus.Gauge
+    gasSpent       *prometheus.CounterVec
+    netProfits     *prometheus.HistogramVec
+    conversion     *prometheus.GaugeVec
+    walletExecs    *prometheus.CounterVec
+    walletBalance  *prometheus.GaugeVec
+    gasBalance     *prometheus.GaugeVec
+    pausedGauge    prometheus.Gauge
+    rejections     *prometheus.CounterVec
+    rpcLatency     *prometheus.HistogramVec
+    executedAge    *prometheus.HistogramVec
+    exposure       *prometheus.GaugeVec
+    dropped        *prometheus.CounterVec
+    dailyCap       prometheus.Gauge
+    profitSweeps   *prometheus.CounterVec
+    errorsByClass  *prometheus.CounterVec
+    sendRetries    *prometheus.CounterVec
+    routes         *prometheus.CounterVec
+    competition    *prometheus.GaugeVec
+    reconciliation *prometheus.GaugeVec
+    tradeRate      prometheus.Gauge
+    sinceOpp       *prometheus.GaugeVec
+    droughtActive  *prometheus.GaugeVec
+    oracleSanity   *prometheus.CounterVec
+    cycleOpps      *prometheus.CounterVec
+    cycleSpreads   *prometheus.GaugeVec
+    breaker        BreakerResetter
+    alerts         AlertSink
+    tripped        bool
+    lowGas         map[string]bool
+    
+    controller   TradingController
+    controlToken string
+    statsToken   string
+    openMetrics  bool
+    paused       bool
+    pausedBy     []string
+    phase        string
+    toggles      AssetToggler
+    simulator    Simulator
+    thresholds   ThresholdStore
+    logger       *logrus.Logger
+    
+    rpcHealthy map[string]bool
+    lastTick   time.Time
+    maxTickGap time.Duration
     
     totalProfit     *big.Int
     totalExecutions uint64
     startTime       time.Time
+    
+    dryRunProfit     *big.Int
+    dryRunExecutions uint64
+    
+    totalGasSpent  *big.Int
+    totalNetProfit *big.Int
+    
+    detectedByAsset  map[uint32]uint64
+    succeededByAsset map[uint32]uint64
+    rejectedByReason map[string]uint64
+    pnl              *rollingPnL
+    executedAges     []time.Duration
+    drought          *opportunityDrought
+    cooldownUntil    map[uint32]time.Time
+    feed             *opportunityFeed
+    history          *opportunityHistory
+    
+    now func() time.Time
 }
 
-func NewMonitor() *Monitor {
+// NewMonitor builds a monitor that reports each asset's realized PnL over
+// pnlWindows, in addition to the lifetime totals. Assets are labeled with
+// their symbols from assets.
+func NewMonitor(pnlWindows []time.Duration, assets *registry.Registry) *Monitor {
     opportunities := prometheus.NewCounterVec(
         prometheus.CounterOpts{
             Name: "arbitrage_opportunities_total",
@@ -37,16 +176,16 @@ func NewMonitor() *Monitor {
             Name: "arbitrage_executions_total",
             Help: "Total number of arbitrage executions",
         },
-        []string{"asset", "success"},
+        []string{"asset", "success", "dry_run"},
     )
     
     profits := prometheus.NewHistogramVec(
         prometheus.HistogramOpts{
             Name:    "arbitrage_profit_usd",
             Help:    "Profit distribution in USD",
-            Buckets: prometheus.ExponentialBuckets(1, 2, 10),
+            Buckets: []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 25, 50, 100, 250, 500, 1000},
         },
-        []string{"asset"},
+        []string{"asset", "dry_run"},
     )
     
     spreads := prometheus.NewGaugeVec(
@@ -57,6 +196,15 @@ func NewMonitor() *Monitor {
         []string{"asset"},
     )
     
+    spreadHist := prometheus.NewHistogramVec(
+        prometheus.HistogramOpts{
+            Name:    "arbitrage_spread_bps",
+            Help:    "Distribution of the spread of detected opportunities in basis points",
+            Buckets: []float64{5, 10, 15, 20, 25, 30, 40, 50, 75, 100, 150, 200, 300, 500, 1000},
+        },
+        []string{"asset"},
+    )
+    
     executionTime := prometheus.NewHistogramVec(
         prometheus.HistogramOpts{
             Name:    "arbitrage_execution_time_ms",
@@ -66,33 +214,475 @@ func NewMonitor() *Monitor {
         []string{"asset"},
     )
     
-    prometheus.MustRegister(opportunities, executions, profits, spreads, executionTime)
+    stalePrices := prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "arbitrage_stale_price_total",
+            Help: "Total number of opportunity checks skipped due to stale oracle prices",
+        },
+        []string{"asset"},
+    )
+    
+    rpcDegraded := prometheus.NewGaugeVec(
+        prometheus.GaugeOpts{
+            Name: "arbitrage_rpc_degraded",
+            Help: "Whether an RPC endpoint is failing after retries (1) or healthy (0)",
+        },
+        []string{"endpoint"},
+    )
+    
+    breakerTripped := prometheus.NewGauge(
+        prometheus.GaugeOpts{
+            Name: "arbitrage_circuit_breaker_tripped",
+            Help: "Whether the executor circuit breaker is halting trading (1) or closed (0)",
+        },
+    )
+    
+    gasSpent := prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "arbitrage_gas_spent_wei",
+            Help: "Total gas fees paid by settled arbitrage transactions in wei",
+        },
+        []string{"asset"},
+    )
+    
+    netProfits := prometheus.NewHistogramVec(
+        prometheus.HistogramOpts{
+            Name:    "arbitrage_net_profit_usd",
+            Help:    "Realized profit after gas fees in USD",
+            Buckets: []float64{-100, -10, -1, 0, 1, 10, 100, 1000},
+        },
+        []string{"asset"},
+    )
+    
+    conversion := prometheus.NewGaugeVec(
+        prometheus.GaugeOpts{
+            Name: "arbitrage_conversion_ratio",
+            Help: "Fraction of detected opportunities that executed successfully",
+        },
+        []string{"asset"},
+    )
+    
+    walletExecs := prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "arbitrage_wallet_executions_total",
+            Help: "Total number of arbitrage executions per executor wallet",
+        },
+        []string{"wallet", "success"},
+    )
+    
+    walletBalance := prometheus.NewGaugeVec(
+        prometheus.GaugeOpts{
+            Name: "arbitrage_wallet_balance_usd",
+            Help: "Last observed trading balance of each executor wallet in USD",
+        },
+        []string{"wallet"},
+    )
+    
+    pausedGauge := prometheus.NewGauge(
+        prometheus.GaugeOpts{
+            Name: "arbitrage_trading_paused",
+            Help: "Whether trading has been paused through the control endpoint or kill switch file (1) or not (0)",
+        },
+    )
+    
+    rejections := prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "arbitrage_rejections_total",
+            Help: "Total number of opportunities rejected before execution, by reason",
+        },
+        []string{"asset", "reason"},
+    )
+    
+    rpcLatency := prometheus.NewHistogramVec(
+        prometheus.HistogramOpts{
+            Name:    "arbitrage_rpc_call_duration_ms",
+            Help:    "RPC call latency in milliseconds by operation",
+            Buckets: prometheus.ExponentialBuckets(1, 2, 12),
+        },
+        []string{"method"},
+    )
+    
+    executedAge := prometheus.NewHistogramVec(
+        prometheus.HistogramOpts{
+            Name:    "arbitrage_executed_opportunity_age_ms",
+            Help:    "Age of opportunities when their transaction was sent in milliseconds",
+            Buckets: []float64{10, 25, 50, 100, 200, 300, 400, 500, 750, 1000, 2000},
+        },
+        []string{"success"},
+    )
+    
+    exposure := prometheus.NewGaugeVec(
+        prometheus.GaugeOpts{
+            Name: "arbitrage_position_size",
+            Help: "Last observed spot balance and perp position of each executor wallet per asset",
+        },
+        []string{"wallet", "asset", "market"},
+    )
+    
+    dropped := prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "arbitrage_opportunities_dropped_total",
+            Help: "Total number of detected opportunities dropped because the executor queue was full",
+        },
+        []string{"asset"},
+    )
+    
+    dailyCap := prometheus.NewGauge(
+        prometheus.GaugeOpts{
+            Name: "arbitrage_daily_cap_reached",
+            Help: "Whether a daily loss or volume cap is halting trading until UTC midnight (1) or not (0)",
+        },
+    )
+    
+    profitSweeps := prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "arbitrage_profit_conversions_total",
+            Help: "Total number of profit conversions into the settlement token by result",
+        },
+        []string{"result"},
+    )
+    
+    errorsByClass := prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "arbitrage_execution_errors_total",
+            Help: "Total number of execution errors by pipeline stage and failure class",
+        },
+        []string{"stage", "class"},
+    )
+    
+    gasBalance := prometheus.NewGaugeVec(
+        prometheus.GaugeOpts{
+            Name: "arbitrage_wallet_gas_balance",
+            Help: "Native gas token balance of each executor wallet, in whole tokens",
+        },
+        []string{"wallet"},
+    )
+    
+    sendRetries := prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "arbitrage_send_retries_total",
+            Help: "Total number of transaction broadcasts retried after a transient failure, by failure class",
+        },
+        []string{"class"},
+    )
+    
+    routes := prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "arbitrage_route_selected_total",
+            Help: "Total number of opportunities routed through each arbitrage contract route",
+        },
+        []string{"route"},
+    )
+    
+    competition := prometheus.NewGaugeVec(
+        prometheus.GaugeOpts{
+            Name: "arbitrage_competition_detected",
+            Help: "Whether other bots were recently seen in the mempool arbitraging each asset (1) or not (0)",
+        },
+        []string{"asset"},
+    )
+    
+    reconciliation := prometheus.NewGaugeVec(
+        prometheus.GaugeOpts{
+            Name: "arbitrage_reconciliation_usd",
+            Help: "Net profit recorded over the last reconciliation window, the wallets' actual balance change over it, and the gap between them, in USD",
+        },
+        []string{"kind"},
+    )
+    
+    tradeRate := prometheus.NewGauge(
+        prometheus.GaugeOpts{
+            Name: "arbitrage_trade_rate_per_minute",
+            Help: "Executions started over the last minute, after the minimum execution interval",
+        },
+    )
+    
+    sinceOpp := prometheus.NewGaugeVec(
+        prometheus.GaugeOpts{
+            Name: "arbitrage_seconds_since_opportunity",
+            Help: "Seconds since the last opportunity detected on each watched asset, or on any asset (all)",
+        },
+        []string{"asset"},
+    )
+    
+    droughtActive := prometheus.NewGaugeVec(
+        prometheus.GaugeOpts{
+            Name: "arbitrage_opportunity_drought",
+            Help: "Whether an asset, or every asset (all), has gone longer than the alert threshold without an opportunity (1) or not (0)",
+        },
+        []string{"asset"},
+    )
+    
+    oracleSanity := prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "arbitrage_oracle_sanity_fail_total",
+            Help: "Total number of oracle prices refused for an unexpected result layout, falling outside the sanity bounds or jumping too far since the previous read",
+        },
+        []string{"asset", "market", "reason"},
+    )
+    
+    cycleOpps := prometheus.NewCounterVec(
+        prometheus.CounterOpts{
+            Name: "arbitrage_cycle_opportunities_total",
+            Help: "Total number of profitable multi-hop cycles detected, by cycle",
+        },
+        []string{"cycle"},
+    )
+    
+    cycleSpreads := prometheus.NewGaugeVec(
+        prometheus.GaugeOpts{
+            Name: "arbitrage_cycle_profit_basis_points",
+            Help: "Net profit after fees of the latest opportunity on each multi-hop cycle in basis points",
+        },
+        []string{"cycle"},
+    )
+    
+    // Each monitor owns its registry so constructing more than one (tests,
+    // hot reload) does not collide in the global default registry.
+    registry := prometheus.NewRegistry()
+    registry.MustRegister(opportunities, executions, profits, spreads, spreadHist, executionTime, stalePrices, rpcDegraded, breakerTripped, gasSpent, netProfits, conversion, walletExecs, walletBalance, pausedGauge, rejections, rpcLatency, executedAge, exposure, dropped, dailyCap, profitSweeps, errorsByClass, gasBalance, sendRetries, routes, competition, reconciliation, tradeRate, sinceOpp, droughtActive, oracleSanity, cycleOpps, cycleSpreads)
+    
+    m := &Monitor{
+        logger:           logrus.StandardLogger(),
+        registry:         registry,
+        assets:           assets,
+        opportunities:    opportunities,
+        executions:       executions,
+        profits:          profits,
+        spreads:          spreads,
+        spreadHist:       spreadHist,
+        executionTime:    executionTime,
+        stalePrices:      stalePrices,
+        rpcDegraded:      rpcDegraded,
+        breakerTripped:   breakerTripped,
+        gasSpent:         gasSpent,
+        netProfits:       netProfits,
+        conversion:       conversion,
+        walletExecs:      walletExecs,
+        walletBalance:    walletBalance,
+        gasBalance:       gasBalance,
+        pausedGauge:      pausedGauge,
+        rejections:       rejections,
+        rpcLatency:       rpcLatency,
+        executedAge:      executedAge,
+        exposure:         exposure,
+        dropped:          dropped,
+        dailyCap:         dailyCap,
+        profitSweeps:     profitSweeps,
+        errorsByClass:    errorsByClass,
+        sendRetries:      sendRetries,
+        routes:           routes,
+        competition:      competition,
+        reconciliation:   reconciliation,
+        tradeRate:        tradeRate,
+        sinceOpp:         sinceOpp,
+        droughtActive:    droughtActive,
+        oracleSanity:     oracleSanity,
+        cycleOpps:        cycleOpps,
+        cycleSpreads:     cycleSpreads,
+        totalProfit:      big.NewInt(0),
+        totalExecutions:  0,
+        startTime:        time.Now(),
+        dryRunProfit:     big.NewInt(0),
+        rpcHealthy:       make(map[string]bool),
+        totalGasSpent:    big.NewInt(0),
+        totalNetProfit:   big.NewInt(0),
+        detectedByAsset:  make(map[uint32]uint64),
+        succeededByAsset: make(map[uint32]uint64),
+        rejectedByReason: make(map[string]uint64),
+        lowGas:           make(map[string]bool),
+        cooldownUntil:    make(map[uint32]time.Time),
+        pnl:              newRollingPnL(pnlWindows),
+        feed:             newOpportunityFeed(),
+        history:          newOpportunityHistory(defaultOpportunityHistory),
+        now:              time.Now,
+    }
     
-    return &Monitor{
-        opportunities:   opportunities,
-        executions:      executions,
-        profits:         profits,
-        spreads:         spreads,
-        executionTime:   executionTime,
-        totalProfit:     big.NewInt(0),
-        totalExecutions: 0,
-        startTime:       time.Now(),
+    registry.MustRegister(&pnlCollector{
+        monitor: m,
+        desc: prometheus.NewDesc(
+            "arbitrage_rolling_pnl_usd",
+            "Realized profit after gas over a trailing window in USD",
+            []string{"asset", "window"}, nil,
+        ),
+    })
+    return m
+}
+
+// Start serves the monitoring endpoints on addr, over TLS when both certFile
+// and keyFile are set. It blocks until the server fails and returns the
+// error, e.g. when the port is already in use.
+func (m *Monitor) Start(addr, certFile, keyFile string) error {
+    listener, err := net.Listen("tcp", addr)
+    if err != nil {
+        return err
     }
+    return m.Serve(listener, certFile, keyFile)
 }
 
-func (m *Monitor) Start(addr string) {
-    http.Handle("/metrics", promhttp.Handler())
-    http.HandleFunc("/stats", m.statsHandler)
-    http.ListenAndServe(addr, nil)
+// Serve serves the monitoring endpoints on listener like Start, for callers
+// that bind the address themselves.
+func (m *Monitor) Serve(listener net.Listener, certFile, keyFile string) error {
+    server := &http.Server{Handler: m.handler()}
+    if certFile != "" && keyFile != "" {
+        return server.ServeTLS(listener, certFile, keyFile)
+    }
+    return server.Serve(listener)
 }
 
-func (m *Monitor) RecordOpportunity(asset uint32, spread *big.Int) {
-    m.opportunities.WithLabelValues(string(rune(asset))).Inc()
+// handler routes every monitoring endpoint.
+func (m *Monitor) handler() http.Handler {
+    metrics := promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
     
-    spreadBps := new(big.Int).Mul(spread, big.NewInt(10000))
-    spreadBps.Div(spreadBps, big.NewInt(100000000))
+    // Every endpoint answers errors as JSON; wrong methods are refused before
+    // the token is checked.
+    mux := http.NewServeMux()
+    mux.Handle("/metrics", m.endpoint(m.requireStatsToken(metrics, true), http.MethodGet))
+    mux.Handle("/stats", m.endpoint(m.requireStatsToken(http.HandlerFunc(m.statsHandler), false), http.MethodGet))
+    mux.Handle("/breaker/reset", m.endpoint(http.HandlerFunc(m.breakerResetHandler), http.MethodPost))
+    mux.Handle("/opportunities", m.endpoint(m.requireStatsToken(http.HandlerFunc(m.opportunitiesHandler), false), http.MethodGet))
+    mux.Handle("/opportunities/stream", m.endpoint(m.requireStatsToken(http.HandlerFunc(m.opportunityStreamHandler), false), http.MethodGet))
+    mux.Handle("/simulate", m.endpoint(m.requireStatsToken(http.HandlerFunc(m.simulateHandler), false), http.MethodPost))
+    mux.Handle("/config", m.endpoint(http.HandlerFunc(m.configHandler), http.MethodGet, http.MethodPost))
+    mux.Handle("/pause", m.endpoint(http.HandlerFunc(m.pauseHandler), http.MethodPost))
+    mux.Handle("/resume", m.endpoint(http.HandlerFunc(m.resumeHandler), http.MethodPost))
+    mux.Handle("/asset/", m.endpoint(http.HandlerFunc(m.assetHandler), http.MethodPost))
+    mux.Handle("/health", m.endpoint(http.HandlerFunc(m.healthHandler), http.MethodGet))
+    mux.Handle("/", m.endpoint(http.HandlerFunc(notFoundHandler)))
+    return mux
+}
+
+func (m *Monitor) RecordOpportunity(asset uint32, spreadBps int64) {
+    m.opportunities.WithLabelValues(m.assets.Label(asset)).Inc()
+    m.spreads.WithLabelValues(m.assets.Label(asset)).Set(float64(spreadBps))
+    m.spreadHist.WithLabelValues(m.assets.Label(asset)).Observe(float64(spreadBps))
     
-    m.spreads.WithLabelValues(string(rune(asset))).Set(float64(spreadBps.Int64()))
+    m.mutex.Lock()
+    m.detectedByAsset[asset]++
+    if m.drought != nil {
+        m.drought.observe(asset, m.now())
+    }
+    m.mutex.Unlock()
+}
+
+func (m *Monitor) RecordStalePrice(asset uint32) {
+    m.stalePrices.WithLabelValues(m.assets.Label(asset)).Inc()
+}
+
+// RecordRejection counts an opportunity the executor turned down before
+// sending it, by reason.
+func (m *Monitor) RecordRejection(asset uint32, reason string) {
+    m.rejections.WithLabelValues(m.assets.Label(asset), reason).Inc()
+    
+    m.mutex.Lock()
+    m.rejectedByReason[reason]++
+    m.mutex.Unlock()
+}
+
+// RecordDropped counts an opportunity the detector could not queue because
+// the executor was not keeping up.
+func (m *Monitor) RecordDropped(asset uint32) {
+    m.dropped.WithLabelValues(m.assets.Label(asset)).Inc()
+}
+
+func (m *Monitor) RecordRPCHealth(endpoint string, healthy bool) {
+    degraded := 1.0
+    if healthy {
+        degraded = 0
+    }
+    m.rpcDegraded.WithLabelValues(endpoint).Set(degraded)
+    
+    m.mutex.Lock()
+    m.rpcHealthy[endpoint] = healthy
+    m.mutex.Unlock()
+}
+
+// RecordExecutedAge observes how stale an opportunity was when it was sent,
+// labeled by whether the execution succeeded.
+func (m *Monitor) RecordExecutedAge(age time.Duration, success bool) {
+    m.executedAge.WithLabelValues(strconv.FormatBool(success)).Observe(float64(age.Microseconds()) / 1000)
+    
+    m.mutex.Lock()
+    m.executedAges = append(m.executedAges, age)
+    if len(m.executedAges) > maxAgeSamples {
+        m.executedAges = m.executedAges[len(m.executedAges)-maxAgeSamples:]
+    }
+    m.mutex.Unlock()
+}
+
+// medianExecutedAge returns the median of the recent executed ages, or zero
+// before anything has been sent. Callers must hold the mutex.
+func (m *Monitor) medianExecutedAge() time.Duration {
+    if len(m.executedAges) == 0 {
+        return 0
+    }
+    
+    ages := append([]time.Duration(nil), m.executedAges...)
+    sort.Slice(ages, func(i, j int) bool { return ages[i] < ages[j] })
+    
+    mid := len(ages) / 2
+    if len(ages)%2 == 0 {
+        return (ages[mid-1] + ages[mid]) / 2
+    }
+    return ages[mid]
+}
+
+// RecordRPCLatency observes how long one RPC operation took.
+func (m *Monitor) RecordRPCLatency(method string, elapsed time.Duration) {
+    if !rpcMethods[method] {
+        method = "other"
+    }
+    m.rpcLatency.WithLabelValues(method).Observe(float64(elapsed.Microseconds()) / 1000)
+}
+
+// RecordTick marks a completed detector scan. The detector is considered
+// wedged if the next tick does not arrive within maxGap. Each tick also
+// checks for an opportunity drought, alerting on any that just began.
+func (m *Monitor) RecordTick(maxGap time.Duration) {
+    m.mutex.Lock()
+    m.lastTick = m.now()
+    m.maxTickGap = maxGap
+    crossed := m.checkDrought(m.lastTick)
+    alerts := m.alerts
+    m.mutex.Unlock()
+    
+    if alerts == nil {
+        return
+    }
+    for label, idle := range crossed {
+        alerts.NoOpportunities(label, idle)
+    }
+}
+
+func (m *Monitor) RecordBreakerState(tripped bool) {
+    value := 0.0
+    if tripped {
+        value = 1
+    }
+    m.breakerTripped.Set(value)
+    
+    m.mutex.Lock()
+    raise := tripped && !m.tripped && m.alerts != nil
+    m.tripped = tripped
+    m.mutex.Unlock()
+    
+    if raise {
+        m.alerts.BreakerTripped()
+    }
+}
+
+// SetAlerts registers the sink that operator alerts are raised through.
+func (m *Monitor) SetAlerts(a AlertSink) {
+    m.mutex.Lock()
+    defer m.mutex.Unlock()
+    m.alerts = a
+}
+
+// SetBreaker registers the circuit breaker reset by POST /breaker/reset.
+func (m *Monitor) SetBreaker(b BreakerResetter) {
+    m.mutex.Lock()
+    defer m.mutex.Unlock()
+    m.breaker = b
 }
 
 func (m *Monitor) RecordExecution(asset uint32, profit *big.Int, success bool) {
@@ -104,14 +694,182 @@ func (m *Monitor) RecordExecution(asset uint32, profit *big.Int, success bool) {
         successStr = "true"
         m.totalProfit.Add(m.totalProfit, profit)
         m.totalExecutions++
+        m.succeededByAsset[asset]++
     }
     
-    m.executions.WithLabelValues(string(rune(asset)), successStr).Inc()
+    m.conversion.WithLabelValues(m.assets.Label(asset)).Set(m.conversionRatio(asset))
+    
+    m.executions.WithLabelValues(m.assets.Label(asset), successStr, "false").Inc()
     
     if success && profit.Sign() > 0 {
-        profitUSD := new(big.Int).Div(profit, big.NewInt(100000000))
-        m.profits.WithLabelValues(string(rune(asset))).Observe(float64(profitUSD.Int64()))
+        m.profits.WithLabelValues(m.assets.Label(asset), "false").Observe(pricing.ToUSD(profit, pricing.USDDecimals))
+    }
+    
+    if m.alerts != nil {
+        m.alerts.Execution(asset, profit, success)
+    }
+}
+
+// RecordSettled records the on-chain outcome of a mined arbitrage
+// transaction: the gross profit, the gas fee from its receipt, and the net
+// result after converting that fee to USD.
+func (m *Monitor) RecordSettled(asset uint32, grossProfit, gasCostWei, netProfit *big.Int) {
+    m.mutex.Lock()
+    defer m.mutex.Unlock()
+    
+    m.totalGasSpent.Add(m.totalGasSpent, gasCostWei)
+    m.totalNetProfit.Add(m.totalNetProfit, netProfit)
+    
+    gasWei, _ := new(big.Float).SetInt(gasCostWei).Float64()
+    m.gasSpent.WithLabelValues(m.assets.Label(asset)).Add(gasWei)
+    
+    m.netProfits.WithLabelValues(m.assets.Label(asset)).Observe(pricing.ToUSD(netProfit, pricing.USDDecimals))
+    
+    m.pnl.add(asset, netProfit, time.Now())
+}
+
+// RecordDailyCap records whether a daily loss or volume cap is halting
+// trading.
+func (m *Monitor) RecordDailyCap(reached bool) {
+    value := 0.0
+    if reached {
+        value = 1
     }
+    m.dailyCap.Set(value)
+}
+
+// RecordConversion records the result of a profit conversion: "converted",
+// "failed" or "skipped" when its cost exceeded the profit.
+func (m *Monitor) RecordConversion(result string) {
+    m.profitSweeps.WithLabelValues(result).Inc()
+}
+
+// RecordError records an execution error by the stage it happened in and
+// its failure class.
+func (m *Monitor) RecordError(stage, class string) {
+    m.errorsByClass.WithLabelValues(stage, class).Inc()
+}
+
+// RecordSendRetry records a broadcast retried after a transient failure of
+// the given class.
+func (m *Monitor) RecordSendRetry(class string) {
+    m.sendRetries.WithLabelValues(class).Inc()
+}
+
+// RecordRouteSelected records the route chosen as the most profitable for
+// an opportunity.
+func (m *Monitor) RecordRouteSelected(route string) {
+    m.routes.WithLabelValues(route).Inc()
+}
+
+// RecordCompetition flags whether competing arbitrage for asset has been
+// seen in the mempool within the competition window.
+func (m *Monitor) RecordCompetition(asset uint32, contested bool) {
+    value := 0.0
+    if contested {
+        value = 1
+    }
+    m.competition.WithLabelValues(m.assets.Label(asset)).Set(value)
+}
+
+// RecordReconciliation records one reconciliation window: the net profit
+// recorded for settled trades and the actual change in wallet value, both in
+// 1e8 USD, and the gap between them.
+func (m *Monitor) RecordReconciliation(recorded, actual *big.Int) {
+    gap := new(big.Int).Sub(actual, recorded)
+    m.reconciliation.WithLabelValues("recorded").Set(pricing.ToUSD(recorded, pricing.USDDecimals))
+    m.reconciliation.WithLabelValues("actual").Set(pricing.ToUSD(actual, pricing.USDDecimals))
+    m.reconciliation.WithLabelValues("gap").Set(pricing.ToUSD(gap, pricing.USDDecimals))
+}
+
+// RecordTradeRate records how many executions started over the last minute.
+func (m *Monitor) RecordTradeRate(perMinute float64) {
+    m.tradeRate.Set(perMinute)
+}
+
+// RecordOracleSanityFail counts an oracle price for asset's market refused
+// by the detector's sanity checks, by reason.
+func (m *Monitor) RecordOracleSanityFail(asset uint32, market, reason string) {
+    m.oracleSanity.WithLabelValues(m.assets.Label(asset), market, reason).Inc()
+}
+
+// RecordCycleOpportunity records a profitable multi-hop cycle, labelled by
+// its token path, apart from the single-pair opportunity metrics.
+func (m *Monitor) RecordCycleOpportunity(cycle string, netBps int64) {
+    m.cycleOpps.WithLabelValues(cycle).Inc()
+    m.cycleSpreads.WithLabelValues(cycle).Set(float64(netBps))
+}
+
+// RecordWalletBalance records the trading balance of an executor wallet, in
+// 1e8 USD fixed point.
+func (m *Monitor) RecordWalletBalance(wallet string, balance *big.Int) {
+    m.walletBalance.WithLabelValues(wallet).Set(pricing.ToUSD(balance, pricing.USDDecimals))
+}
+
+// RecordGasBalance records a wallet's native balance, in wei, and whether it
+// is below the gas floor. An alert is raised when a wallet first drops below.
+func (m *Monitor) RecordGasBalance(wallet string, balance *big.Int, low bool) {
+    m.gasBalance.WithLabelValues(wallet).Set(pricing.ToUSD(balance, pricing.NativeDecimals))
+    
+    m.mutex.Lock()
+    raise := low && !m.lowGas[wallet] && m.alerts != nil
+    m.lowGas[wallet] = low
+    m.mutex.Unlock()
+    
+    if raise {
+        m.alerts.LowGasBalance(wallet, balance)
+    }
+}
+
+// RecordCooldown records when an asset's post-failure cooldown ends; the
+// zero time clears it.
+func (m *Monitor) RecordCooldown(asset uint32, until time.Time) {
+    m.mutex.Lock()
+    defer m.mutex.Unlock()
+    
+    if until.IsZero() {
+        delete(m.cooldownUntil, asset)
+        return
+    }
+    m.cooldownUntil[asset] = until
+}
+
+// RecordExposure records a wallet's perp position and spot balance in an
+// asset, in 1e8 fixed point.
+func (m *Monitor) RecordExposure(wallet string, asset uint32, perp, spot *big.Int) {
+    m.exposure.WithLabelValues(wallet, m.assets.Label(asset), "perp").Set(pricing.ToUSD(perp, pricing.USDDecimals))
+    m.exposure.WithLabelValues(wallet, m.assets.Label(asset), "spot").Set(pricing.ToUSD(spot, pricing.USDDecimals))
+}
+
+// RecordWalletExecution counts an execution settled by an executor wallet.
+func (m *Monitor) RecordWalletExecution(wallet string, success bool) {
+    m.walletExecs.WithLabelValues(wallet, strconv.FormatBool(success)).Inc()
+}
+
+// RecordDryRun records a paper trade that passed validation and simulation
+// but was not broadcast. Its profit is tracked apart from real executions.
+func (m *Monitor) RecordDryRun(asset uint32, profit *big.Int) {
+    m.mutex.Lock()
+    defer m.mutex.Unlock()
+    
+    m.dryRunProfit.Add(m.dryRunProfit, profit)
+    m.dryRunExecutions++
+    
+    m.executions.WithLabelValues(m.assets.Label(asset), "true", "true").Inc()
+    
+    if profit.Sign() > 0 {
+        m.profits.WithLabelValues(m.assets.Label(asset), "true").Observe(pricing.ToUSD(profit, pricing.USDDecimals))
+    }
+}
+
+// conversionRatio returns successful executions over detected opportunities
+// for asset, or 0 before any opportunity has been seen. Callers hold m.mutex.
+func (m *Monitor) conversionRatio(asset uint32) float64 {
+    detected := m.detectedByAsset[asset]
+    if detected == 0 {
+        return 0
+    }
+    return float64(m.succeededByAsset[asset]) / float64(detected)
 }
 
 func (m *Monitor) statsHandler(w http.ResponseWriter, r *http.Request) {
@@ -121,14 +879,102 @@ func (m *Monitor) statsHandler(w http.ResponseWriter, r *http.Request) {
     uptime := time.Since(m.startTime)
     avgProfit := new(big.Int)
     if m.totalExecutions > 0 {
-        avgProfit.Div(m.totalProfit, big.NewInt(int64(m.totalExecutions)))
+        avgProfit = pricing.Div(m.totalProfit, big.NewInt(int64(m.totalExecutions)), pricing.RoundHalfUp)
+    }
+    
+    stats := Stats{
+        UptimeSeconds:    int64(uptime.Seconds()),
+        TotalExecutions:  m.totalExecutions,
+        TotalProfit:      m.totalProfit.String(),
+        AverageProfit:    avgProfit.String(),
+        DryRunExecutions: m.dryRunExecutions,
+        DryRunProfit:     m.dryRunProfit.String(),
+        TotalGasSpentWei: m.totalGasSpent.String(),
+        TotalNetProfit:   m.totalNetProfit.String(),
+        Paused:           m.paused,
+        Phase:            m.phase,
+        PausedBy:         m.pausedBy,
+        Rejections:       make(map[string]uint64),
+        ConversionRatio:  make(map[string]float64),
+        RollingPnL:       make(map[string]map[string]string),
+        
+        MedianExecutedAgeMs: float64(m.medianExecutedAge().Microseconds()) / 1000,
+    }
+    for asset := range m.detectedByAsset {
+        stats.ConversionRatio[m.assets.Label(asset)] = m.conversionRatio(asset)
+    }
+    for reason, count := range m.rejectedByReason {
+        stats.Rejections[reason] = count
+    }
+    if m.toggles != nil {
+        stats.AssetEnabled = make(map[string]bool)
+        for asset, enabled := range m.toggles.States() {
+            stats.AssetEnabled[m.assets.Label(asset)] = enabled
+        }
+    }
+    
+    now := time.Now()
+    for asset, until := range m.cooldownUntil {
+        if !now.Before(until) {
+            continue
+        }
+        if stats.CooldownSeconds == nil {
+            stats.CooldownSeconds = make(map[string]float64)
+        }
+        stats.CooldownSeconds[m.assets.Label(asset)] = until.Sub(now).Seconds()
+    }
+    for asset := range m.pnl.buckets {
+        windows := make(map[string]string)
+        for _, window := range m.pnl.windows {
+            windows[windowLabel(window)] = m.pnl.sum(asset, window, now).String()
+        }
+        stats.RollingPnL[m.assets.Label(asset)] = windows
+    }
+    
+    writeJSON(w, http.StatusOK, stats)
+}
+
+// breakerResetHandler serves POST /breaker/reset. Resetting the breaker
+// resumes trading, so it takes the control token like /resume.
+func (m *Monitor) breakerResetHandler(w http.ResponseWriter, r *http.Request) {
+    if !m.authorizeControl(w, r) {
+        return
+    }
+    
+    m.mutex.RLock()
+    breaker := m.breaker
+    m.mutex.RUnlock()
+    
+    if breaker == nil {
+        writeError(w, http.StatusServiceUnavailable, "no circuit breaker registered")
+        return
+    }
+    
+    breaker.ResetBreaker()
+    w.WriteHeader(http.StatusNoContent)
+}
+
+func (m *Monitor) healthHandler(w http.ResponseWriter, r *http.Request) {
+    m.mutex.RLock()
+    var failing []string
+    for endpoint, healthy := range m.rpcHealthy {
+        if !healthy {
+            failing = append(failing, "rpc:"+endpoint)
+        }
+    }
+    if m.lastTick.IsZero() || time.Since(m.lastTick) > m.maxTickGap {
+        failing = append(failing, "detector")
+    }
+    m.mutex.RUnlock()
+    
+    sort.Strings(failing)
+    
+    health := Health{Status: "ok"}
+    status := http.StatusOK
+    if len(failing) > 0 {
+        health = Health{Status: "unhealthy", Failing: failing}
+        status = http.StatusServiceUnavailable
     }
     
-    w.Header().Set("Content-Type", "application/json")
-    w.Write([]byte(`{
-        "uptime_seconds": ` + string(rune(int(uptime.Seconds()))) + `,
-        "total_executions": ` + string(rune(m.totalExecutions)) + `,
-        "total_profit": "` + m.totalProfit.String() + `",
-        "average_profit": "` + avgProfit.String() + `"
-    }`))
+    writeJSON(w, status, health)
 }
\ No newline at end of file
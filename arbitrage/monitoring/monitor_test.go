@@ -0,0 +1,204 @@
+package monitoring
+
+import (
+    "encoding/json"
+    "io"
+    "math/big"
+    "net"
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "testing"
+    "time"
+)
+
+// execution is one RecordExecution call.
+type execution struct {
+    profit  int64
+    success bool
+}
+
+func TestStatsHandler(t *testing.T) {
+    tests := []struct {
+        name       string
+        executions []execution
+        wantCount  uint64
+        wantTotal  string
+        wantAvg    string
+    }{
+        {"no executions", nil, 0, "0", "0"},
+        {"one execution", []execution{{250000000, true}}, 1, "250000000", "250000000"},
+        {
+            name:       "failures are not counted",
+            executions: []execution{{100000000, true}, {900000000, false}, {200000001, true}},
+            wantCount:  2,
+            wantTotal:  "300000001",
+            wantAvg:    "150000001",
+        },
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            m := NewMonitor(nil, nil)
+            for _, e := range tt.executions {
+                m.RecordExecution(0, big.NewInt(e.profit), e.success)
+            }
+            
+            rec := httptest.NewRecorder()
+            m.statsHandler(rec, httptest.NewRequest(http.MethodGet, "/stats", nil))
+            if rec.Code != http.StatusOK {
+                t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+            }
+            
+            var raw map[string]json.RawMessage
+            if err := json.Unmarshal(rec.Body.Bytes(), &raw); err != nil {
+                t.Fatalf("response is not JSON: %v\n%s", err, rec.Body)
+            }
+            for _, field := range []string{"uptime_seconds", "total_executions", "total_profit", "average_profit"} {
+                if _, ok := raw[field]; !ok {
+                    t.Errorf("response lacks %s", field)
+                }
+            }
+            
+            var stats Stats
+            if err := json.Unmarshal(rec.Body.Bytes(), &stats); err != nil {
+                t.Fatalf("decode stats: %v", err)
+            }
+            if stats.TotalExecutions != tt.wantCount {
+                t.Errorf("total_executions = %d, want %d", stats.TotalExecutions, tt.wantCount)
+            }
+            if stats.TotalProfit != tt.wantTotal {
+                t.Errorf("total_profit = %s, want %s", stats.TotalProfit, tt.wantTotal)
+            }
+            if stats.AverageProfit != tt.wantAvg {
+                t.Errorf("average_profit = %s, want %s", stats.AverageProfit, tt.wantAvg)
+            }
+        })
+    }
+}
+
+func TestServeMetrics(t *testing.T) {
+    listener, err := net.Listen("tcp", "127.0.0.1:0")
+    if err != nil {
+        t.Fatalf("listen: %v", err)
+    }
+    m := NewMonitor(nil, nil)
+    m.RecordOpportunity(0, 42)
+    
+    served := make(chan error, 1)
+    go func() { served <- m.Serve(listener, "", "") }()
+    defer listener.Close()
+    
+    resp, err := http.Get("http://" + listener.Addr().String() + "/metrics")
+    if err != nil {
+        t.Fatalf("scrape /metrics: %v", err)
+    }
+    defer resp.Body.Close()
+    body, err := io.ReadAll(resp.Body)
+    if err != nil {
+        t.Fatalf("read /metrics: %v", err)
+    }
+    
+    if resp.StatusCode != http.StatusOK {
+        t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+    }
+    if !strings.Contains(string(body), "arbitrage_opportunities_total") {
+        t.Fatalf("/metrics lacks arbitrage_opportunities_total:\n%s", body)
+    }
+    
+    select {
+    case err := <-served:
+        t.Fatalf("server stopped while serving: %v", err)
+    default:
+    }
+}
+
+func TestStartFailsOnBusyPort(t *testing.T) {
+    listener, err := net.Listen("tcp", "127.0.0.1:0")
+    if err != nil {
+        t.Fatalf("listen: %v", err)
+    }
+    defer listener.Close()
+    
+    if err := NewMonitor(nil, nil).Start(listener.Addr().String(), "", ""); err == nil {
+        t.Fatal("Start on a port in use succeeded, want an error")
+    }
+}
+
+func TestMonitorsHaveSeparateRegistries(t *testing.T) {
+    first := NewMonitor([]time.Duration{time.Hour}, nil)
+    second := NewMonitor([]time.Duration{time.Hour}, nil)
+    first.RecordOpportunity(0, 42)
+    
+    tests := []struct {
+        name    string
+        monitor *Monitor
+        want    float64
+    }{
+        {"recording monitor", first, 1},
+        {"other monitor", second, 0},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            families, err := tt.monitor.registry.Gather()
+            if err != nil {
+                t.Fatalf("gather: %v", err)
+            }
+            
+            var got float64
+            for _, family := range families {
+                if family.GetName() != "arbitrage_opportunities_total" {
+                    continue
+                }
+                for _, metric := range family.GetMetric() {
+                    got += metric.GetCounter().GetValue()
+                }
+            }
+            if got != tt.want {
+                t.Fatalf("arbitrage_opportunities_total = %v, want %v", got, tt.want)
+            }
+        })
+    }
+}
+
+func TestRecordExecutionKeepsSubDollarProfit(t *testing.T) {
+    tests := []struct {
+        name      string
+        execution execution
+        wantCount uint64
+        wantSum   float64
+    }{
+        {"fifty cents", execution{50000000, true}, 1, 0.5},
+        {"one cent", execution{1000000, true}, 1, 0.01},
+        {"whole dollars", execution{250000000, true}, 1, 2.5},
+        {"failure not observed", execution{50000000, false}, 0, 0},
+        {"zero profit not observed", execution{0, true}, 0, 0},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            m := NewMonitor(nil, nil)
+            m.RecordExecution(0, big.NewInt(tt.execution.profit), tt.execution.success)
+            
+            families, err := m.registry.Gather()
+            if err != nil {
+                t.Fatalf("gather: %v", err)
+            }
+            
+            var count uint64
+            var sum float64
+            for _, family := range families {
+                if family.GetName() != "arbitrage_profit_usd" {
+                    continue
+                }
+                for _, metric := range family.GetMetric() {
+                    count += metric.GetHistogram().GetSampleCount()
+                    sum += metric.GetHistogram().GetSampleSum()
+                }
+            }
+            if count != tt.wantCount || sum != tt.wantSum {
+                t.Fatalf("arbitrage_profit_usd observed %d samples summing to %v, want %d summing to %v", count, sum, tt.wantCount, tt.wantSum)
+            }
+        })
+    }
+}
\ No newline at end of file
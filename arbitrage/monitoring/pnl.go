@@ -0,0 +1,122 @@
+package monitoring
+
+import (
+    "math/big"
+    "strings"
+    "time"
+
+    "github.com/hypercore-suite/arbitrage/pricing"
+    "github.com/prometheus/client_golang/prometheus"
+)
+
+// maxPnLBuckets bounds the ring buffer kept per asset. Buckets are one
+// minute wide unless the longest window needs wider ones to fit.
+const maxPnLBuckets = 1440
+
+// rollingPnL sums realized PnL per asset over trailing windows. Each asset
+// has a fixed-size ring of time buckets; a bucket is reset when its slot is
+// reused, so expired PnL is evicted and memory does not grow with uptime.
+type rollingPnL struct {
+    windows []time.Duration
+    width   time.Duration
+    size    int
+    buckets map[uint32][]pnlBucket
+}
+
+type pnlBucket struct {
+    slot int64
+    pnl  *big.Int
+}
+
+func newRollingPnL(windows []time.Duration) *rollingPnL {
+    var longest time.Duration
+    for _, w := range windows {
+        if w > longest {
+            longest = w
+        }
+    }
+    
+    width := time.Minute
+    if longest/maxPnLBuckets > width {
+        width = longest / maxPnLBuckets
+    }
+    
+    return &rollingPnL{
+        windows: windows,
+        width:   width,
+        size:    int(longest/width) + 1,
+        buckets: make(map[uint32][]pnlBucket),
+    }
+}
+
+func (r *rollingPnL) add(asset uint32, pnl *big.Int, now time.Time) {
+    if len(r.windows) == 0 {
+        return
+    }
+    
+    ring, ok := r.buckets[asset]
+    if !ok {
+        ring = make([]pnlBucket, r.size)
+        r.buckets[asset] = ring
+    }
+    
+    slot := now.UnixNano() / int64(r.width)
+    b := &ring[slot%int64(len(ring))]
+    if b.slot != slot || b.pnl == nil {
+        b.slot = slot
+        b.pnl = new(big.Int)
+    }
+    b.pnl.Add(b.pnl, pnl)
+}
+
+// sum returns the PnL of asset over the window ending at now, to the
+// resolution of one bucket.
+func (r *rollingPnL) sum(asset uint32, window time.Duration, now time.Time) *big.Int {
+    total := new(big.Int)
+    current := now.UnixNano() / int64(r.width)
+    oldest := current - int64(window/r.width)
+    
+    for _, b := range r.buckets[asset] {
+        if b.pnl != nil && b.slot > oldest && b.slot <= current {
+            total.Add(total, b.pnl)
+        }
+    }
+    return total
+}
+
+// windowLabel formats a window without zero units, e.g. "1h" or "1h30m".
+func windowLabel(window time.Duration) string {
+    label := window.String()
+    if strings.HasSuffix(label, "m0s") {
+        label = label[:len(label)-2]
+    }
+    if strings.HasSuffix(label, "h0m") {
+        label = label[:len(label)-2]
+    }
+    return label
+}
+
+// pnlCollector exports rolling PnL as gauges computed at scrape time, so
+// windows keep rolling forward between trades.
+type pnlCollector struct {
+    monitor *Monitor
+    desc    *prometheus.Desc
+}
+
+func (c *pnlCollector) Describe(ch chan<- *prometheus.Desc) {
+    ch <- c.desc
+}
+
+func (c *pnlCollector) Collect(ch chan<- prometheus.Metric) {
+    m := c.monitor
+    m.mutex.RLock()
+    defer m.mutex.RUnlock()
+    
+    now := time.Now()
+    for asset := range m.pnl.buckets {
+        for _, window := range m.pnl.windows {
+            usd := pricing.ToUSD(m.pnl.sum(asset, window, now), pricing.USDDecimals)
+            ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, usd, m.assets.Label(asset), windowLabel(window))
+        }
+    }
+}
\ No newline at end of file
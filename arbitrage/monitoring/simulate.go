@@ -0,0 +1,68 @@
+package monitoring
+
+import (
+    "encoding/json"
+    "math/big"
+    "net/http"
+
+    "github.com/hypercore-suite/arbitrage/backtest"
+    "github.com/hypercore-suite/arbitrage/detector"
+)
+
+// Simulator judges a hypothetical quote with the live detection and profit
+// logic, for the /simulate endpoint.
+type Simulator interface {
+    Simulate(q detector.Quote) backtest.Verdict
+}
+
+// simulateRequest is the body of POST /simulate. Asset is an asset id or
+// registry symbol; prices are in USD at trade.PriceDecimals.
+type simulateRequest struct {
+    Asset     string   `json:"asset"`
+    PerpPrice *big.Int `json:"perp_price"`
+    SpotPrice *big.Int `json:"spot_price"`
+}
+
+// SetSimulator registers the simulator behind POST /simulate; the endpoint
+// answers 503 until one is set.
+func (m *Monitor) SetSimulator(s Simulator) {
+    m.mutex.Lock()
+    defer m.mutex.Unlock()
+    m.simulator = s
+}
+
+// simulateHandler serves POST /simulate, returning the detector's and
+// executor's verdict on the posted prices without touching live feeds.
+func (m *Monitor) simulateHandler(w http.ResponseWriter, r *http.Request) {
+    m.mutex.RLock()
+    simulator := m.simulator
+    m.mutex.RUnlock()
+    
+    if simulator == nil {
+        writeError(w, http.StatusServiceUnavailable, "simulator not configured")
+        return
+    }
+    
+    var req simulateRequest
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        writeError(w, http.StatusBadRequest, "invalid request body")
+        return
+    }
+    asset, err := m.resolveAsset(req.Asset)
+    if err != nil {
+        writeError(w, http.StatusBadRequest, "unknown asset")
+        return
+    }
+    if req.PerpPrice == nil || req.PerpPrice.Sign() <= 0 || req.SpotPrice == nil || req.SpotPrice.Sign() <= 0 {
+        writeError(w, http.StatusBadRequest, "perp_price and spot_price must be positive")
+        return
+    }
+    
+    verdict := simulator.Simulate(detector.Quote{
+        Asset:     asset,
+        PerpPrice: req.PerpPrice,
+        SpotPrice: req.SpotPrice,
+    })
+    
+    writeJSON(w, http.StatusOK, verdict)
+}
\ No newline at end of file
@@ -0,0 +1,98 @@
+package monitoring
+
+import (
+    "encoding/json"
+    "net/http"
+
+    "github.com/hypercore-suite/arbitrage/thresholds"
+    "github.com/sirupsen/logrus"
+)
+
+// ThresholdStore is implemented by the holder the detector and executor read
+// their thresholds from, served and replaced through /config.
+type ThresholdStore interface {
+    Load() *thresholds.Set
+    Store(s thresholds.Set) (thresholds.Set, error)
+}
+
+// SetThresholds registers the thresholds behind /config; the endpoint
+// answers 503 until they are set.
+func (m *Monitor) SetThresholds(s ThresholdStore) {
+    m.mutex.Lock()
+    defer m.mutex.Unlock()
+    m.thresholds = s
+}
+
+// configHandler serves GET /config, the current thresholds behind the stats
+// token, and POST /config, which replaces them behind the control token.
+func (m *Monitor) configHandler(w http.ResponseWriter, r *http.Request) {
+    if r.Method == http.MethodGet {
+        m.requireStatsToken(http.HandlerFunc(m.configGetHandler), false).ServeHTTP(w, r)
+        return
+    }
+    if m.authorizeControl(w, r) {
+        m.configPostHandler(w, r)
+    }
+}
+
+func (m *Monitor) configGetHandler(w http.ResponseWriter, r *http.Request) {
+    store := m.thresholdStore(w)
+    if store == nil {
+        return
+    }
+    writeJSON(w, http.StatusOK, store.Load())
+}
+
+// configPostHandler applies the posted thresholds over the current ones:
+// fields left out of the body keep their value, and an asset_min_spread_bps
+// object replaces every per-asset override. The new set is validated as a
+// whole and applied at once, or not at all.
+func (m *Monitor) configPostHandler(w http.ResponseWriter, r *http.Request) {
+    store := m.thresholdStore(w)
+    if store == nil {
+        return
+    }
+    
+    current := store.Load()
+    next := *current
+    next.AssetMinSpreadBps = nil
+    dec := json.NewDecoder(r.Body)
+    dec.DisallowUnknownFields()
+    if err := dec.Decode(&next); err != nil {
+        writeError(w, http.StatusBadRequest, "invalid request body")
+        return
+    }
+    if next.AssetMinSpreadBps == nil {
+        next.AssetMinSpreadBps = current.AssetMinSpreadBps
+    }
+    
+    previous, err := store.Store(next)
+    if err != nil {
+        writeError(w, http.StatusBadRequest, err.Error())
+        return
+    }
+    
+    m.mutex.RLock()
+    logger := m.logger
+    m.mutex.RUnlock()
+    logger.WithFields(logrus.Fields{
+        "remote_addr": r.RemoteAddr,
+        "previous":    previous,
+        "thresholds":  next,
+    }).Warn("Thresholds changed through the admin endpoint")
+    
+    writeJSON(w, http.StatusOK, store.Load())
+}
+
+// thresholdStore returns the registered thresholds, or writes the error
+// response and returns nil.
+func (m *Monitor) thresholdStore(w http.ResponseWriter) ThresholdStore {
+    m.mutex.RLock()
+    store := m.thresholds
+    m.mutex.RUnlock()
+    
+    if store == nil {
+        writeError(w, http.StatusServiceUnavailable, "thresholds not configured")
+    }
+    return store
+}
\ No newline at end of file
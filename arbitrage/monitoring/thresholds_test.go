@@ -0,0 +1,66 @@
+package monitoring
+
+import (
+    "io"
+    "math/big"
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/hypercore-suite/arbitrage/config"
+    "github.com/hypercore-suite/arbitrage/detector"
+    "github.com/sirupsen/logrus"
+)
+
+func TestPostConfigChangesDetection(t *testing.T) {
+    logger := logrus.New()
+    logger.SetOutput(io.Discard)
+    m := NewMonitor(nil, nil)
+    m.SetLogger(logger)
+    m.SetController(nil, "s3cret")
+    
+    // The detector starts at the default 10 bps minimum spread and shares
+    // its thresholds with /config, as main wires them.
+    det := detector.NewReplayDetector(logger, m, config.Default())
+    m.SetThresholds(det.Thresholds)
+    handler := m.endpoint(http.HandlerFunc(m.configHandler), http.MethodGet, http.MethodPost)
+    
+    start := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+    steps := []struct {
+        name     string
+        body     string
+        wantCode int
+        wantOpp  bool
+    }{
+        {"15 bps clears the configured minimum", "", 0, true},
+        {"raised above it", `{"detector_min_spread_bps": 20}`, http.StatusOK, false},
+        {"invalid set leaves it raised", `{"detector_min_spread_bps": 0}`, http.StatusBadRequest, false},
+        {"lowered for the asset alone", `{"asset_min_spread_bps": {"0": 5}}`, http.StatusOK, true},
+        {"override cleared", `{"asset_min_spread_bps": {}}`, http.StatusOK, false},
+    }
+    for i, step := range steps {
+        if step.body != "" {
+            req := httptest.NewRequest(http.MethodPost, "/config", strings.NewReader(step.body))
+            req.Header.Set("Authorization", "Bearer s3cret")
+            rec := httptest.NewRecorder()
+            handler.ServeHTTP(rec, req)
+            if rec.Code != step.wantCode {
+                t.Fatalf("%s: status = %d, want %d: %s", step.name, rec.Code, step.wantCode, rec.Body)
+            }
+        }
+        
+        // A 15 bps spread, an hour after the last so it is never a
+        // duplicate.
+        opp := det.Evaluate(detector.Quote{
+            Time:      start.Add(time.Duration(i) * time.Hour),
+            Asset:     0,
+            PerpPrice: big.NewInt(10015000000),
+            SpotPrice: big.NewInt(10000000000),
+        })
+        if got := opp != nil; got != step.wantOpp {
+            t.Fatalf("%s: detected = %v, want %v", step.name, got, step.wantOpp)
+        }
+    }
+}
@@ -0,0 +1,163 @@
+// Package preflight checks, before the bot starts trading, that its
+// configuration is usable and everything it depends on is reachable: both
+// RPC endpoints, the wallets' gas, the arbitrage contracts and the oracle
+// prices of every configured asset.
+package preflight
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "math/big"
+    "strings"
+
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/hypercore-suite/arbitrage/config"
+    "github.com/hypercore-suite/arbitrage/detector"
+    "github.com/hypercore-suite/arbitrage/executor"
+)
+
+// Chain is the part of an RPC client the checks use; *ethclient.Client
+// satisfies it.
+type Chain interface {
+    ChainID(ctx context.Context) (*big.Int, error)
+    BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+    CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error)
+}
+
+// Check is the outcome of one preflight check; Err is nil when it passed.
+type Check struct {
+    Name string
+    Err  error
+}
+
+// Report lists every check in the order it ran.
+type Report []Check
+
+// Failed returns the checks that did not pass.
+func (r Report) Failed() []Check {
+    var failed []Check
+    for _, check := range r {
+        if check.Err != nil {
+            failed = append(failed, check)
+        }
+    }
+    return failed
+}
+
+// Err summarizes every failed check in one error, or returns nil when all
+// passed.
+func (r Report) Err() error {
+    failed := r.Failed()
+    if len(failed) == 0 {
+        return nil
+    }
+    
+    var msgs []string
+    for _, check := range failed {
+        msgs = append(msgs, fmt.Sprintf("%s: %v", check.Name, check.Err))
+    }
+    return fmt.Errorf("%d preflight checks failed: %s", len(failed), strings.Join(msgs, "; "))
+}
+
+// Run checks cfg against the HyperCore and HyperEVM endpoints and the price
+// source the detector will read. Every check runs even after one fails, so
+// the report shows everything that is wrong at once; the wallet and contract
+// checks are reported as one credentials failure when the keys or contract
+// addresses are invalid.
+func Run(ctx context.Context, cfg *config.Config, core, evm Chain, prices detector.PriceSource) Report {
+    var report Report
+    add := func(name string, err error) {
+        report = append(report, Check{Name: name, Err: err})
+    }
+    
+    add("config", thresholds(cfg))
+    add("hypercore_rpc", chainID(ctx, core))
+    add("hyperevm_rpc", chainID(ctx, evm))
+    
+    addresses, err := executor.WalletAddresses(cfg.Executor)
+    if err == nil {
+        err = cfg.ValidateCredentials()
+    }
+    if err != nil {
+        add("credentials", err)
+    } else {
+        for _, address := range addresses {
+            add("gas_balance "+address.Hex(), gasBalance(ctx, evm, address, cfg.Executor.MinGasBalance))
+        }
+        for _, route := range cfg.Executor.RouteList() {
+            add("contract "+route.Name, contractCode(ctx, evm, common.HexToAddress(route.Contract)))
+        }
+    }
+    
+    registry := cfg.Registry()
+    for _, asset := range cfg.Detector.Assets {
+        add("oracle "+registry.Label(asset), oraclePrices(ctx, prices, asset))
+    }
+    return report
+}
+
+// thresholds re-validates cfg and checks that its thresholds leave the
+// executor something to trade.
+func thresholds(cfg *config.Config) error {
+    if err := cfg.Validate(); err != nil {
+        return err
+    }
+    if cfg.Executor.MinNetProfit <= 0 {
+        return errors.New("executor.min_net_profit must be positive, or every trade is worth sending")
+    }
+    if cfg.Executor.MinSpreadBps >= 10000 || cfg.Detector.MinSpreadBps >= 10000 {
+        return errors.New("spread thresholds of 10000 bps or more never trade")
+    }
+    return nil
+}
+
+func chainID(ctx context.Context, client Chain) error {
+    id, err := client.ChainID(ctx)
+    if err != nil {
+        return fmt.Errorf("chain id query failed: %w", err)
+    }
+    if id == nil || id.Sign() <= 0 {
+        return fmt.Errorf("invalid chain id %v", id)
+    }
+    return nil
+}
+
+// gasBalance requires a non-zero native balance, and at least floor wei when
+// a gas floor is configured.
+func gasBalance(ctx context.Context, client Chain, address common.Address, floor uint64) error {
+    balance, err := client.BalanceAt(ctx, address, nil)
+    if err != nil {
+        return fmt.Errorf("balance query failed: %w", err)
+    }
+    if balance.Sign() == 0 {
+        return errors.New("wallet has no gas")
+    }
+    if minBalance := new(big.Int).SetUint64(floor); balance.Cmp(minBalance) < 0 {
+        return fmt.Errorf("balance %s wei below the %s wei gas floor", balance, minBalance)
+    }
+    return nil
+}
+
+func contractCode(ctx context.Context, client Chain, address common.Address) error {
+    code, err := client.CodeAt(ctx, address, nil)
+    if err != nil {
+        return fmt.Errorf("code query failed: %w", err)
+    }
+    if len(code) == 0 {
+        return fmt.Errorf("no contract deployed at %s", address.Hex())
+    }
+    return nil
+}
+
+func oraclePrices(ctx context.Context, prices detector.PriceSource, asset uint32) error {
+    perp, _ := prices.PerpPrice(ctx, asset)
+    spot, _ := prices.SpotPrice(ctx, asset)
+    switch {
+    case perp == nil || perp.Sign() <= 0:
+        return errors.New("perp oracle returned no price")
+    case spot == nil || spot.Sign() <= 0:
+        return errors.New("spot oracle returned no price")
+    }
+    return nil
+}
\ No newline at end of file
@@ -0,0 +1,180 @@
+package preflight
+
+import (
+    "context"
+    "errors"
+    "math/big"
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+    "time"
+
+    "github.com/ethereum/go-ethereum/common"
+    "github.com/hypercore-suite/arbitrage/config"
+)
+
+// stubChain answers every query with the same canned result.
+type stubChain struct {
+    chainID  *big.Int
+    chainErr error
+    balance  *big.Int
+    code     []byte
+}
+
+func (c stubChain) ChainID(context.Context) (*big.Int, error) {
+    return c.chainID, c.chainErr
+}
+
+func (c stubChain) BalanceAt(context.Context, common.Address, *big.Int) (*big.Int, error) {
+    return c.balance, nil
+}
+
+func (c stubChain) CodeAt(context.Context, common.Address, *big.Int) ([]byte, error) {
+    return c.code, nil
+}
+
+// healthyChain is a reachable chain where the wallet holds one native token
+// and the contract is deployed.
+func healthyChain() stubChain {
+    return stubChain{
+        chainID: big.NewInt(999),
+        balance: new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil),
+        code:    []byte{0x60, 0x80},
+    }
+}
+
+// stubPrices prices every asset at $100 except those listed as missing.
+type stubPrices struct {
+    noPerp, noSpot map[uint32]bool
+}
+
+func (p stubPrices) PerpPrice(ctx context.Context, asset uint32) (*big.Int, time.Time) {
+    if p.noPerp[asset] {
+        return nil, time.Time{}
+    }
+    return big.NewInt(10000000000), time.Now()
+}
+
+func (p stubPrices) SpotPrice(ctx context.Context, asset uint32) (*big.Int, time.Time) {
+    if p.noSpot[asset] {
+        return nil, time.Time{}
+    }
+    return big.NewInt(10000000000), time.Now()
+}
+
+// loadConfig loads a configuration trading live through one wallet and the
+// default route.
+func loadConfig(t *testing.T) *config.Config {
+    t.Helper()
+    
+    lines := []string{
+        "executor:",
+        "  private_key: 4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318",
+        "  arb_contract: \"0x00000000000000000000000000000000000a4b17\"",
+        "  native_token_price: 2000000000",
+    }
+    path := filepath.Join(t.TempDir(), "config.yaml")
+    if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o600); err != nil {
+        t.Fatalf("write config: %v", err)
+    }
+    cfg, err := config.Load(path)
+    if err != nil {
+        t.Fatalf("load config: %v", err)
+    }
+    return cfg
+}
+
+func TestRun(t *testing.T) {
+    tests := []struct {
+        name       string
+        configure  func(*config.Config)
+        core, evm  func(*stubChain)
+        prices     stubPrices
+        wantFailed []string
+    }{
+        {name: "everything passes"},
+        {
+            name:       "nothing left to trade",
+            configure:  func(cfg *config.Config) { cfg.Executor.MinNetProfit = 0 },
+            wantFailed: []string{"config"},
+        },
+        {
+            name:       "hypercore unreachable",
+            core:       func(c *stubChain) { c.chainErr = errors.New("connection refused") },
+            wantFailed: []string{"hypercore_rpc"},
+        },
+        {
+            name:       "hyperevm reports no chain id",
+            evm:        func(c *stubChain) { c.chainID = big.NewInt(0) },
+            wantFailed: []string{"hyperevm_rpc"},
+        },
+        {
+            name:       "wallet has no gas",
+            evm:        func(c *stubChain) { c.balance = new(big.Int) },
+            wantFailed: []string{"gas_balance "},
+        },
+        {
+            name:       "wallet below the gas floor",
+            evm:        func(c *stubChain) { c.balance = big.NewInt(249999999999999999) },
+            wantFailed: []string{"gas_balance "},
+        },
+        {
+            name:       "contract not deployed",
+            evm:        func(c *stubChain) { c.code = nil },
+            wantFailed: []string{"contract default"},
+        },
+        {
+            name:       "invalid key skips the wallet and contract checks",
+            configure:  func(cfg *config.Config) { cfg.Executor.PrivateKey = "not-a-key" },
+            evm:        func(c *stubChain) { c.balance, c.code = new(big.Int), nil },
+            wantFailed: []string{"credentials"},
+        },
+        {
+            name:       "missing oracle prices",
+            prices:     stubPrices{noPerp: map[uint32]bool{1: true}, noSpot: map[uint32]bool{0: true}},
+            wantFailed: []string{"oracle BTC", "oracle ETH"},
+        },
+        {
+            name:       "every failure is reported",
+            core:       func(c *stubChain) { c.chainErr = errors.New("timeout") },
+            evm:        func(c *stubChain) { c.code = nil },
+            prices:     stubPrices{noSpot: map[uint32]bool{1: true}},
+            wantFailed: []string{"hypercore_rpc", "contract default", "oracle ETH"},
+        },
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            cfg := loadConfig(t)
+            if tt.configure != nil {
+                tt.configure(cfg)
+            }
+            core, evm := healthyChain(), healthyChain()
+            if tt.core != nil {
+                tt.core(&core)
+            }
+            if tt.evm != nil {
+                tt.evm(&evm)
+            }
+            
+            report := Run(context.Background(), cfg, core, evm, tt.prices)
+            failed := report.Failed()
+            var names []string
+            for _, check := range failed {
+                names = append(names, check.Name)
+            }
+            if len(failed) != len(tt.wantFailed) {
+                t.Fatalf("failed checks = %v, want %v", names, tt.wantFailed)
+            }
+            for i, want := range tt.wantFailed {
+                if !strings.HasPrefix(names[i], want) {
+                    t.Fatalf("failed checks = %v, want %v", names, tt.wantFailed)
+                }
+            }
+            if (report.Err() == nil) != (len(tt.wantFailed) == 0) {
+                t.Fatalf("Err() = %v with %d failed checks", report.Err(), len(failed))
+            }
+        })
+    }
+}
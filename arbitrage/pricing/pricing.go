@@ -0,0 +1,122 @@
+// Package pricing defines the fixed-point scales used for prices and USD
+// amounts, and the conversions between them.
+package pricing
+
+import (
+    "fmt"
+    "math/big"
+    "strings"
+)
+
+const (
+    // USDDecimals is the scale of every price, spread, trade size and USD
+    // amount the bot handles: 1e8 units per dollar.
+    USDDecimals = 8
+    
+    // NativeDecimals is the scale of native token amounts such as gas fees
+    // in wei.
+    NativeDecimals = 18
+)
+
+// Pow10 returns 10^n.
+func Pow10(n int) *big.Int {
+    return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// Rounding selects which way a fixed-point division goes when it drops
+// precision. Amounts that decide whether to trade round against the trade, so
+// a borderline opportunity is never judged better than it is: profits and
+// spreads RoundDown, costs and notionals charged against caps RoundUp.
+// Amounts that are only reported or recorded, such as settled gas costs,
+// averages and alert text, use RoundHalfUp to stay accurate.
+type Rounding int
+
+const (
+    // RoundDown rounds toward negative infinity.
+    RoundDown Rounding = iota
+    // RoundUp rounds toward positive infinity.
+    RoundUp
+    // RoundHalfUp rounds to the nearest value, halves away from zero.
+    RoundHalfUp
+)
+
+// Div returns x / y rounded by mode. y must not be zero.
+func Div(x, y *big.Int, mode Rounding) *big.Int {
+    q, r := new(big.Int).QuoRem(x, y, new(big.Int))
+    if r.Sign() == 0 {
+        return q
+    }
+    
+    // Quo truncates toward zero; step away from zero where mode says to.
+    negative := (x.Sign() < 0) != (y.Sign() < 0)
+    away := false
+    switch mode {
+    case RoundDown:
+        away = negative
+    case RoundUp:
+        away = !negative
+    case RoundHalfUp:
+        twice := new(big.Int).Lsh(r.Abs(r), 1)
+        away = twice.Cmp(new(big.Int).Abs(y)) >= 0
+    }
+    if !away {
+        return q
+    }
+    if negative {
+        return q.Sub(q, big.NewInt(1))
+    }
+    return q.Add(q, big.NewInt(1))
+}
+
+// MulDiv returns x * y / z rounded by mode, without losing precision in the
+// product. z must not be zero.
+func MulDiv(x, y, z *big.Int, mode Rounding) *big.Int {
+    return Div(new(big.Int).Mul(x, y), z, mode)
+}
+
+// Rescale converts value from one number of decimals to another, truncating
+// toward zero when precision is dropped.
+func Rescale(value *big.Int, from, to int) *big.Int {
+    switch {
+    case from < to:
+        return new(big.Int).Mul(value, Pow10(to-from))
+    case from > to:
+        return new(big.Int).Quo(value, Pow10(from-to))
+    default:
+        return new(big.Int).Set(value)
+    }
+}
+
+// ToUSD converts a fixed-point amount with the given decimals to dollars as a
+// float, keeping the fractional part, for metrics and display.
+func ToUSD(raw *big.Int, decimals int) float64 {
+    usd, _ := new(big.Float).Quo(new(big.Float).SetInt(raw), new(big.Float).SetInt(Pow10(decimals))).Float64()
+    return usd
+}
+
+// ParseDecimal parses a non-negative decimal such as "5", "0.25" or "$12.50"
+// into fixed point with the given decimals, exactly and without going through
+// a float. A leading "$" is allowed so dollar amounts can be written as such;
+// more fractional digits than decimals is an error rather than a rounding.
+func ParseDecimal(raw string, decimals int) (*big.Int, error) {
+    s := strings.TrimPrefix(strings.TrimSpace(raw), "$")
+    whole, frac, _ := strings.Cut(s, ".")
+    if whole == "" && frac == "" {
+        return nil, fmt.Errorf("invalid amount %q", raw)
+    }
+    if len(frac) > decimals {
+        return nil, fmt.Errorf("amount %q has more than %d decimal places", raw, decimals)
+    }
+    for _, part := range []string{whole, frac} {
+        if strings.Trim(part, "0123456789") != "" {
+            return nil, fmt.Errorf("invalid amount %q", raw)
+        }
+    }
+    
+    digits := whole + frac + strings.Repeat("0", decimals-len(frac))
+    value, ok := new(big.Int).SetString(digits, 10)
+    if !ok {
+        return nil, fmt.Errorf("invalid amount %q", raw)
+    }
+    return value, nil
+}
\ No newline at end of file
@@ -0,0 +1,154 @@
+package pricing
+
+import (
+    "math/big"
+    "testing"
+)
+
+func TestToUSD(t *testing.T) {
+    tests := []struct {
+        name     string
+        raw      int64
+        decimals int
+        want     float64
+    }{
+        {"whole dollars", 300000000, USDDecimals, 3},
+        {"fifty cents", 50000000, USDDecimals, 0.5},
+        {"one cent", 1000000, USDDecimals, 0.01},
+        {"one unit", 1, USDDecimals, 0.00000001},
+        {"sub-dollar loss", -25000000, USDDecimals, -0.25},
+        {"six decimals", 1500000, 6, 1.5},
+        {"zero decimals", 7, 0, 7},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := ToUSD(big.NewInt(tt.raw), tt.decimals); got != tt.want {
+                t.Fatalf("ToUSD(%d, %d) = %v, want %v", tt.raw, tt.decimals, got, tt.want)
+            }
+        })
+    }
+}
+
+func TestRescale(t *testing.T) {
+    tests := []struct {
+        name     string
+        value    int64
+        from, to int
+        want     int64
+    }{
+        {"widen", 150, 2, USDDecimals, 150000000},
+        {"narrow exactly", 150000000, USDDecimals, 2, 150},
+        {"narrow drops sub-cent", 150999999, USDDecimals, 2, 150},
+        {"narrow truncates a loss toward zero", -150999999, USDDecimals, 2, -150},
+        {"same scale", 42, USDDecimals, USDDecimals, 42},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := Rescale(big.NewInt(tt.value), tt.from, tt.to); got.Int64() != tt.want {
+                t.Fatalf("Rescale(%d, %d, %d) = %v, want %d", tt.value, tt.from, tt.to, got, tt.want)
+            }
+        })
+    }
+}
+func TestParseDecimal(t *testing.T) {
+    tests := []struct {
+        raw     string
+        want    int64
+        wantErr bool
+    }{
+        {"5", 500000000, false},
+        {"0.25", 25000000, false},
+        {"$12.50", 1250000000, false},
+        {" 7 ", 700000000, false},
+        {".5", 50000000, false},
+        {"3.", 300000000, false},
+        {"0.00000001", 1, false},
+        {"0", 0, false},
+        {"0.000000001", 0, true},
+        {"-1", 0, true},
+        {"1e3", 0, true},
+        {"1.2.3", 0, true},
+        {"$", 0, true},
+        {".", 0, true},
+        {"", 0, true},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.raw, func(t *testing.T) {
+            got, err := ParseDecimal(tt.raw, USDDecimals)
+            if tt.wantErr {
+                if err == nil {
+                    t.Fatalf("ParseDecimal(%q) = %v, want an error", tt.raw, got)
+                }
+                return
+            }
+            if err != nil {
+                t.Fatalf("ParseDecimal(%q): %v", tt.raw, err)
+            }
+            if got.Int64() != tt.want {
+                t.Fatalf("ParseDecimal(%q) = %v, want %d", tt.raw, got, tt.want)
+            }
+        })
+    }
+}
+
+func TestDiv(t *testing.T) {
+    tests := []struct {
+        name             string
+        x, y             int64
+        wantDown, wantUp int64
+        wantHalfUp       int64
+    }{
+        {"exact", 6, 3, 2, 2, 2},
+        {"zero", 0, 5, 0, 0, 0},
+        {"below the half", 4, 3, 1, 2, 1},
+        {"above the half", 5, 3, 1, 2, 2},
+        {"half", 7, 2, 3, 4, 4},
+        {"negative below the half", -4, 3, -2, -1, -1},
+        {"negative above the half", -5, 3, -2, -1, -2},
+        {"negative half", -7, 2, -4, -3, -4},
+        {"negative divisor", 7, -2, -4, -3, -4},
+        {"both negative", -7, -2, 3, 4, 4},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            for mode, want := range map[Rounding]int64{RoundDown: tt.wantDown, RoundUp: tt.wantUp, RoundHalfUp: tt.wantHalfUp} {
+                if got := Div(big.NewInt(tt.x), big.NewInt(tt.y), mode); got.Int64() != want {
+                    t.Errorf("Div(%d, %d, %d) = %v, want %d", tt.x, tt.y, mode, got, want)
+                }
+            }
+        })
+    }
+}
+
+func TestMulDiv(t *testing.T) {
+    tests := []struct {
+        name             string
+        x, y, z          int64
+        wantDown, wantUp int64
+        wantHalfUp       int64
+    }{
+        // A 30 bps fee on $1.00000001 is $0.00300000003.
+        {"fee", 100000001, 30, 10000, 300000, 300001, 300000},
+        // The product overflows int64 but the result does not.
+        {"wide product", 9223372036854775807, 2, 4, 4611686018427387903, 4611686018427387904, 4611686018427387904},
+        {"loss", -100000001, 30, 10000, -300001, -300000, -300000},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            for mode, want := range map[Rounding]int64{RoundDown: tt.wantDown, RoundUp: tt.wantUp, RoundHalfUp: tt.wantHalfUp} {
+                x := big.NewInt(tt.x)
+                if got := MulDiv(x, big.NewInt(tt.y), big.NewInt(tt.z), mode); got.Int64() != want {
+                    t.Errorf("MulDiv(%d, %d, %d, %d) = %v, want %d", tt.x, tt.y, tt.z, mode, got, want)
+                }
+                if x.Int64() != tt.x {
+                    t.Fatalf("MulDiv modified its argument to %v", x)
+                }
+            }
+        })
+    }
+}
@@ -0,0 +1,111 @@
+// Package registry maps HyperCore asset ids to their symbols and size
+// decimals, so configuration, logs and metrics can name assets instead of
+// using bare ids.
+package registry
+
+import (
+    "errors"
+    "fmt"
+    "strconv"
+    "strings"
+)
+
+// ErrUnknownAsset is returned for ids and symbols that are not registered.
+var ErrUnknownAsset = errors.New("unknown asset")
+
+// Asset is one registered asset. Decimals is the number of decimal places
+// the venue accepts in order sizes.
+type Asset struct {
+    ID       uint32 `yaml:"id"`
+    Symbol   string `yaml:"symbol"`
+    Decimals int    `yaml:"decimals"`
+}
+
+// Registry looks assets up by id and by symbol. Symbols are matched
+// case-insensitively. A nil Registry has no assets.
+type Registry struct {
+    byID     map[uint32]Asset
+    bySymbol map[string]Asset
+}
+
+// New builds a registry, rejecting duplicate ids or symbols and empty
+// symbols.
+func New(assets []Asset) (*Registry, error) {
+    r := &Registry{
+        byID:     make(map[uint32]Asset, len(assets)),
+        bySymbol: make(map[string]Asset, len(assets)),
+    }
+    for _, a := range assets {
+        symbol := strings.ToUpper(strings.TrimSpace(a.Symbol))
+        if symbol == "" {
+            return nil, fmt.Errorf("asset %d has no symbol", a.ID)
+        }
+        if _, err := strconv.ParseUint(symbol, 10, 32); err == nil {
+            return nil, fmt.Errorf("asset %d: symbol %q must not be numeric", a.ID, a.Symbol)
+        }
+        if a.Decimals < 0 {
+            return nil, fmt.Errorf("asset %s: decimals must not be negative", symbol)
+        }
+        if _, dup := r.byID[a.ID]; dup {
+            return nil, fmt.Errorf("asset id %d registered more than once", a.ID)
+        }
+        if _, dup := r.bySymbol[symbol]; dup {
+            return nil, fmt.Errorf("asset symbol %s registered more than once", symbol)
+        }
+        
+        a.Symbol = symbol
+        r.byID[a.ID] = a
+        r.bySymbol[symbol] = a
+    }
+    return r, nil
+}
+
+// Lookup returns the asset registered under id.
+func (r *Registry) Lookup(id uint32) (Asset, error) {
+    if r != nil {
+        if a, ok := r.byID[id]; ok {
+            return a, nil
+        }
+    }
+    return Asset{}, fmt.Errorf("%w: id %d", ErrUnknownAsset, id)
+}
+
+// BySymbol returns the asset registered under symbol.
+func (r *Registry) BySymbol(symbol string) (Asset, error) {
+    if r != nil {
+        if a, ok := r.bySymbol[strings.ToUpper(strings.TrimSpace(symbol))]; ok {
+            return a, nil
+        }
+    }
+    return Asset{}, fmt.Errorf("%w: symbol %q", ErrUnknownAsset, symbol)
+}
+
+// Resolve looks up ref as a numeric id or, failing that, as a symbol.
+func (r *Registry) Resolve(ref string) (Asset, error) {
+    ref = strings.TrimSpace(ref)
+    if id, err := strconv.ParseUint(ref, 10, 32); err == nil {
+        return r.Lookup(uint32(id))
+    }
+    return r.BySymbol(ref)
+}
+
+// Label returns the symbol for id, or "asset_<id>" for unregistered ids,
+// which can never collide with a symbol.
+func (r *Registry) Label(id uint32) string {
+    if a, err := r.Lookup(id); err == nil {
+        return a.Symbol
+    }
+    return "asset_" + strconv.FormatUint(uint64(id), 10)
+}
+
+// Assets returns every registered asset.
+func (r *Registry) Assets() []Asset {
+    if r == nil {
+        return nil
+    }
+    assets := make([]Asset, 0, len(r.byID))
+    for _, a := range r.byID {
+        assets = append(assets, a)
+    }
+    return assets
+}
\ No newline at end of file
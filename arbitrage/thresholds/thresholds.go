@@ -0,0 +1,113 @@
+// Package thresholds holds the trading thresholds operators can change while
+// the bot runs. The detector and executor read them from a shared Holder on
+// every use, so a new set applies from the next opportunity on.
+package thresholds
+
+import (
+    "errors"
+    "fmt"
+    "sync/atomic"
+
+    "github.com/hypercore-suite/arbitrage/config"
+)
+
+// Set is one complete set of thresholds, with the meaning and units of the
+// configuration settings they start from: spreads in basis points,
+// MinNetProfit in 1e8 USD and MaxGasPrice in wei.
+type Set struct {
+    DetectorMinSpreadBps int64            `json:"detector_min_spread_bps"`
+    AssetMinSpreadBps    map[uint32]int64 `json:"asset_min_spread_bps"`
+    ExecutorMinSpreadBps int64            `json:"executor_min_spread_bps"`
+    MinNetProfit         int64            `json:"min_net_profit"`
+    MaxGasPrice          uint64           `json:"max_gas_price"`
+    MaxGasProfitRatioBps int64            `json:"max_gas_profit_ratio_bps"`
+}
+
+// FromConfig returns the thresholds configured in cfg.
+func FromConfig(cfg *config.Config) Set {
+    s := Set{
+        DetectorMinSpreadBps: cfg.Detector.MinSpreadBps,
+        ExecutorMinSpreadBps: cfg.Executor.MinSpreadBps,
+        MinNetProfit:         cfg.Executor.MinNetProfit,
+        MaxGasPrice:          cfg.Executor.MaxGasPrice,
+        MaxGasProfitRatioBps: cfg.Executor.MaxGasProfitRatioBps,
+    }
+    s.AssetMinSpreadBps = make(map[uint32]int64, len(cfg.Detector.AssetMinSpreadBps))
+    for asset, bps := range cfg.Detector.AssetMinSpreadBps {
+        s.AssetMinSpreadBps[asset] = bps
+    }
+    return s
+}
+
+// Validate applies the configuration's checks for each threshold.
+func (s Set) Validate() error {
+    if s.DetectorMinSpreadBps <= 0 {
+        return errors.New("detector_min_spread_bps must be positive")
+    }
+    for asset, bps := range s.AssetMinSpreadBps {
+        if bps <= 0 {
+            return fmt.Errorf("asset_min_spread_bps: asset %d threshold must be positive", asset)
+        }
+    }
+    if s.ExecutorMinSpreadBps <= 0 {
+        return errors.New("executor_min_spread_bps must be positive")
+    }
+    if s.MinNetProfit < 0 {
+        return errors.New("min_net_profit must not be negative")
+    }
+    if s.MaxGasPrice == 0 {
+        return errors.New("max_gas_price must be positive")
+    }
+    if s.MaxGasProfitRatioBps < 0 {
+        return errors.New("max_gas_profit_ratio_bps must not be negative")
+    }
+    return nil
+}
+
+// MinSpreadBpsFor returns the detector's minimum spread for asset, its
+// override when it has one.
+func (s *Set) MinSpreadBpsFor(asset uint32) int64 {
+    if bps, ok := s.AssetMinSpreadBps[asset]; ok {
+        return bps
+    }
+    return s.DetectorMinSpreadBps
+}
+
+// clone returns a copy of s that shares no map with it.
+func (s Set) clone() Set {
+    overrides := make(map[uint32]int64, len(s.AssetMinSpreadBps))
+    for asset, bps := range s.AssetMinSpreadBps {
+        overrides[asset] = bps
+    }
+    s.AssetMinSpreadBps = overrides
+    return s
+}
+
+// Holder is the current Set, swapped atomically as a whole so a reader never
+// sees half of an update.
+type Holder struct {
+    current atomic.Pointer[Set]
+}
+
+// New returns a holder starting at s, which must be valid.
+func New(s Set) *Holder {
+    h := &Holder{}
+    next := s.clone()
+    h.current.Store(&next)
+    return h
+}
+
+// Load returns the current thresholds. The result must not be modified.
+func (h *Holder) Load() *Set {
+    return h.current.Load()
+}
+
+// Store validates s and makes it the current thresholds, returning the set
+// it replaced. An invalid s leaves the current thresholds in place.
+func (h *Holder) Store(s Set) (Set, error) {
+    if err := s.Validate(); err != nil {
+        return Set{}, err
+    }
+    next := s.clone()
+    return *h.current.Swap(&next), nil
+}
\ No newline at end of file
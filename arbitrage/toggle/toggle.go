@@ -0,0 +1,132 @@
+// Package toggle tracks which assets are enabled for trading. Operators flip
+// assets at runtime and the state is saved to disk so it survives restarts.
+package toggle
+
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+    "os"
+    "path/filepath"
+    "sort"
+    "sync"
+)
+
+// Assets is a concurrency-safe set of disabled assets. Every asset is enabled
+// unless it has been disabled.
+type Assets struct {
+    path  string
+    known []uint32
+    
+    mu       sync.RWMutex
+    disabled map[uint32]bool
+}
+
+// state is the on-disk form of the disabled set.
+type state struct {
+    Disabled []uint32 `json:"disabled"`
+}
+
+// Open loads the toggle state saved at path. A missing file means every
+// asset is enabled; an empty path keeps the state in memory only. known are
+// the configured assets, reported by States even while enabled.
+func Open(path string, known []uint32) (*Assets, error) {
+    a := &Assets{
+        path:     path,
+        known:    known,
+        disabled: make(map[uint32]bool),
+    }
+    if path == "" {
+        return a, nil
+    }
+    
+    raw, err := os.ReadFile(path)
+    if errors.Is(err, os.ErrNotExist) {
+        return a, nil
+    }
+    if err != nil {
+        return nil, fmt.Errorf("read asset state: %w", err)
+    }
+    
+    var saved state
+    if err := json.Unmarshal(raw, &saved); err != nil {
+        return nil, fmt.Errorf("decode asset state %s: %w", path, err)
+    }
+    for _, asset := range saved.Disabled {
+        a.disabled[asset] = true
+    }
+    return a, nil
+}
+
+// Enabled reports whether asset may be traded.
+func (a *Assets) Enabled(asset uint32) bool {
+    a.mu.RLock()
+    defer a.mu.RUnlock()
+    return !a.disabled[asset]
+}
+
+// SetEnabled enables or disables asset and saves the new state. The change
+// is kept in memory even when saving fails.
+func (a *Assets) SetEnabled(asset uint32, enabled bool) error {
+    a.mu.Lock()
+    defer a.mu.Unlock()
+    
+    if enabled {
+        delete(a.disabled, asset)
+    } else {
+        a.disabled[asset] = true
+    }
+    return a.save()
+}
+
+// States returns the enabled state of every known or disabled asset.
+func (a *Assets) States() map[uint32]bool {
+    a.mu.RLock()
+    defer a.mu.RUnlock()
+    
+    states := make(map[uint32]bool, len(a.known)+len(a.disabled))
+    for _, asset := range a.known {
+        states[asset] = true
+    }
+    for asset := range a.disabled {
+        states[asset] = false
+    }
+    return states
+}
+
+// save writes the disabled set through a temporary file so a crash never
+// leaves a truncated state file. Callers hold a.mu.
+func (a *Assets) save() error {
+    if a.path == "" {
+        return nil
+    }
+    
+    saved := state{Disabled: []uint32{}}
+    for asset := range a.disabled {
+        saved.Disabled = append(saved.Disabled, asset)
+    }
+    sort.Slice(saved.Disabled, func(i, j int) bool { return saved.Disabled[i] < saved.Disabled[j] })
+    
+    raw, err := json.Marshal(saved)
+    if err != nil {
+        return err
+    }
+    
+    tmp, err := os.CreateTemp(filepath.Dir(a.path), ".asset-state-*")
+    if err != nil {
+        return fmt.Errorf("save asset state: %w", err)
+    }
+    defer os.Remove(tmp.Name())
+    
+    if _, err := tmp.Write(raw); err != nil {
+        tmp.Close()
+        return fmt.Errorf("save asset state: %w", err)
+    }
+    if err := tmp.Close(); err != nil {
+        return fmt.Errorf("save asset state: %w", err)
+    }
+    if err := os.Rename(tmp.Name(), a.path); err != nil {
+        return fmt.Errorf("save asset state: %w", err)
+    }
+    return nil
+}
\ No newline at end of file
@@ -0,0 +1,54 @@
+package toggle
+
+import (
+    "path/filepath"
+    "reflect"
+    "testing"
+)
+
+func TestStateSurvivesReopen(t *testing.T) {
+    tests := []struct {
+        name    string
+        disable []uint32
+        enable  []uint32
+        want    map[uint32]bool
+    }{
+        {"nothing toggled", nil, nil, map[uint32]bool{0: true, 1: true}},
+        {"disabled asset stays disabled", []uint32{1}, nil, map[uint32]bool{0: true, 1: false}},
+        {"unknown asset reported once disabled", []uint32{5}, nil, map[uint32]bool{0: true, 1: true, 5: false}},
+        {"re-enabled asset stays enabled", []uint32{0}, []uint32{0}, map[uint32]bool{0: true, 1: true}},
+    }
+    
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            path := filepath.Join(t.TempDir(), "assets.json")
+            assets, err := Open(path, []uint32{0, 1})
+            if err != nil {
+                t.Fatalf("open: %v", err)
+            }
+            for _, asset := range tt.disable {
+                if err := assets.SetEnabled(asset, false); err != nil {
+                    t.Fatalf("disable %d: %v", asset, err)
+                }
+            }
+            for _, asset := range tt.enable {
+                if err := assets.SetEnabled(asset, true); err != nil {
+                    t.Fatalf("enable %d: %v", asset, err)
+                }
+            }
+            
+            reopened, err := Open(path, []uint32{0, 1})
+            if err != nil {
+                t.Fatalf("reopen: %v", err)
+            }
+            if got := reopened.States(); !reflect.DeepEqual(got, tt.want) {
+                t.Fatalf("states after reopen = %v, want %v", got, tt.want)
+            }
+            for asset, enabled := range tt.want {
+                if reopened.Enabled(asset) != enabled {
+                    t.Errorf("Enabled(%d) = %v, want %v", asset, !enabled, enabled)
+                }
+            }
+        })
+    }
+}
\ No newline at end of file
@@ -0,0 +1,67 @@
+package trade
+
+import (
+    "math/big"
+    "time"
+
+    "github.com/hypercore-suite/arbitrage/pricing"
+)
+
+// FundingRateDecimals is the fixed-point scale of hourly funding rates: a
+// rate of 1e4 is 0.01% per hour. Positive rates mean longs pay shorts.
+const FundingRateDecimals = 8
+
+// Market is the venue one leg of an arbitrage trades on.
+type Market string
+
+const (
+    MarketSpot Market = "spot"
+    MarketPerp Market = "perp"
+)
+
+// FeeModel prices the costs of a trade beyond gas that depend on the venue
+// and the operator's fee tier. Amounts are in USD at PriceDecimals and costs
+// are rounded up, towards the smaller rebate, since they decide whether to
+// trade.
+type FeeModel interface {
+    // TakerFee returns the fee for taking notional of asset on market; a
+    // negative fee is a rebate.
+    TakerFee(asset uint32, market Market, notional *big.Int) *big.Int
+    
+    // Funding returns what a perp position of notional in asset, short or
+    // long, pays over holding at the hourly rate; a negative amount is
+    // funding earned.
+    Funding(asset uint32, notional, rate *big.Int, holding time.Duration, short bool) *big.Int
+}
+
+// BpsFees is the default FeeModel: a flat taker fee in basis points of
+// notional per market, negative for a rebate, overridden for the assets in
+// Assets, and funding accrued linearly at the quoted rate.
+type BpsFees struct {
+    SpotBps int64
+    PerpBps int64
+    
+    Assets map[uint32]BpsFees
+}
+
+func (f BpsFees) TakerFee(asset uint32, market Market, notional *big.Int) *big.Int {
+    tier, ok := f.Assets[asset]
+    if !ok {
+        tier = f
+    }
+    
+    bps := tier.SpotBps
+    if market == MarketPerp {
+        bps = tier.PerpBps
+    }
+    return pricing.MulDiv(notional, big.NewInt(bps), big.NewInt(10000), pricing.RoundUp)
+}
+
+func (f BpsFees) Funding(asset uint32, notional, rate *big.Int, holding time.Duration, short bool) *big.Int {
+    paid := new(big.Int).Mul(notional, rate)
+    paid.Mul(paid, big.NewInt(int64(holding/time.Second)))
+    if short {
+        paid.Neg(paid)
+    }
+    return pricing.Div(paid, new(big.Int).Mul(big.NewInt(3600), pricing.Pow10(FundingRateDecimals)), pricing.RoundUp)
+}
\ No newline at end of file
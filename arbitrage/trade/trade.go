@@ -0,0 +1,81 @@
+// Package trade holds the types passed from opportunity producers, such as
+// the detector, to the executor, so the executor depends on neither the
+// detector nor any other particular source.
+package trade
+
+import (
+    "errors"
+    "math/big"
+    "time"
+
+    "github.com/hypercore-suite/arbitrage/pricing"
+    "github.com/sirupsen/logrus"
+)
+
+// PriceDecimals is the fixed-point scale of every price and USD amount in an
+// Opportunity: 1e8 units per dollar.
+const PriceDecimals = pricing.USDDecimals
+
+// ErrRPCUnavailable wraps failures to reach a node or get an answer from it
+// in time. They are transient: the same call may succeed later.
+var ErrRPCUnavailable = errors.New("rpc unavailable")
+
+// Kind distinguishes the shapes of opportunity producers emit.
+type Kind string
+
+const (
+    // KindPerpSpot is a perp/spot gap on one asset. It is the zero Kind.
+    KindPerpSpot Kind = ""
+    // KindCycle is a multi-hop cycle across spot pairs, described by Path.
+    // The executor cannot trade it yet and rejects it.
+    KindCycle Kind = "cycle"
+)
+
+// Hop is one leg of a cycle: From is traded for To on spot pair Pair at
+// Rate, in units of To per unit of From at PriceDecimals, before fees.
+type Hop struct {
+    Pair uint32
+    From string
+    To   string
+    Rate *big.Int
+}
+
+// Opportunity is a perp/spot price gap on one asset, sized and ready to
+// execute. CorePrice is the perp price and EVMPrice the spot price; FillPrice
+// is the expected spot fill, when known.
+//
+// A KindCycle opportunity instead trades Amount of its Path's first token
+// around the Path back to it, and carries no prices. Asset is the first
+// hop's pair and Symbol labels the path; Spread and NetSpread are the gain
+// per unit before and after fees, and SpreadBps the net gain.
+type Opportunity struct {
+    ID          string
+    Kind        Kind
+    Asset       uint32
+    Symbol      string
+    CorePrice   *big.Int
+    EVMPrice    *big.Int
+    Spread      *big.Int
+    SpreadBps   int64
+    FundingRate *big.Int
+    NetSpread   *big.Int
+    FillPrice   *big.Int
+    IsBuy       bool
+    Amount      *big.Int
+    Path        []Hop
+    Timestamp   time.Time
+}
+
+// LogFields returns the fields that identify the opportunity in log entries,
+// so a single id can be followed from detection through to the receipt.
+func (o *Opportunity) LogFields() logrus.Fields {
+    fields := logrus.Fields{
+        "opportunity_id": o.ID,
+        "asset":          o.Asset,
+        "symbol":         o.Symbol,
+    }
+    if o.Kind != KindPerpSpot {
+        fields["kind"] = o.Kind
+    }
+    return fields
+}
\ No newline at end of file